@@ -1,52 +1,305 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
 
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/config"
 	"taxiAPI/internal/endpoints"
+	"taxiAPI/internal/grpcapi"
+	"taxiAPI/internal/phone"
+	"taxiAPI/internal/pricing"
 	"taxiAPI/internal/service"
 	"taxiAPI/internal/storage"
+	"taxiAPI/internal/storage/sqlstore"
 )
 
 func main() {
-	// ✅ Initialize in-memory storage
-	rideStore := storage.NewRide()
-	passengerStore := storage.NewPassenger()
-	driverStore := storage.NewDriver()
+	cfg, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetLogLoggerLevel(cfg.LogLevel)
+
+	if err := run(cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves the REST and gRPC APIs until SIGINT or SIGTERM, then lets
+// requests in flight finish and stops the background workers.
+func run(cfg config.Config) error {
+	if !phone.ValidRegion(cfg.PhoneRegion) {
+		return fmt.Errorf("unknown phone region %q; known regions: %s", cfg.PhoneRegion, strings.Join(phone.Regions(), ", "))
+	}
+
+	// 🔐 Initialize authentication
+	secret := []byte(cfg.JWTSecret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return fmt.Errorf("generate token secret: %w", err)
+		}
+		slog.Warn("⚠️  No -jwt-secret given; tokens will not survive a restart")
+	}
+	var adminPasswordHash string
+	if cfg.AdminPassword != "" {
+		hash, err := auth.HashPassword(cfg.AdminPassword)
+		if err != nil {
+			return fmt.Errorf("admin password: %w", err)
+		}
+		adminPasswordHash = hash
+	}
+
+	// ✅ Initialize storage
+	var (
+		rideStore      service.RideStore
+		passengerStore service.PassengerStore
+		driverStore    service.DriverStore
+		offerStore     service.OfferStore
+		ratingStore    service.RatingStore
+		webhookStore   service.WebhookStore
+		transactor     service.Transactor
+	)
+	switch cfg.Store {
+	case "memory":
+		rideStore = storage.NewRide()
+		passengerStore = storage.NewPassenger()
+		driverStore = storage.NewDriver()
+		offerStore = storage.NewOffer()
+		ratingStore = storage.NewRating()
+		webhookStore = storage.NewWebhook()
+		transactor = storage.NewTransactor()
+	case "sqlite":
+		db, err := sqlstore.Open(context.Background(), cfg.DBPath)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+		defer db.Close()
+		if err := sqlstore.NormalizePhoneNumbers(context.Background(), db, cfg.PhoneRegion); err != nil {
+			return fmt.Errorf("normalize phone numbers: %w", err)
+		}
+		rideStore = sqlstore.NewRide(db)
+		passengerStore = sqlstore.NewPassenger(db)
+		driverStore = sqlstore.NewDriver(db)
+		offerStore = sqlstore.NewOffer(db)
+		ratingStore = sqlstore.NewRating(db)
+		webhookStore = sqlstore.NewWebhook(db)
+		transactor = sqlstore.NewTransactor(db)
+	default:
+		return fmt.Errorf("unknown store backend %q", cfg.Store)
+	}
+
+	// Driver positions are short-lived, so they stay in memory for every backend
+	locationStore := storage.NewLocation()
+	// Quotes expire within minutes and are kept in memory as well
+	quoteStore := storage.NewQuote()
 
 	// ✅ Initialize services
-	passengerService := service.NewPassengerService(passengerStore)
-	rideService := service.NewRideService(rideStore, passengerStore, driverStore)
-	driverService := service.NewDriverService(driverStore)
+	authService := service.NewAuthService(passengerStore, driverStore, auth.NewTokens(secret, auth.DefaultTokenTTL), adminPasswordHash, cfg.PhoneRegion)
+	passengerService := service.NewPassengerService(passengerStore, cfg.PhoneRegion)
+	pricingEngine := pricing.NewEngine(pricing.DefaultConfig())
+	surgeService := service.NewSurgeService(pricingEngine, rideStore, driverStore, locationStore)
+	pricingService := service.NewPricingService(pricingEngine, quoteStore, surgeService, service.DefaultQuoteTTL)
+	rideService := service.NewRideService(rideStore, passengerStore, driverStore, transactor, pricingService)
+	driverService := service.NewDriverService(driverStore, rideStore, locationStore, transactor, cfg.PhoneRegion)
+	ratingService := service.NewRatingService(ratingStore, rideService)
+	offerService := service.NewOfferService(offerStore, rideService, service.DefaultOfferTimeout)
+	dispatcher := service.NewDispatcher(rideService, offerService, driverStore, locationStore, service.DefaultDispatchConfig())
+	rideService.SetDispatcher(dispatcher)
+	schedulerConfig := service.DefaultSchedulerConfig()
+	schedulerConfig.LeadTime = cfg.ScheduleLeadTime
+	scheduler := service.NewScheduler(rideService, schedulerConfig)
+	eventBus := service.NewEventBus(service.DefaultEventBacklog)
+	rideService.SetEventBus(eventBus)
+	driverService.SetEventBus(eventBus)
+	webhookService := service.NewWebhookService(webhookStore, transactor, &http.Client{}, service.DefaultWebhookConfig())
+	eventBus.Listen(webhookService.Notify)
+	defer webhookService.Stop()
+	defer dispatcher.Stop()
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	// ✅ Initialize handlers and routes
+	router := newRouter(handlers{
+		auth:      endpoints.NewAuthHandler(authService),
+		passenger: endpoints.NewPassengerHandler(passengerService, authService),
+		ride:      endpoints.NewRideHandler(rideService, pricingService, ratingService),
+		driver:    endpoints.NewDriverHandler(driverService, authService),
+		offer:     endpoints.NewOfferHandler(offerService),
+		pricing:   endpoints.NewPricingHandler(surgeService),
+		webhook:   endpoints.NewWebhookHandler(webhookService),
+	}, authService)
+
+	// ✅ Start server
+	server := &http.Server{
+		Addr:         cfg.Addr,
+		Handler:      router,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+	// Event streams never finish on their own, so they are ended as soon as
+	// the shutdown starts instead of holding it up.
+	server.RegisterOnShutdown(eventBus.Close)
 
-	// ✅ Initialize handlers
-	passengerHandler := endpoints.NewPassengerHandler(passengerService)
-	rideHandler := endpoints.NewRideHandler(rideService)
-	driverHandler := endpoints.NewDriverHandler(driverService)
+	// ✅ Initialize the gRPC server; it gets its own port but calls the same
+	// services
+	var (
+		grpcServer   *grpc.Server
+		grpcListener net.Listener
+	)
+	if cfg.GRPCAddr != "" {
+		var err error
+		if grpcListener, err = net.Listen("tcp", cfg.GRPCAddr); err != nil {
+			return fmt.Errorf("listen for gRPC: %w", err)
+		}
+		grpcServer = grpcapi.NewServer(grpcapi.Services{
+			Auth:       authService,
+			Passengers: passengerService,
+			Drivers:    driverService,
+			Rides:      rideService,
+		})
+	}
 
-	// ✅ Setup router
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	serveErr := make(chan error, 2)
+	go func() {
+		slog.Info("🚀 Server running", "addr", cfg.Addr)
+		serveErr <- server.ListenAndServe()
+	}()
+	if grpcServer != nil {
+		go func() {
+			slog.Info("🚀 gRPC server running", "addr", cfg.GRPCAddr)
+			serveErr <- grpcServer.Serve(grpcListener)
+		}()
+	}
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	stop()
+	slog.Info("🛑 Shutting down; waiting for requests in flight", "timeout", cfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	err := server.Shutdown(shutdownCtx)
+	if grpcServer != nil {
+		// WatchRide streams end with the event bus, which the HTTP
+		// shutdown closed.
+		stopGRPC(shutdownCtx, grpcServer)
+	}
+	if err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	// The deferred Stop calls end dispatch, scheduling and webhook
+	// deliveries before the database is closed.
+	slog.Info("👋 Servers stopped; stopping background workers")
+	return nil
+}
+
+// stopGRPC lets the RPCs in flight finish until ctx is done and then closes
+// the connections that are left.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		server.Stop()
+		<-done
+	}
+}
+
+// handlers groups the HTTP handlers the router dispatches to.
+type handlers struct {
+	auth      *endpoints.AuthHandler
+	passenger *endpoints.PassengerHandler
+	ride      *endpoints.RideHandler
+	driver    *endpoints.DriverHandler
+	offer     *endpoints.OfferHandler
+	pricing   *endpoints.PricingHandler
+	webhook   *endpoints.WebhookHandler
+}
+
+// newRouter registers every route. Each one needs an entry in the OpenAPI
+// document (internal/endpoints/spec.go).
+func newRouter(h handlers, authService *service.AuthService) *mux.Router {
 	router := mux.NewRouter()
+	router.Use(endpoints.Authenticate(authService))
 
+	var (
+		anyone    = endpoints.Require()
+		admin     = endpoints.Require(auth.RoleAdmin)
+		driver    = endpoints.Require(auth.RoleDriver, auth.RoleAdmin)
+		passenger = endpoints.Require(auth.RolePassenger, auth.RoleAdmin)
+	)
+
+	// 🔐 Auth routes
+	router.HandleFunc("/auth/login", h.auth.Login).Methods("POST")
 	// 🧍 Passenger routes
-	router.HandleFunc("/passengers", passengerHandler.RegisterPassenger).Methods("POST")
-	router.HandleFunc("/passengers", passengerHandler.GetAllPassengers).Methods("GET")
-	router.HandleFunc("/passengers/{id}", passengerHandler.GetPassengerByID).Methods("GET")
-	router.HandleFunc("/passengers/{id}", passengerHandler.DeletePassenger).Methods("DELETE")
+	router.HandleFunc("/passengers", h.passenger.RegisterPassenger).Methods("POST")
+	router.HandleFunc("/passengers", admin(h.passenger.GetAllPassengers)).Methods("GET")
+	router.HandleFunc("/passengers/{id}", passenger(h.passenger.GetPassengerByID)).Methods("GET")
+	router.HandleFunc("/passengers/{id}", admin(h.passenger.DeletePassenger)).Methods("DELETE")
 	// 🚗 Driver routes
-	router.HandleFunc("/drivers", driverHandler.RegisterDriver).Methods("POST")
-	router.HandleFunc("/drivers", driverHandler.GetAllDrivers).Methods("GET")
-	router.HandleFunc("/drivers/{id}", driverHandler.GetDriverByID).Methods("GET")
-	router.HandleFunc("/drivers/{id}", driverHandler.DeleteDriver).Methods("DELETE")
+	router.HandleFunc("/drivers", h.driver.RegisterDriver).Methods("POST")
+	router.HandleFunc("/drivers", admin(h.driver.GetAllDrivers)).Methods("GET")
+	router.HandleFunc("/drivers/nearby", passenger(h.driver.GetNearbyDrivers)).Methods("GET")
+	router.HandleFunc("/drivers/{id}", driver(h.driver.GetDriverByID)).Methods("GET")
+	router.HandleFunc("/drivers/{id}", admin(h.driver.DeleteDriver)).Methods("DELETE")
+	router.HandleFunc("/drivers/{id}/availability", driver(h.driver.UpdateDriverAvailability)).Methods("PUT")
+	router.HandleFunc("/drivers/{id}/location", driver(h.driver.UpdateDriverLocation)).Methods("PUT")
+	router.HandleFunc("/drivers/{id}/offers", driver(h.offer.GetDriverOffers)).Methods("GET")
+	router.HandleFunc("/drivers/{id}/offers/{offerID}/accept", driver(h.offer.AcceptOffer)).Methods("POST")
+	router.HandleFunc("/drivers/{id}/offers/{offerID}/decline", driver(h.offer.DeclineOffer)).Methods("POST")
 	// 🚕 Ride routes
-	router.HandleFunc("/rides", rideHandler.CreateRide).Methods("POST")
-	router.HandleFunc("/rides", rideHandler.GetAllRides).Methods("GET")
-	router.HandleFunc("/rides/{id}", rideHandler.GetRide).Methods("GET")
-	router.HandleFunc("/rides/{id}/driver", rideHandler.AssignDriverToRide).Methods("PUT")
-	router.HandleFunc("/rides/{id}/status", rideHandler.UpdateRideStatus).Methods("PUT")
-	// ✅ Start server
-	log.Println("🚀 Server running at http://localhost:8080")
-	log.Fatal(http.ListenAndServe(":8080", router))
+	router.HandleFunc("/rides", passenger(h.ride.CreateRide)).Methods("POST")
+	router.HandleFunc("/rides", anyone(h.ride.GetAllRides)).Methods("GET")
+	router.HandleFunc("/rides/estimate", passenger(h.ride.EstimateFare)).Methods("POST")
+	router.HandleFunc("/rides/{id}", anyone(h.ride.GetRide)).Methods("GET")
+	router.HandleFunc("/rides/{id}", passenger(h.ride.EditRide)).Methods("PATCH")
+	router.HandleFunc("/rides/{id}/cancel", anyone(h.ride.CancelRide)).Methods("POST")
+	router.HandleFunc("/rides/{id}/driver", admin(h.offer.OfferRide)).Methods("PUT")
+	router.HandleFunc("/rides/{id}/events", anyone(h.ride.StreamRideEvents)).Methods("GET")
+	router.HandleFunc("/rides/{id}/history", anyone(h.ride.GetRideHistory)).Methods("GET")
+	router.HandleFunc("/rides/{id}/offers", admin(h.offer.GetRideOffers)).Methods("GET")
+	router.HandleFunc("/rides/{id}/rating", anyone(h.ride.RateRide)).Methods("POST")
+	router.HandleFunc("/rides/{id}/ratings", anyone(h.ride.GetRideRatings)).Methods("GET")
+	router.HandleFunc("/rides/{id}/status", driver(h.ride.UpdateRideStatus)).Methods("PUT")
+	router.HandleFunc("/rides/{id}/transitions", anyone(h.ride.GetRideTransitions)).Methods("GET")
+	// 💰 Pricing routes
+	router.HandleFunc("/pricing/surge", anyone(h.pricing.GetSurge)).Methods("GET")
+	// 🪝 Webhook routes
+	router.HandleFunc("/webhooks", admin(h.webhook.CreateWebhook)).Methods("POST")
+	router.HandleFunc("/webhooks", admin(h.webhook.GetAllWebhooks)).Methods("GET")
+	router.HandleFunc("/webhooks/{id}", admin(h.webhook.GetWebhook)).Methods("GET")
+	router.HandleFunc("/webhooks/{id}", admin(h.webhook.DeleteWebhook)).Methods("DELETE")
+	router.HandleFunc("/webhooks/{id}/deliveries", admin(h.webhook.GetWebhookDeliveries)).Methods("GET")
+	// 📖 API docs
+	router.HandleFunc("/openapi.json", endpoints.ServeOpenAPI).Methods("GET")
+	return router
 }
@@ -0,0 +1,46 @@
+package auth
+
+import "context"
+
+type Role string
+
+const (
+	RolePassenger Role = "passenger"
+	RoleDriver    Role = "driver"
+	RoleAdmin     Role = "admin"
+)
+
+func (r Role) IsValid() bool {
+	switch r {
+	case RolePassenger, RoleDriver, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// Identity is the authenticated caller. ID is the passenger or driver ID and
+// is 0 for admins.
+type Identity struct {
+	Role Role
+	ID   int
+}
+
+func (i Identity) IsAdmin() bool {
+	return i.Role == RoleAdmin
+}
+
+// Is reports whether the caller is the given passenger or driver.
+func (i Identity) Is(role Role, id int) bool {
+	return i.Role == role && i.ID == id && id != 0
+}
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity)
+}
+
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(contextKey{}).(Identity)
+	return identity, ok
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	customErrors "taxiAPI/internal/errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	MinPasswordLength = 8
+	// MaxPasswordLength is in bytes; bcrypt ignores anything longer.
+	MaxPasswordLength = 72
+)
+
+// dummyHash is compared against when there is no real hash, so that logins
+// to unknown accounts take as long as wrong passwords.
+const dummyHash = "$2a$10$DVWmMAgD6/2dISz.UwrfUest7n6UoU6dYttW4iRvHNbd/0AO0g.tC"
+
+func HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", customErrors.ErrPasswordRequired
+	}
+	if len(password) < MinPasswordLength {
+		return "", customErrors.ErrPasswordTooShort
+	}
+	if len(password) > MaxPasswordLength {
+		return "", customErrors.ErrPasswordTooLong
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches hash. An empty hash, as on
+// accounts created before passwords existed or accounts that were not
+// found, never matches but takes as long to check as a real one.
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		bcrypt.CompareHashAndPassword([]byte(dummyHash), []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
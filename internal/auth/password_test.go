@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	customErrors "taxiAPI/internal/errors"
+)
+
+func TestHashPassword(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		password string
+		err      error
+	}{
+		{"empty", "", customErrors.ErrPasswordRequired},
+		{"too short", "short", customErrors.ErrPasswordTooShort},
+		{"longest", strings.Repeat("a", MaxPasswordLength), nil},
+		{"too long", strings.Repeat("a", MaxPasswordLength+1), customErrors.ErrPasswordTooLong},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := HashPassword(tc.password)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("HashPassword = %v, want %v", err, tc.err)
+			}
+			if err == nil && !CheckPassword(hash, tc.password) {
+				t.Fatal("the password does not match its hash")
+			}
+		})
+	}
+}
+
+func TestCheckPasswordWithoutHashNeverMatches(t *testing.T) {
+	if CheckPassword("", "") || CheckPassword("", "not a password anyone has") {
+		t.Fatal("an empty hash matched")
+	}
+}
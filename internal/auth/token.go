@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"strconv"
+	customErrors "taxiAPI/internal/errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const DefaultTokenTTL = 24 * time.Hour
+
+// Session is a token handed out on registration or login.
+type Session struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Role      Role      `json:"role"`
+	ID        int       `json:"id,omitempty"`
+}
+
+type claims struct {
+	Role Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Tokens issues and verifies HS256-signed JWTs.
+type Tokens struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokens(secret []byte, ttl time.Duration) *Tokens {
+	return &Tokens{secret: secret, ttl: ttl}
+}
+
+func (t *Tokens) Issue(identity Identity) (*Session, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(t.ttl)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Role: identity.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(identity.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+	signed, err := token.SignedString(t.secret)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{Token: signed, ExpiresAt: expiresAt, Role: identity.Role, ID: identity.ID}, nil
+}
+
+// Parse verifies the token and returns the identity it was issued to.
+func (t *Tokens) Parse(token string) (Identity, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (any, error) {
+		return t.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return Identity{}, customErrors.ErrInvalidToken
+	}
+	id, err := strconv.Atoi(c.Subject)
+	if err != nil || !c.Role.IsValid() {
+		return Identity{}, customErrors.ErrInvalidToken
+	}
+	return Identity{Role: c.Role, ID: id}, nil
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	customErrors "taxiAPI/internal/errors"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestTokens(t *testing.T) {
+	secret := []byte("test secret")
+	tokens := NewTokens(secret, time.Hour)
+	passenger := Identity{Role: RolePassenger, ID: 7}
+
+	issue := func(t *testing.T) string {
+		session, err := tokens.Issue(passenger)
+		if err != nil {
+			t.Fatalf("Issue: %v", err)
+		}
+		return session.Token
+	}
+	sign := func(t *testing.T, method jwt.SigningMethod, key any, c claims) string {
+		signed, err := jwt.NewWithClaims(method, c).SignedString(key)
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return signed
+	}
+	valid := func(expiresAt time.Time) claims {
+		return claims{Role: RoleDriver, RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "3",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		}}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		token func(t *testing.T) string
+		want  Identity
+		err   error
+	}{
+		{"round trip", issue, passenger, nil},
+		{"signed elsewhere", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodHS256, secret, valid(time.Now().Add(time.Minute)))
+		}, Identity{Role: RoleDriver, ID: 3}, nil},
+		{"expired", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodHS256, secret, valid(time.Now().Add(-time.Minute)))
+		}, Identity{}, customErrors.ErrInvalidToken},
+		{"no expiry", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodHS256, secret, claims{Role: RoleDriver, RegisteredClaims: jwt.RegisteredClaims{Subject: "3"}})
+		}, Identity{}, customErrors.ErrInvalidToken},
+		{"wrong algorithm", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodHS512, secret, valid(time.Now().Add(time.Minute)))
+		}, Identity{}, customErrors.ErrInvalidToken},
+		{"unsigned", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid(time.Now().Add(time.Minute)))
+		}, Identity{}, customErrors.ErrInvalidToken},
+		{"other secret", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodHS256, []byte("other secret"), valid(time.Now().Add(time.Minute)))
+		}, Identity{}, customErrors.ErrInvalidToken},
+		{"tampered signature", func(t *testing.T) string {
+			parts := strings.Split(issue(t), ".")
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Flipping a whole byte, unlike the last character, always
+			// changes the decoded signature.
+			signature[len(signature)/2] ^= 0xff
+			parts[2] = base64.RawURLEncoding.EncodeToString(signature)
+			return strings.Join(parts, ".")
+		}, Identity{}, customErrors.ErrInvalidToken},
+		{"tampered claims", func(t *testing.T) string {
+			parts := strings.Split(issue(t), ".")
+			admin := sign(t, jwt.SigningMethodHS256, []byte("other secret"), claims{Role: RoleAdmin, RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "7",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			}})
+			return strings.Join([]string{parts[0], strings.Split(admin, ".")[1], parts[2]}, ".")
+		}, Identity{}, customErrors.ErrInvalidToken},
+		{"unknown role", func(t *testing.T) string {
+			c := valid(time.Now().Add(time.Minute))
+			c.Role = "root"
+			return sign(t, jwt.SigningMethodHS256, secret, c)
+		}, Identity{}, customErrors.ErrInvalidToken},
+		{"malformed", func(*testing.T) string { return "not-a-token" }, Identity{}, customErrors.ErrInvalidToken},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tokens.Parse(tc.token(t))
+			if !errors.Is(err, tc.err) || got != tc.want {
+				t.Fatalf("Parse = %+v, %v; want %+v, %v", got, err, tc.want, tc.err)
+			}
+		})
+	}
+}
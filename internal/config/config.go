@@ -0,0 +1,156 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"taxiAPI/internal/phone"
+)
+
+// EnvPrefix is prepended to a setting's flag name, upper-cased with dashes
+// turned into underscores, to get its environment variable: -read-timeout
+// is TAXI_READ_TIMEOUT.
+const EnvPrefix = "TAXI_"
+
+// Config holds the server settings. Each setting is taken from the first of
+// its command-line flag, its environment variable, the config file and the
+// default that is set.
+type Config struct {
+	Addr            string
+	GRPCAddr        string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+	Store           string
+	DBPath          string
+	LogLevel        slog.Level
+
+	JWTSecret        string
+	AdminPassword    string
+	ScheduleLeadTime time.Duration
+	PhoneRegion      string
+}
+
+func Default() Config {
+	return Config{
+		Addr:            ":8080",
+		GRPCAddr:        ":9090",
+		ReadTimeout:     15 * time.Second,
+		WriteTimeout:    30 * time.Second,
+		IdleTimeout:     2 * time.Minute,
+		ShutdownTimeout: 20 * time.Second,
+		Store:           "memory",
+		DBPath:          "taxi.db",
+		LogLevel:        slog.LevelInfo,
+
+		ScheduleLeadTime: 15 * time.Minute,
+		PhoneRegion:      phone.DefaultRegion,
+	}
+}
+
+func (c *Config) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.Addr, "addr", c.Addr, "address the HTTP server listens on")
+	fs.StringVar(&c.GRPCAddr, "grpc-addr", c.GRPCAddr, "address the gRPC server listens on; empty disables it")
+	fs.DurationVar(&c.ReadTimeout, "read-timeout", c.ReadTimeout, "maximum time to read a request, body included")
+	fs.DurationVar(&c.WriteTimeout, "write-timeout", c.WriteTimeout, "maximum time to write a response; event streams are exempt")
+	fs.DurationVar(&c.IdleTimeout, "idle-timeout", c.IdleTimeout, "how long an idle keep-alive connection is kept open")
+	fs.DurationVar(&c.ShutdownTimeout, "shutdown-timeout", c.ShutdownTimeout, "how long to wait for requests in flight when shutting down")
+	fs.StringVar(&c.Store, "store", c.Store, `storage backend: "memory" or "sqlite"`)
+	fs.StringVar(&c.DBPath, "db", c.DBPath, "SQLite database file used when -store=sqlite")
+	fs.TextVar(&c.LogLevel, "log-level", c.LogLevel, "minimum `level` logged: \"debug\", \"info\", \"warn\" or \"error\"")
+	fs.StringVar(&c.JWTSecret, "jwt-secret", c.JWTSecret, "key used to sign access tokens")
+	fs.StringVar(&c.AdminPassword, "admin-password", c.AdminPassword, "password of the admin account; admin login is disabled when empty")
+	fs.DurationVar(&c.ScheduleLeadTime, "schedule-lead-time", c.ScheduleLeadTime, "how long before pickup a scheduled ride is released for dispatch")
+	fs.StringVar(&c.PhoneRegion, "phone-region", c.PhoneRegion, "`region` (ISO 3166 code) of phone numbers given without a country code")
+}
+
+// Load reads the configuration from the command-line arguments (without the
+// program name), the environment and the JSON file named by -config or
+// TAXI_CONFIG. The file is an object keyed by flag name, e.g.
+// {"addr": ":9090", "read-timeout": "5s"}.
+func Load(args []string, getenv func(string) string) (Config, error) {
+	cfg := Default()
+	fs := flag.NewFlagSet("taxi", flag.ContinueOnError)
+	cfg.register(fs)
+	path := fs.String("config", getenv(EnvPrefix+"CONFIG"), "optional JSON config file (env "+EnvPrefix+"CONFIG)")
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Name != "config" {
+			f.Usage += " (env " + envName(f.Name) + ")"
+		}
+	})
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	set := func(name, value, source string) error {
+		if explicit[name] {
+			return nil
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("%s: invalid value %q for %s: %w", source, value, name, err)
+		}
+		return nil
+	}
+
+	if *path != "" {
+		values, err := readFile(*path)
+		if err != nil {
+			return cfg, err
+		}
+		for name, value := range values {
+			if name == "config" || fs.Lookup(name) == nil {
+				return cfg, fmt.Errorf("%s: unknown setting %q", *path, name)
+			}
+			if err := set(name, value, *path); err != nil {
+				return cfg, err
+			}
+		}
+	}
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if value := getenv(envName(f.Name)); err == nil && value != "" && f.Name != "config" {
+			err = set(f.Name, value, envName(f.Name))
+		}
+	})
+	return cfg, err
+}
+
+func envName(flagName string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// readFile returns the settings in a config file as flag values. Strings
+// are used as they are; numbers and booleans in their JSON form.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	values := make(map[string]string, len(raw))
+	for name, value := range raw {
+		var s string
+		if bytes.HasPrefix(value, []byte(`"`)) {
+			if err := json.Unmarshal(value, &s); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", path, name, err)
+			}
+		} else {
+			s = string(value)
+		}
+		values[name] = s
+	}
+	return values, nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taxi.json")
+	file := `{"addr": ":7000", "read-timeout": "3s", "store": "sqlite", "log-level": "debug"}`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{
+		"TAXI_CONFIG":       path,
+		"TAXI_ADDR":         ":7001",
+		"TAXI_IDLE_TIMEOUT": "1m",
+	}
+
+	cfg, err := Load([]string{"-addr", ":7002"}, func(name string) string { return env[name] })
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := Default()
+	want.Addr = ":7002"                // flag beats environment and file
+	want.IdleTimeout = time.Minute     // environment
+	want.ReadTimeout = 3 * time.Second // file
+	want.Store = "sqlite"
+	want.LogLevel = slog.LevelDebug
+	if cfg != want {
+		t.Fatalf("Load = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadRejectsBadSettings(t *testing.T) {
+	noEnv := func(string) string { return "" }
+	if _, err := Load([]string{"-read-timeout", "soon"}, noEnv); err == nil {
+		t.Error("invalid flag value accepted")
+	}
+	if _, err := Load(nil, func(name string) string {
+		if name == "TAXI_LOG_LEVEL" {
+			return "loud"
+		}
+		return ""
+	}); err == nil {
+		t.Error("invalid environment value accepted")
+	}
+
+	path := filepath.Join(t.TempDir(), "taxi.json")
+	if err := os.WriteFile(path, []byte(`{"port": 8080}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load([]string{"-config", path}, noEnv); err == nil {
+		t.Error("unknown file setting accepted")
+	}
+}
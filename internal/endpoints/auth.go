@@ -0,0 +1,134 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"taxiAPI/internal/auth"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+func NewAuthHandler(service *service.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+type loginRequest struct {
+	Role        string `json:"role"`
+	PhoneNumber string `json:"phone_number"`
+	Password    string `json:"password"`
+}
+
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	session, err := h.service.Login(r.Context(), auth.Role(req.Role), req.PhoneNumber, req.Password)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(session); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// Authenticate puts the identity of callers that send a bearer token into
+// the request context. Requests without a token pass through anonymously;
+// Require decides whether a route needs one. Browsers cannot set headers on
+// EventSource and WebSocket requests, so on the event stream routes the
+// token may also be passed as the access_token query parameter.
+func Authenticate(service *service.AuthService) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				writeError(w, customErrors.ErrInvalidToken)
+				return
+			}
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			identity, err := service.Authenticate(r.Context(), token)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), identity)))
+		})
+	}
+}
+
+// queryTokenRoutes are the routes that accept the access_token query
+// parameter. Anywhere else it is ignored, so tokens do not end up in the
+// URLs, and logs, of ordinary requests.
+var queryTokenRoutes = map[string]bool{
+	"/rides/{id}/events": true,
+}
+
+// bearerToken returns the token sent with the request, or "" when there is
+// none. It reports false for a malformed Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		if route := mux.CurrentRoute(r); route != nil {
+			if path, err := route.GetPathTemplate(); err == nil && queryTokenRoutes[path] {
+				return r.URL.Query().Get("access_token"), true
+			}
+		}
+		return "", true
+	}
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	token = strings.TrimSpace(token)
+	return token, ok && token != ""
+}
+
+// Require only lets callers with one of the roles through. Without roles
+// any authenticated caller is allowed.
+func Require(roles ...auth.Role) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			identity, ok := auth.FromContext(r.Context())
+			if !ok {
+				writeError(w, customErrors.ErrAuthenticationRequired)
+				return
+			}
+			if len(roles) > 0 && !hasRole(identity, roles) {
+				writeError(w, customErrors.ErrForbidden)
+				return
+			}
+			next(w, r)
+		}
+	}
+}
+
+func hasRole(identity auth.Identity, roles []auth.Role) bool {
+	for _, role := range roles {
+		if identity.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// caller returns the identity Require let through.
+func caller(r *http.Request) auth.Identity {
+	identity, _ := auth.FromContext(r.Context())
+	return identity
+}
+
+// requireSelf allows admins and the passenger or driver the route is about.
+func requireSelf(r *http.Request, role auth.Role, id int) error {
+	return service.RequireSelf(caller(r), role, id)
+}
@@ -0,0 +1,204 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+	"taxiAPI/internal/pricing"
+	"taxiAPI/internal/service"
+	"taxiAPI/internal/storage"
+
+	"github.com/gorilla/mux"
+)
+
+type authTest struct {
+	router *mux.Router
+	rides  *service.RideService
+	tokens map[string]string
+	ids    map[string]int
+}
+
+// newAuthTest serves some of the ride routes behind Authenticate and Require, with
+// tokens for an admin, two passengers and two drivers.
+func newAuthTest(t *testing.T) *authTest {
+	t.Helper()
+	ctx := context.Background()
+	rideStore, passengerStore, driverStore, locationStore := storage.NewRide(), storage.NewPassenger(), storage.NewDriver(), storage.NewLocation()
+	engine := pricing.NewEngine(pricing.DefaultConfig())
+	surge := service.NewSurgeService(engine, rideStore, driverStore, locationStore)
+	prices := service.NewPricingService(engine, storage.NewQuote(), surge, service.DefaultQuoteTTL)
+	transactor := storage.NewTransactor()
+	rides := service.NewRideService(rideStore, passengerStore, driverStore, transactor, prices)
+	rides.SetEventBus(service.NewEventBus(service.DefaultEventBacklog))
+	tokens := auth.NewTokens([]byte("test secret"), auth.DefaultTokenTTL)
+	authService := service.NewAuthService(passengerStore, driverStore, tokens, "", "US")
+
+	test := &authTest{router: mux.NewRouter(), rides: rides, tokens: make(map[string]string), ids: make(map[string]int)}
+	issue := func(name string, identity auth.Identity) {
+		session, err := tokens.Issue(identity)
+		if err != nil {
+			t.Fatalf("Issue: %v", err)
+		}
+		test.tokens[name] = session.Token
+		test.ids[name] = identity.ID
+	}
+	issue("admin", auth.Identity{Role: auth.RoleAdmin})
+	// Registered straight in the stores to skip password hashing.
+	for i, name := range []string{"alice", "bob"} {
+		p, err := passengerStore.RegisterPassenger(ctx, &entity.Passenger{FirstName: "P", LastName: name, PhoneNumber: fmt.Sprintf("+141555501%02d", i)})
+		if err != nil {
+			t.Fatalf("RegisterPassenger: %v", err)
+		}
+		issue(name, auth.Identity{Role: auth.RolePassenger, ID: p.PassengerID})
+	}
+	for i, name := range []string{"dave", "erin"} {
+		d, err := driverStore.RegisterDriver(ctx, &entity.Driver{FirstName: "D", LastName: name, PhoneNumber: fmt.Sprintf("+141555502%02d", i), CarType: "Prius", LicensePlate: 1, IsOnline: true, IsAvailable: true})
+		if err != nil {
+			t.Fatalf("RegisterDriver: %v", err)
+		}
+		issue(name, auth.Identity{Role: auth.RoleDriver, ID: d.DriverID})
+	}
+
+	h := NewRideHandler(rides, prices, nil)
+	test.router.Use(Authenticate(authService))
+	test.router.HandleFunc("/rides/{id}", Require()(h.GetRide)).Methods("GET")
+	test.router.HandleFunc("/rides/{id}/cancel", Require()(h.CancelRide)).Methods("POST")
+	test.router.HandleFunc("/rides/{id}/events", Require()(h.StreamRideEvents)).Methods("GET")
+	test.router.HandleFunc("/rides/{id}/status", Require(auth.RoleDriver, auth.RoleAdmin)(h.UpdateRideStatus)).Methods("PUT")
+	drivers := NewDriverHandler(service.NewDriverService(driverStore, rideStore, locationStore, transactor, "US"), authService)
+	test.router.HandleFunc("/drivers/{id}", Require(auth.RoleDriver, auth.RoleAdmin)(drivers.GetDriverByID)).Methods("GET")
+	// Role-restricted reads, so Require can be tried without side effects.
+	test.router.HandleFunc("/driver/rides/{id}", Require(auth.RoleDriver, auth.RoleAdmin)(h.GetRide)).Methods("GET")
+	test.router.HandleFunc("/admin/rides/{id}", Require(auth.RoleAdmin)(h.GetRide)).Methods("GET")
+	return test
+}
+
+// do sends the request with the named caller's token, or with the given
+// Authorization header if caller is not a known name.
+func (a *authTest) do(method, path, caller, body string) (int, string) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token, ok := a.tokens[caller]; ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if caller != "" {
+		req.Header.Set("Authorization", caller)
+	}
+	rec := httptest.NewRecorder()
+	a.router.ServeHTTP(rec, req)
+	var resp errorResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	return rec.Code, resp.Code
+}
+
+// createRide books a ride for the passenger, assigned to the driver unless
+// driver is "".
+func (a *authTest) createRide(t *testing.T, passenger, driver string) int {
+	t.Helper()
+	ctx := context.Background()
+	ride, err := a.rides.CreateRide(ctx, &entity.Ride{PassengerID: a.ids[passenger], Origin: entity.Location{Address: "A"}, Destination: entity.Location{Address: "B"}}, 0)
+	if err != nil {
+		t.Fatalf("CreateRide: %v", err)
+	}
+	if driver != "" {
+		if err := a.rides.AssignDriverToRide(ctx, ride.RideID, a.ids[driver]); err != nil {
+			t.Fatalf("AssignDriverToRide: %v", err)
+		}
+	}
+	return ride.RideID
+}
+
+func TestRequire(t *testing.T) {
+	a := newAuthTest(t)
+	ride := a.createRide(t, "alice", "dave")
+	anyone := "/rides/" + strconv.Itoa(ride)
+	driver := "/driver/rides/" + strconv.Itoa(ride)
+	admin := "/admin/rides/" + strconv.Itoa(ride)
+
+	for _, tc := range []struct {
+		name, path, caller string
+		status             int
+		code               string
+	}{
+		{"no token", anyone, "", http.StatusUnauthorized, "authentication_required"},
+		{"malformed header", anyone, "Token abc", http.StatusUnauthorized, "invalid_token"},
+		{"empty bearer", anyone, "Bearer ", http.StatusUnauthorized, "invalid_token"},
+		{"invalid token", anyone, "Bearer not-a-token", http.StatusUnauthorized, "invalid_token"},
+		{"passenger, any role", anyone, "alice", http.StatusOK, ""},
+		{"driver, any role", anyone, "dave", http.StatusOK, ""},
+		{"admin, any role", anyone, "admin", http.StatusOK, ""},
+		{"passenger, driver route", driver, "alice", http.StatusForbidden, "forbidden"},
+		{"driver, driver route", driver, "dave", http.StatusOK, ""},
+		{"admin, driver route", driver, "admin", http.StatusOK, ""},
+		{"passenger, admin route", admin, "alice", http.StatusForbidden, "forbidden"},
+		{"driver, admin route", admin, "dave", http.StatusForbidden, "forbidden"},
+		{"admin, admin route", admin, "admin", http.StatusOK, ""},
+		{"no token, admin route", admin, "", http.StatusUnauthorized, "authentication_required"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gotStatus, gotCode := a.do("GET", tc.path, tc.caller, "")
+			if gotStatus != tc.status || gotCode != tc.code {
+				t.Fatalf("GET %s = %d %q, want %d %q", tc.path, gotStatus, gotCode, tc.status, tc.code)
+			}
+		})
+	}
+}
+
+func TestRideOwnership(t *testing.T) {
+	a := newAuthTest(t)
+	bobsRide := a.createRide(t, "bob", "erin")
+	path := "/rides/" + strconv.Itoa(bobsRide)
+
+	for _, tc := range []struct {
+		name, method, path, caller string
+		status                     int
+	}{
+		{"another passenger reads", "GET", path, "alice", http.StatusForbidden},
+		{"another passenger cancels", "POST", path + "/cancel", "alice", http.StatusForbidden},
+		{"unassigned driver reads", "GET", path, "dave", http.StatusForbidden},
+		{"unassigned driver cancels", "POST", path + "/cancel", "dave", http.StatusForbidden},
+		{"unassigned driver moves it along", "PUT", path + "/status", "dave", http.StatusForbidden},
+		{"passenger reads", "GET", path, "bob", http.StatusOK},
+		{"assigned driver reads", "GET", path, "erin", http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body := "{}"
+			if strings.HasSuffix(tc.path, "/status") {
+				body = `{"status": "in_progress"}`
+			}
+			if got, _ := a.do(tc.method, tc.path, tc.caller, body); got != tc.status {
+				t.Fatalf("%s %s by %s = %d, want %d", tc.method, tc.path, tc.caller, got, tc.status)
+			}
+		})
+	}
+
+	ride, err := a.rides.GetRide(context.Background(), bobsRide)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ride.Status != entity.StatusAccepted {
+		t.Fatalf("status = %s after refused requests, want accepted", ride.Status)
+	}
+}
+
+func TestDriverProfileOwnership(t *testing.T) {
+	a := newAuthTest(t)
+	path := "/drivers/" + strconv.Itoa(a.ids["dave"])
+
+	for caller, want := range map[string]int{
+		"dave":  http.StatusOK,
+		"admin": http.StatusOK,
+		"erin":  http.StatusForbidden,
+		"alice": http.StatusForbidden,
+	} {
+		if got, _ := a.do("GET", path, caller, ""); got != want {
+			t.Errorf("GET %s by %s = %d, want %d", path, caller, got, want)
+		}
+	}
+}
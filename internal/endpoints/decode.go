@@ -0,0 +1,117 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/validation"
+)
+
+// decodeJSON reads the JSON request body into the struct dst points to.
+// Malformed JSON is ErrInvalidPayload. Fields dst has no room for and values
+// of the wrong type are reported all at once, as a validation error with an
+// ErrUnknownField or ErrInvalidFieldType for each.
+func decodeJSON(r *http.Request, dst any) error {
+	problems, err := decodeFields(r, dst)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return &customErrors.ValidationError{Errors: problems}
+	}
+	return nil
+}
+
+// decodeValidated is decodeJSON for requests the service validates with a
+// validation.Validator: instead of failing, it returns the request context
+// carrying the problems with the body, and the service reports them together
+// with its own.
+func decodeValidated(r *http.Request, dst any) (context.Context, error) {
+	problems, err := decodeFields(r, dst)
+	if err != nil {
+		return nil, err
+	}
+	return validation.WithProblems(r.Context(), problems), nil
+}
+
+// decodeFields decodes what it can of the body into dst and returns the
+// problems with the fields it could not, sorted by field name.
+func decodeFields(r *http.Request, dst any) ([]*customErrors.FieldError, error) {
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, customErrors.ErrInvalidPayload
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, customErrors.ErrInvalidPayload
+	}
+
+	t := reflect.TypeOf(dst).Elem()
+	known := jsonFields(t)
+	var problems []*customErrors.FieldError
+	valid := make(map[string]json.RawMessage, len(fields))
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		if !known[strings.ToLower(name)] {
+			problems = append(problems, &customErrors.FieldError{Field: name, Err: customErrors.ErrUnknownField})
+			continue
+		}
+		// Decoding each field on its own finds every bad value, not just the
+		// first one.
+		single, _ := json.Marshal(map[string]json.RawMessage{name: fields[name]})
+		if err := json.Unmarshal(single, reflect.New(t).Interface()); err != nil {
+			field := name
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) && typeErr.Field != "" {
+				field = typeErr.Field
+			}
+			problems = append(problems, &customErrors.FieldError{Field: field, Err: customErrors.ErrInvalidFieldType})
+			continue
+		}
+		valid[name] = fields[name]
+	}
+
+	// Fields with problems are left at their zero value.
+	body, _ = json.Marshal(valid)
+	if err := json.Unmarshal(body, dst); err != nil {
+		return nil, customErrors.ErrInvalidPayload
+	}
+	return problems, nil
+}
+
+// jsonFields returns the lower-cased names of the JSON fields of struct
+// type t, including those of embedded structs. encoding/json matches names
+// case-insensitively, and so does decodeJSON.
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := make(map[string]bool)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for name := range jsonFields(embedded) {
+					fields[name] = true
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = true
+	}
+	return fields
+}
@@ -0,0 +1,82 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"taxiAPI/internal/entity"
+	"taxiAPI/internal/service"
+	"taxiAPI/internal/storage"
+)
+
+// decodeErrors returns the status and code of the error response rec holds,
+// and "field:code" for each problem it lists.
+func decodeErrors(t *testing.T, rec *httptest.ResponseRecorder) (int, string, []string) {
+	t.Helper()
+	var resp errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	var problems []string
+	for _, e := range resp.Errors {
+		problems = append(problems, e.Field+":"+e.Code)
+	}
+	return rec.Code, resp.Code, problems
+}
+
+func TestDecodeJSONRejectsUnknownFields(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		body     string
+		status   int
+		code     string
+		problems []string
+	}{
+		{"known fields", `{"First_Name": "John", "password": "secret123"}`, 0, "", nil},
+		{"unknown fields", `{"first_name": "John", "nickname": "Jo", "age": 30}`, http.StatusUnprocessableEntity, "validation_failed",
+			[]string{"age:unknown_field", "nickname:unknown_field"}},
+		{"malformed", `{"first_name": `, http.StatusBadRequest, "invalid_payload", nil},
+		{"not an object", `["first_name"]`, http.StatusBadRequest, "invalid_payload", nil},
+		{"wrong types", `{"first_name": "John", "phone_number": 4155550100, "password": false, "nickname": 1}`, http.StatusUnprocessableEntity, "validation_failed",
+			[]string{"nickname:unknown_field", "password:invalid_type", "phone_number:invalid_type"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var req registerPassengerRequest
+			err := decodeJSON(httptest.NewRequest(http.MethodPost, "/passengers", strings.NewReader(tc.body)), &req)
+			if tc.status == 0 {
+				if err != nil || req.FirstName != "John" {
+					t.Fatalf("decodeJSON = %v, %+v", err, req)
+				}
+				return
+			}
+
+			rec := httptest.NewRecorder()
+			writeError(rec, err)
+			status, code, problems := decodeErrors(t, rec)
+			if status != tc.status || code != tc.code || strings.Join(problems, ",") != strings.Join(tc.problems, ",") {
+				t.Fatalf("response = %d %s %v, want %d %s %v", status, code, problems, tc.status, tc.code, tc.problems)
+			}
+		})
+	}
+}
+
+func TestDecodeProblemsReportedWithFieldRules(t *testing.T) {
+	drivers := service.NewDriverService(storage.NewDriver(), storage.NewRide(), storage.NewLocation(), storage.NewTransactor(), "US")
+	h := NewDriverHandler(drivers, nil)
+
+	body := `{"last_name": "Smith", "phone_number": "12", "car_type": "Prius", "license_plate": "abc", "password": "secret123", "nickname": "Al"}`
+	rec := httptest.NewRecorder()
+	h.RegisterDriver(rec, httptest.NewRequest(http.MethodPost, "/drivers", strings.NewReader(body)))
+
+	status, code, problems := decodeErrors(t, rec)
+	want := []string{"license_plate:invalid_type", "nickname:unknown_field", "first_name:first_name_required", "phone_number:invalid_phone_number"}
+	if status != http.StatusUnprocessableEntity || code != "validation_failed" || strings.Join(problems, ",") != strings.Join(want, ",") {
+		t.Fatalf("response = %d %s %v, want 422 validation_failed %v", status, code, problems, want)
+	}
+	if all, _, _ := drivers.ListDrivers(t.Context(), entity.DriverQuery{}); len(all) != 0 {
+		t.Fatalf("a driver was registered from an invalid request: %+v", all)
+	}
+}
@@ -5,30 +5,42 @@ import (
 	"github.com/gorilla/mux"
 	"net/http"
 	"strconv"
+	"taxiAPI/internal/auth"
 	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
 	"taxiAPI/internal/service"
+	"time"
 )
 
 type DriverHandler struct {
 	service *service.DriverService
+	auth    *service.AuthService
 }
 
-func NewDriverHandler(service *service.DriverService) *DriverHandler {
-	return &DriverHandler{service: service}
+func NewDriverHandler(service *service.DriverService, auth *service.AuthService) *DriverHandler {
+	return &DriverHandler{service: service, auth: auth}
 }
 
 type registerDriverRequest struct {
 	FirstName    string `json:"first_name"`
 	LastName     string `json:"last_name"`
-	PhoneNumber  int    `json:"phone_number"`
+	PhoneNumber  string `json:"phone_number"`
 	CarType      string `json:"car_type"`
 	LicensePlate int    `json:"license_plate"`
+	Password     string `json:"password"`
+}
+
+// driverRegistration is the new driver together with their first session.
+type driverRegistration struct {
+	*entity.Driver
+	Session *auth.Session `json:"session"`
 }
 
 func (h *DriverHandler) RegisterDriver(w http.ResponseWriter, r *http.Request) {
 	var req registerDriverRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+	ctx, err := decodeValidated(r, &req)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
@@ -38,31 +50,39 @@ func (h *DriverHandler) RegisterDriver(w http.ResponseWriter, r *http.Request) {
 		PhoneNumber:  req.PhoneNumber,
 		CarType:      req.CarType,
 		LicensePlate: req.LicensePlate,
-		IsAvailable:  true,
 	}
 
-	created, err := h.service.RegisterDriver(r.Context(),driver)
+	created, err := h.service.RegisterDriver(ctx, driver, req.Password)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	session, err := h.auth.IssueSession(auth.Identity{Role: auth.RoleDriver, ID: created.DriverID})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(created)
+	json.NewEncoder(w).Encode(driverRegistration{Driver: created, Session: session})
 }
 
 func (h *DriverHandler) GetDriverByID(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["id"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid driver ID", http.StatusBadRequest)
+		writeError(w, customErrors.ErrInvalidDriverID)
+		return
+	}
+	if err := requireSelf(r, auth.RoleDriver, id); err != nil {
+		writeError(w, err)
 		return
 	}
 
-	driver, err := h.service.GetDriverByID(r.Context(),id)
+	driver, err := h.service.GetDriverByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, err)
 		return
 	}
 
@@ -74,28 +94,156 @@ func (h *DriverHandler) GetDriverByID(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *DriverHandler) GetAllDrivers(w http.ResponseWriter, r *http.Request) {
-	drivers, err := h.service.GetAllDrivers(r.Context())
+	query, err := parseDriverQuery(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
+	drivers, total, err := h.service.ListDrivers(r.Context(), query)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	setTotalCount(w, total)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(drivers)
 }
 
+func parseDriverQuery(r *http.Request) (entity.DriverQuery, error) {
+	q := r.URL.Query()
+	var (
+		query entity.DriverQuery
+		err   error
+	)
+	query.CarType = q.Get("car_type")
+	if query.IsAvailable, err = parseBoolParam(q, "is_available"); err != nil {
+		return query, err
+	}
+	if query.Sort, err = parseSort(q, entity.DriverSortFields); err != nil {
+		return query, err
+	}
+	query.Page, err = parsePage(q)
+	return query, err
+}
+
 func (h *DriverHandler) DeleteDriver(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["id"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid driver ID", http.StatusBadRequest)
+		writeError(w, customErrors.ErrInvalidDriverID)
 		return
 	}
 
-	err = h.service.DeleteDriver(r.Context(),id)
+	err = h.service.DeleteDriver(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+type updateAvailabilityRequest struct {
+	IsOnline *bool `json:"is_online"`
+}
+
+func (h *DriverHandler) UpdateDriverAvailability(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidDriverID)
+		return
+	}
+	if err := requireSelf(r, auth.RoleDriver, id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req updateAvailabilityRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.IsOnline == nil {
+		writeError(w, customErrors.ErrOnlineRequired)
+		return
+	}
+
+	driver, err := h.service.SetOnline(r.Context(), id, *req.IsOnline)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(driver)
+}
+
+type updateLocationRequest struct {
+	Latitude  float64   `json:"lat"`
+	Longitude float64   `json:"lng"`
+	Heading   float64   `json:"heading"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (h *DriverHandler) UpdateDriverLocation(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidDriverID)
+		return
+	}
+	if err := requireSelf(r, auth.RoleDriver, id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req updateLocationRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	loc, err := h.service.UpdateLocation(r.Context(), &entity.DriverLocation{
+		DriverID:  id,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Heading:   req.Heading,
+		Timestamp: req.Timestamp,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(loc)
+}
+
+func (h *DriverHandler) GetNearbyDrivers(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	lat, err := parseFloatParam(q, "lat", true)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	lng, err := parseFloatParam(q, "lng", true)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	radius, err := parseFloatParam(q, "radius", false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	nearby, err := h.service.FindNearbyDrivers(r.Context(), entity.Location{Latitude: lat, Longitude: lng}, radius)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(nearby)
+}
@@ -0,0 +1,170 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	customErrors "taxiAPI/internal/errors"
+)
+
+type errorResponse struct {
+	Code    string           `json:"code"`
+	Message string           `json:"message"`
+	Field   string           `json:"field,omitempty"`
+	Errors  []FieldViolation `json:"errors,omitempty"`
+}
+
+// FieldViolation is one of the problems a validation error lists.
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+	field  string
+}
+
+// errorMappings translates the sentinel errors returned by the service layer
+// into HTTP responses. Errors that match none of them are reported as 500s.
+var errorMappings = []errorMapping{
+	// A validation error wraps its field errors, so it must come first.
+	{customErrors.ErrValidation, http.StatusUnprocessableEntity, "validation_failed", ""},
+	{customErrors.ErrUnknownField, http.StatusBadRequest, "unknown_field", ""},
+	{customErrors.ErrInvalidFieldType, http.StatusBadRequest, "invalid_type", ""},
+	{customErrors.ErrInvalidPayload, http.StatusBadRequest, "invalid_payload", ""},
+	{customErrors.ErrInvalidRideID, http.StatusBadRequest, "invalid_ride_id", "id"},
+	{customErrors.ErrInvalidPassengerID, http.StatusBadRequest, "invalid_passenger_id", "id"},
+	{customErrors.ErrInvalidDriverID, http.StatusBadRequest, "invalid_driver_id", "id"},
+	{customErrors.ErrInvalidOfferID, http.StatusBadRequest, "invalid_offer_id", "offer_id"},
+	{customErrors.ErrInvalidQueryParam, http.StatusBadRequest, "invalid_query_param", ""},
+	{customErrors.ErrInvalidLastEventID, http.StatusBadRequest, "invalid_last_event_id", "last_event_id"},
+	{customErrors.ErrInvalidWebhookID, http.StatusBadRequest, "invalid_webhook_id", "id"},
+
+	{customErrors.ErrAuthenticationRequired, http.StatusUnauthorized, "authentication_required", ""},
+	{customErrors.ErrInvalidToken, http.StatusUnauthorized, "invalid_token", ""},
+	{customErrors.ErrInvalidCredentials, http.StatusUnauthorized, "invalid_credentials", ""},
+	{customErrors.ErrForbidden, http.StatusForbidden, "forbidden", ""},
+
+	{customErrors.ErrRideNotFound, http.StatusNotFound, "ride_not_found", ""},
+	{customErrors.ErrWebhookNotFound, http.StatusNotFound, "webhook_not_found", ""},
+	{customErrors.ErrPassengerNotFound, http.StatusNotFound, "passenger_not_found", ""},
+	{customErrors.ErrDriverNotFound, http.StatusNotFound, "driver_not_found", ""},
+	{customErrors.ErrLocationNotFound, http.StatusNotFound, "location_not_found", ""},
+	{customErrors.ErrOfferNotFound, http.StatusNotFound, "offer_not_found", ""},
+	{customErrors.ErrQuoteNotFound, http.StatusNotFound, "quote_not_found", "quote_id"},
+
+	{customErrors.ErrRideIDRequired, http.StatusBadRequest, "ride_id_required", "id"},
+	{customErrors.ErrPassengerIDRequired, http.StatusBadRequest, "passenger_id_required", "passenger_id"},
+	{customErrors.ErrDriverIDRequired, http.StatusBadRequest, "driver_id_required", "driver_id"},
+	{customErrors.ErrOfferIDRequired, http.StatusBadRequest, "offer_id_required", "offer_id"},
+	{customErrors.ErrOriginRequired, http.StatusBadRequest, "origin_required", "origin"},
+	{customErrors.ErrDestinationRequired, http.StatusBadRequest, "destination_required", "destination"},
+	{customErrors.ErrSameOriginDestination, http.StatusBadRequest, "same_origin_destination", "destination"},
+	{customErrors.ErrInvalidLatitude, http.StatusBadRequest, "invalid_latitude", "lat"},
+	{customErrors.ErrInvalidLongitude, http.StatusBadRequest, "invalid_longitude", "lng"},
+	{customErrors.ErrInvalidHeading, http.StatusBadRequest, "invalid_heading", "heading"},
+	{customErrors.ErrTimestampInFuture, http.StatusBadRequest, "timestamp_in_future", "timestamp"},
+	{customErrors.ErrInvalidRadius, http.StatusBadRequest, "invalid_radius", "radius"},
+	{customErrors.ErrCoordinatesRequired, http.StatusBadRequest, "coordinates_required", ""},
+	{customErrors.ErrQuoteMismatch, http.StatusBadRequest, "quote_mismatch", "quote_id"},
+	{customErrors.ErrInvalidRideStatus, http.StatusBadRequest, "invalid_ride_status", "status"},
+	{customErrors.ErrInvalidActor, http.StatusBadRequest, "invalid_actor", "actor"},
+	{customErrors.ErrInvalidCancelReason, http.StatusBadRequest, "invalid_cancel_reason", "reason_code"},
+	{customErrors.ErrInvalidRater, http.StatusBadRequest, "invalid_rater", "actor"},
+	{customErrors.ErrInvalidScore, http.StatusBadRequest, "invalid_score", "score"},
+	{customErrors.ErrInvalidScheduledAt, http.StatusBadRequest, "invalid_scheduled_at", "scheduled_at"},
+	{customErrors.ErrRideDataRequired, http.StatusBadRequest, "ride_data_required", ""},
+	{customErrors.ErrPassengerDataRequired, http.StatusBadRequest, "passenger_data_required", ""},
+	{customErrors.ErrDriverDataRequired, http.StatusBadRequest, "driver_data_required", ""},
+	{customErrors.ErrPasswordRequired, http.StatusBadRequest, "password_required", "password"},
+	{customErrors.ErrPasswordTooShort, http.StatusBadRequest, "password_too_short", "password"},
+	{customErrors.ErrPasswordTooLong, http.StatusBadRequest, "password_too_long", "password"},
+	{customErrors.ErrInvalidRole, http.StatusBadRequest, "invalid_role", "role"},
+	{customErrors.ErrFirstName, http.StatusBadRequest, "first_name_required", "first_name"},
+	{customErrors.ErrLastName, http.StatusBadRequest, "last_name_required", "last_name"},
+	{customErrors.ErrPhoneNumber, http.StatusBadRequest, "phone_number_required", "phone_number"},
+	{customErrors.ErrInvalidName, http.StatusBadRequest, "invalid_name", ""},
+	{customErrors.ErrInvalidPhoneNumber, http.StatusBadRequest, "invalid_phone_number", "phone_number"},
+	{customErrors.ErrInvalidLicensePlate, http.StatusBadRequest, "invalid_license_plate", "license_plate"},
+	{customErrors.ErrCarTypeRequired, http.StatusBadRequest, "car_type_required", "car_type"},
+	{customErrors.ErrLicensePlateRequired, http.StatusBadRequest, "license_plate_required", "license_plate"},
+	{customErrors.ErrInvalidWebhookURL, http.StatusBadRequest, "invalid_webhook_url", "url"},
+	{customErrors.ErrWebhookEventsRequired, http.StatusBadRequest, "events_required", "events"},
+	{customErrors.ErrInvalidEventType, http.StatusBadRequest, "invalid_event_type", "events"},
+	{customErrors.ErrWebhookSecretRequired, http.StatusBadRequest, "secret_required", "secret"},
+
+	{customErrors.ErrOnlineRequired, http.StatusBadRequest, "is_online_required", "is_online"},
+
+	{customErrors.ErrPhoneNumberExists, http.StatusConflict, "phone_number_exists", "phone_number"},
+	{customErrors.ErrDriverAlreadyOnActiveRide, http.StatusConflict, "driver_already_on_active_ride", "driver_id"},
+	{customErrors.ErrDriverUnavailable, http.StatusConflict, "driver_unavailable", "driver_id"},
+	{customErrors.ErrRideAlreadyAssigned, http.StatusConflict, "ride_already_assigned", ""},
+	{customErrors.ErrRideNotAssigned, http.StatusConflict, "ride_not_assigned", ""},
+	{customErrors.ErrRideNotCompleted, http.StatusConflict, "ride_not_completed", ""},
+	{customErrors.ErrRideNotScheduled, http.StatusConflict, "ride_not_scheduled", ""},
+	{customErrors.ErrAlreadyRated, http.StatusConflict, "already_rated", "actor"},
+	{customErrors.ErrDriverAlreadyAssignedToRide, http.StatusConflict, "driver_already_assigned_to_ride", "driver_id"},
+	{customErrors.ErrCannotAssignDriverToNonPendingRide, http.StatusConflict, "ride_not_pending", ""},
+	{customErrors.ErrOfferNotPending, http.StatusConflict, "offer_not_pending", ""},
+	{customErrors.ErrOfferExpired, http.StatusConflict, "offer_expired", ""},
+	{customErrors.ErrOfferAlreadyPending, http.StatusConflict, "offer_already_pending", ""},
+	{customErrors.ErrQuoteExpired, http.StatusConflict, "quote_expired", "quote_id"},
+	{customErrors.ErrQuoteUsed, http.StatusConflict, "quote_used", "quote_id"},
+	{customErrors.ErrInvalidStatusTransition, http.StatusConflict, "invalid_status_transition", "status"},
+}
+
+// ErrorStatus returns the HTTP status, error code and offending request
+// field err is reported with. Errors that match no mapping are 500s with
+// the code "internal_error"; the gRPC API reports errors the same way.
+func ErrorStatus(err error) (status int, code, field string) {
+	status, code = http.StatusInternalServerError, "internal_error"
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			status, code, field = m.status, m.code, m.field
+			break
+		}
+	}
+	var fieldErr *customErrors.FieldError
+	if !errors.Is(err, customErrors.ErrValidation) && errors.As(err, &fieldErr) && status != http.StatusInternalServerError {
+		field = fieldErr.Field
+	}
+	return status, code, field
+}
+
+// Violations lists the problems of a validation error, each with the code
+// it would be reported with on its own. It returns nil for other errors.
+func Violations(err error) []FieldViolation {
+	var validationErr *customErrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+	violations := make([]FieldViolation, len(validationErr.Errors))
+	for i, fieldErr := range validationErr.Errors {
+		_, code, _ := ErrorStatus(fieldErr.Err)
+		violations[i] = FieldViolation{Field: fieldErr.Field, Code: code, Message: fieldErr.Err.Error()}
+	}
+	return violations
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status, code, field := ErrorStatus(err)
+	resp := errorResponse{Code: code, Message: err.Error(), Field: field, Errors: Violations(err)}
+	if resp.Errors != nil {
+		resp.Message = customErrors.ErrValidation.Error()
+	}
+	if status == http.StatusInternalServerError {
+		slog.Error("unhandled error", "err", err)
+		resp.Message = "internal server error"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		return
+	}
+}
@@ -0,0 +1,49 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	customErrors "taxiAPI/internal/errors"
+)
+
+func TestWriteError(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		field   string
+		message string
+	}{
+		{"not found", customErrors.ErrRideNotFound, http.StatusNotFound, "ride_not_found", "", "ride not found"},
+		{"wrapped", fmt.Errorf("load ride 7: %w", customErrors.ErrRideNotFound), http.StatusNotFound, "ride_not_found", "", "load ride 7: ride not found"},
+		{"phone number taken", customErrors.ErrPhoneNumberExists, http.StatusConflict, "phone_number_exists", "phone_number", "phone number exists"},
+		{"driver busy", customErrors.ErrDriverAlreadyOnActiveRide, http.StatusConflict, "driver_already_on_active_ride", "driver_id", "driver already on active ride"},
+		{"transition", &customErrors.TransitionError{From: "completed", To: "pending"}, http.StatusConflict, "invalid_status_transition", "status",
+			`cannot change ride status from "completed" to "pending"`},
+		{"field named by the error", &customErrors.FieldError{Field: "origin.lat", Err: customErrors.ErrInvalidLatitude}, http.StatusBadRequest, "invalid_latitude", "origin.lat",
+			"origin.lat: " + customErrors.ErrInvalidLatitude.Error()},
+		{"unknown", errors.New("disk on fire"), http.StatusInternalServerError, "internal_error", "", "internal server error"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeError(rec, tc.err)
+
+			var resp errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if rec.Code != tc.status || resp.Code != tc.code || resp.Field != tc.field || resp.Message != tc.message {
+				t.Fatalf("response = %d %+v, want %d {Code:%s Message:%s Field:%s}", rec.Code, resp, tc.status, tc.code, tc.message, tc.field)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Fatalf("Content-Type = %q", got)
+			}
+		})
+	}
+}
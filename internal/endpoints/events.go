@@ -0,0 +1,192 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/service"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+// eventHeartbeat is how often an idle stream is pinged so proxies keep it
+// open and dead clients are noticed. It is a variable for the tests.
+var eventHeartbeat = 15 * time.Second
+
+// eventWriteTimeout bounds how long writing one event may take.
+const eventWriteTimeout = 10 * time.Second
+
+var upgrader = websocket.Upgrader{
+	// Streams are authorized by token, not by cookies, so any origin may
+	// connect.
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// StreamRideEvents pushes the events of a ride to the client, as a WebSocket
+// when the request asks for an upgrade and as Server-Sent Events otherwise.
+// Clients resume after a disconnect by sending the ID of the last event they
+// received in the Last-Event-ID header or the last_event_id query parameter.
+func (h *RideHandler) StreamRideEvents(w http.ResponseWriter, r *http.Request) {
+	rideID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidRideID)
+		return
+	}
+	lastEventID, err := parseLastEventID(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	sub, err := h.service.SubscribeRide(r.Context(), caller(r), rideID, lastEventID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer sub.Close()
+	if websocket.IsWebSocketUpgrade(r) {
+		streamWebSocket(w, r, sub)
+		return
+	}
+	streamSSE(w, r, sub)
+}
+
+func parseLastEventID(r *http.Request) (uint64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, customErrors.ErrInvalidLastEventID
+	}
+	return id, nil
+}
+
+func streamSSE(w http.ResponseWriter, r *http.Request, sub *service.Subscription) {
+	rc := http.NewResponseController(w)
+	// The stream outlives any server-wide write timeout.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", (3 * time.Second).Milliseconds()); err != nil {
+		return
+	}
+	for _, event := range sub.Missed {
+		if err := writeSSE(w, event); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				// Dropped for falling behind or shutting down; the client
+				// reconnects with Last-Event-ID and catches up from the
+				// backlog. A driver taken off the ride gets 403 instead.
+				return
+			}
+			if err := writeSSE(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, event entity.RideEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+func streamWebSocket(w http.ResponseWriter, r *http.Request, sub *service.Subscription) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied with an error.
+		return
+	}
+	defer conn.Close()
+
+	// Clients only send pongs and close frames; reading is what processes
+	// them and notices a client that went away.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		conn.SetReadLimit(512)
+		_ = conn.SetReadDeadline(time.Now().Add(2 * eventHeartbeat))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(2 * eventHeartbeat))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(event entity.RideEvent) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(eventWriteTimeout))
+		return conn.WriteJSON(event)
+	}
+	for _, event := range sub.Missed {
+		if err := send(event); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow, reconnect with last_event_id")
+				switch {
+				case sub.BusClosed():
+					message = websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+				case sub.Revoked():
+					message = websocket.FormatCloseMessage(websocket.ClosePolicyViolation, customErrors.ErrForbidden.Error())
+				}
+				_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(eventWriteTimeout))
+				return
+			}
+			if err := send(event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
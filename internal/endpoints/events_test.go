@@ -0,0 +1,68 @@
+package endpoints
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestStreamRideEventsSendsHeartbeats(t *testing.T) {
+	heartbeat := eventHeartbeat
+	eventHeartbeat = 10 * time.Millisecond
+	t.Cleanup(func() { eventHeartbeat = heartbeat })
+
+	a := newAuthTest(t)
+	ride := a.createRide(t, "alice", "")
+	server := httptest.NewServer(a.router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/rides/" + strconv.Itoa(ride) + "/events?access_token=" + a.tokens["alice"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("response = %d %s, want an event stream", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	lines := bufio.NewScanner(resp.Body)
+	deadline := time.AfterFunc(time.Second, func() { resp.Body.Close() })
+	defer deadline.Stop()
+	heartbeats := 0
+	for heartbeats < 2 && lines.Scan() {
+		if lines.Text() == ": heartbeat" {
+			heartbeats++
+		}
+	}
+	if heartbeats < 2 {
+		t.Fatalf("got %d heartbeats on an idle stream, want 2", heartbeats)
+	}
+}
+
+func TestAccessTokenOnlyOnEventStreams(t *testing.T) {
+	a := newAuthTest(t)
+	ride := strconv.Itoa(a.createRide(t, "alice", ""))
+	query := "?access_token=" + a.tokens["alice"]
+
+	// Only the status matters here, so the stream is closed once the
+	// headers arrive.
+	server := httptest.NewServer(a.router)
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/rides/" + ride + "/events" + query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("event stream with access_token = %d, want 200", resp.StatusCode)
+	}
+
+	if status, code := a.do("GET", "/rides/"+ride+query, "", ""); status != http.StatusUnauthorized || code != "authentication_required" {
+		t.Fatalf("GET /rides/%s with access_token = %d %s, want 401 authentication_required", ride, status, code)
+	}
+	if status, _ := a.do("GET", "/rides/"+ride+"/events"+query, "Bearer not-a-token", ""); status != http.StatusUnauthorized {
+		t.Fatalf("a bad Authorization header next to access_token = %d, want 401", status)
+	}
+}
@@ -0,0 +1,139 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+type OfferHandler struct {
+	service *service.OfferService
+}
+
+func NewOfferHandler(service *service.OfferService) *OfferHandler {
+	return &OfferHandler{
+		service: service,
+	}
+}
+
+type offerRideRequest struct {
+	DriverID int `json:"driver_id"`
+}
+
+// OfferRide proposes a pending ride to the given driver. The ride is only
+// assigned once the driver accepts the offer.
+func (h *OfferHandler) OfferRide(w http.ResponseWriter, r *http.Request) {
+	rideID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidRideID)
+		return
+	}
+
+	var req offerRideRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	offer, err := h.service.OfferRide(r.Context(), rideID, req.DriverID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(offer); err != nil {
+		return
+	}
+}
+
+func (h *OfferHandler) GetRideOffers(w http.ResponseWriter, r *http.Request) {
+	rideID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidRideID)
+		return
+	}
+
+	offers, err := h.service.GetRideOffers(r.Context(), rideID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(offers); err != nil {
+		return
+	}
+}
+
+func (h *OfferHandler) GetDriverOffers(w http.ResponseWriter, r *http.Request) {
+	driverID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidDriverID)
+		return
+	}
+	if err := requireSelf(r, auth.RoleDriver, driverID); err != nil {
+		writeError(w, err)
+		return
+	}
+	status := entity.OfferStatus(r.URL.Query().Get("status"))
+	if status != "" && !status.IsValid() {
+		writeError(w, invalidParam("status"))
+		return
+	}
+
+	offers, err := h.service.GetDriverOffers(r.Context(), driverID, status)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(offers); err != nil {
+		return
+	}
+}
+
+func (h *OfferHandler) AcceptOffer(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.service.AcceptOffer)
+}
+
+func (h *OfferHandler) DeclineOffer(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.service.DeclineOffer)
+}
+
+func (h *OfferHandler) respond(w http.ResponseWriter, r *http.Request, answer func(ctx context.Context, driverID, offerID int) (*entity.Offer, error)) {
+	vars := mux.Vars(r)
+	driverID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidDriverID)
+		return
+	}
+	if err := requireSelf(r, auth.RoleDriver, driverID); err != nil {
+		writeError(w, err)
+		return
+	}
+	offerID, err := strconv.Atoi(vars["offerID"])
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidOfferID)
+		return
+	}
+
+	offer, err := answer(r.Context(), driverID, offerID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(offer); err != nil {
+		return
+	}
+}
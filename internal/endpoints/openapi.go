@@ -0,0 +1,322 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+)
+
+// operation documents one route for the OpenAPI document. Request and
+// response bodies are given as values of the types the handler decodes and
+// encodes; their schemas are derived from the types' json tags, so the
+// document follows the handlers when fields change.
+type operation struct {
+	method  string
+	path    string
+	tag     string
+	summary string
+	// roles that may call the route; nil for public routes and empty for
+	// any signed-in caller.
+	roles    []auth.Role
+	params   []parameter
+	request  any
+	status   int
+	response any
+	// paged responses carry the X-Total-Count header.
+	paged bool
+	// stream is set for routes that answer with an event stream instead
+	// of JSON.
+	stream bool
+}
+
+type parameter struct {
+	name        string
+	in          string
+	typ         string
+	format      string
+	required    bool
+	description string
+}
+
+// schemaEnums lists the values of the string types that are enumerations.
+var schemaEnums = map[reflect.Type][]string{
+	reflect.TypeFor[entity.Status](): {
+		string(entity.StatusScheduled), string(entity.StatusPending), string(entity.StatusAccepted),
+		string(entity.StatusDriverArriving), string(entity.StatusInProgress), string(entity.StatusCompleted),
+		string(entity.StatusCancelled), string(entity.StatusNoShow),
+	},
+	reflect.TypeFor[entity.Actor](): {string(entity.ActorPassenger), string(entity.ActorDriver), string(entity.ActorSystem)},
+	reflect.TypeFor[entity.CancelReason](): {
+		string(entity.CancelChangedPlans), string(entity.CancelDriverLate), string(entity.CancelWrongPickup),
+		string(entity.CancelFoundAlternative), string(entity.CancelPassengerUnreachable), string(entity.CancelVehicleProblem),
+		string(entity.CancelUnsafePickup), string(entity.CancelNoDriverFound), string(entity.CancelOther),
+	},
+	reflect.TypeFor[entity.OfferStatus](): {
+		string(entity.OfferPending), string(entity.OfferAccepted), string(entity.OfferDeclined),
+		string(entity.OfferExpired), string(entity.OfferCancelled),
+	},
+	reflect.TypeFor[entity.RideEventType](): eventTypeNames(),
+	reflect.TypeFor[auth.Role]():            {string(auth.RolePassenger), string(auth.RoleDriver), string(auth.RoleAdmin)},
+}
+
+func eventTypeNames() []string {
+	names := make([]string, len(entity.RideEventTypes))
+	for i, t := range entity.RideEventTypes {
+		names[i] = string(t)
+	}
+	return names
+}
+
+// schemaDescriptions documents types whose JSON form is not obvious from
+// their fields.
+var schemaDescriptions = map[reflect.Type]string{
+	reflect.TypeFor[entity.Location](): "A place. In requests it may also be given as a bare address string.",
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// schemas collects the component schemas of the named struct types met
+// while describing the operations.
+type schemas struct {
+	byName map[string]any
+	types  map[string]reflect.Type
+}
+
+// of returns the schema of t, a reference for named struct types.
+func (s *schemas) of(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		schema := map[string]any{"type": "string"}
+		if enum, ok := schemaEnums[t]; ok {
+			schema["enum"] = enum
+		}
+		return schema
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]any{"type": "integer"}
+	case reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": s.of(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.of(t.Elem())}
+	case reflect.Struct:
+		name := s.name(t)
+		if _, ok := s.byName[name]; !ok {
+			s.byName[name] = nil // stops recursion through self-referencing types
+			s.byName[name] = s.object(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]any{}
+}
+
+// name is the component name of a struct type: its Go name starting with a
+// capital letter, prefixed with the package name if two packages share it.
+func (s *schemas) name(t reflect.Type) string {
+	runes := []rune(t.Name())
+	runes[0] = unicode.ToUpper(runes[0])
+	name := string(runes)
+	if t == reflect.TypeFor[errorResponse]() {
+		name = "Error"
+	}
+	if other, ok := s.types[name]; ok && other != t {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	s.types[name] = t
+	return name
+}
+
+func (s *schemas) object(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	s.properties(t, properties)
+	schema := map[string]any{"type": "object", "properties": properties}
+	if description, ok := schemaDescriptions[t]; ok {
+		schema["description"] = description
+	}
+	return schema
+}
+
+// properties adds the JSON fields of struct type t, including those of
+// embedded structs, to properties.
+func (s *schemas) properties(t reflect.Type, properties map[string]any) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				s.properties(embedded, properties)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = s.of(field.Type)
+	}
+}
+
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// openAPIDocument builds the OpenAPI 3 description of the operations.
+func openAPIDocument(operations []operation) map[string]any {
+	s := &schemas{byName: make(map[string]any), types: make(map[string]reflect.Type)}
+	errorSchema := s.of(reflect.TypeFor[errorResponse]())
+	errorReply := func(description string) map[string]any {
+		return map[string]any{
+			"description": description,
+			"content":     map[string]any{"application/json": map[string]any{"schema": errorSchema}},
+		}
+	}
+
+	paths := make(map[string]any)
+	for _, op := range operations {
+		var params []any
+		for _, match := range pathParam.FindAllStringSubmatch(op.path, -1) {
+			params = append(params, map[string]any{
+				"name": match[1], "in": "path", "required": true,
+				"schema": map[string]any{"type": "integer"},
+			})
+		}
+		for _, p := range op.params {
+			schema := map[string]any{"type": p.typ}
+			if p.format != "" {
+				schema["format"] = p.format
+			}
+			param := map[string]any{"name": p.name, "in": p.in, "required": p.required, "schema": schema}
+			if p.description != "" {
+				param["description"] = p.description
+			}
+			params = append(params, param)
+		}
+
+		success := map[string]any{"description": http.StatusText(op.status)}
+		switch {
+		case op.stream:
+			success["description"] = "Server-Sent Events, or one JSON event per WebSocket message after an upgrade"
+			success["content"] = map[string]any{"text/event-stream": map[string]any{"schema": s.of(reflect.TypeOf(op.response))}}
+		case op.response != nil:
+			success["content"] = map[string]any{"application/json": map[string]any{"schema": s.of(reflect.TypeOf(op.response))}}
+		}
+		if op.paged {
+			success["headers"] = map[string]any{"X-Total-Count": map[string]any{
+				"description": "number of matching records on all pages",
+				"schema":      map[string]any{"type": "integer"},
+			}}
+		}
+		responses := map[string]any{
+			strconv.Itoa(op.status): success,
+			"default":               errorReply("Error"),
+		}
+
+		entry := map[string]any{
+			"tags":        []string{op.tag},
+			"summary":     op.summary,
+			"operationId": operationID(op),
+			"responses":   responses,
+		}
+		if params != nil {
+			entry["parameters"] = params
+		}
+		if op.request != nil {
+			entry["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"schema": s.of(reflect.TypeOf(op.request))}},
+			}
+			responses["422"] = errorReply("Unknown fields or invalid values; errors lists every one")
+		}
+		if op.roles == nil {
+			entry["security"] = []any{}
+		} else {
+			responses["401"] = errorReply("Missing, invalid or expired token")
+			if len(op.roles) > 0 {
+				responses["403"] = errorReply("Not allowed for this account")
+				roles := make([]string, len(op.roles))
+				for i, role := range op.roles {
+					roles[i] = string(role)
+				}
+				entry["description"] = "Roles: " + strings.Join(roles, ", ")
+			}
+		}
+
+		methods, _ := paths[op.path].(map[string]any)
+		if methods == nil {
+			methods = make(map[string]any)
+			paths[op.path] = methods
+		}
+		methods[strings.ToLower(op.method)] = entry
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Taxi API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": s.byName,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+		"security": []any{map[string]any{"bearerAuth": []string{}}},
+	}
+}
+
+// operationID derives a stable ID such as "getRidesIdHistory" from the
+// method and path.
+func operationID(op operation) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(op.method))
+	for _, part := range strings.FieldsFunc(op.path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+var openAPIJSON = sync.OnceValues(func() ([]byte, error) {
+	return json.MarshalIndent(openAPIDocument(apiOperations), "", "  ")
+})
+
+// ServeOpenAPI serves the OpenAPI 3 document describing every route.
+func ServeOpenAPI(w http.ResponseWriter, r *http.Request) {
+	doc, err := openAPIJSON()
+	if err != nil {
+		writeError(w, fmt.Errorf("build OpenAPI document: %w", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(doc)
+}
@@ -5,30 +5,43 @@ import (
 	"github.com/gorilla/mux"
 	"net/http"
 	"strconv"
+	"taxiAPI/internal/auth"
 	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
 	"taxiAPI/internal/service"
 )
 
 type PassengerHandler struct {
 	service *service.PassengerService
+	auth    *service.AuthService
 }
 
-func NewPassengerHandler(service *service.PassengerService) *PassengerHandler {
+func NewPassengerHandler(service *service.PassengerService, auth *service.AuthService) *PassengerHandler {
 	return &PassengerHandler{
 		service: service,
+		auth:    auth,
 	}
 }
 
 type registerPassengerRequest struct {
 	FirstName   string `json:"first_name"`
 	LastName    string `json:"last_name"`
-	PhoneNumber int    `json:"phone_number"`
+	PhoneNumber string `json:"phone_number"`
+	Password    string `json:"password"`
+}
+
+// passengerRegistration is the new passenger together with their first
+// session.
+type passengerRegistration struct {
+	*entity.Passenger
+	Session *auth.Session `json:"session"`
 }
 
 func (h *PassengerHandler) RegisterPassenger(w http.ResponseWriter, r *http.Request) {
 	var req registerPassengerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	ctx, err := decodeValidated(r, &req)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 	passenger := &entity.Passenger{
@@ -36,14 +49,19 @@ func (h *PassengerHandler) RegisterPassenger(w http.ResponseWriter, r *http.Requ
 		LastName:    req.LastName,
 		PhoneNumber: req.PhoneNumber,
 	}
-	created, err := h.service.RegisterPassenger(r.Context(),passenger)
+	created, err := h.service.RegisterPassenger(ctx, passenger, req.Password)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	session, err := h.auth.IssueSession(auth.Identity{Role: auth.RolePassenger, ID: created.PassengerID})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(created); err != nil {
+	if err := json.NewEncoder(w).Encode(passengerRegistration{Passenger: created, Session: session}); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
 }
@@ -52,12 +70,16 @@ func (h *PassengerHandler) GetPassengerByID(w http.ResponseWriter, r *http.Reque
 	idStr := vars["id"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid passenger ID", http.StatusBadRequest)
+		writeError(w, customErrors.ErrInvalidPassengerID)
 		return
 	}
-	passenger, err := h.service.GetPassengerByID(r.Context(),id)
+	if err := requireSelf(r, auth.RolePassenger, id); err != nil {
+		writeError(w, err)
+		return
+	}
+	passenger, err := h.service.GetPassengerByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -67,11 +89,23 @@ func (h *PassengerHandler) GetPassengerByID(w http.ResponseWriter, r *http.Reque
 	}
 }
 func (h *PassengerHandler) GetAllPassengers(w http.ResponseWriter, r *http.Request) {
-	passengers, err := h.service.GetAllPassengers(r.Context())
+	q := r.URL.Query()
+	var query entity.PassengerQuery
+	var err error
+	if query.Sort, err = parseSort(q, entity.PassengerSortFields); err != nil {
+		writeError(w, err)
+		return
+	}
+	if query.Page, err = parsePage(q); err != nil {
+		writeError(w, err)
+		return
+	}
+	passengers, total, err := h.service.ListPassengers(r.Context(), query)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
+	setTotalCount(w, total)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
@@ -84,12 +118,12 @@ func (h *PassengerHandler) DeletePassenger(w http.ResponseWriter, r *http.Reques
 	idStr := vars["id"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid passenger ID", http.StatusBadRequest)
+		writeError(w, customErrors.ErrInvalidPassengerID)
 		return
 	}
-	err = h.service.DeletePassenger(r.Context(),id)
+	err = h.service.DeletePassenger(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
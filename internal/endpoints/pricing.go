@@ -0,0 +1,41 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"taxiAPI/internal/entity"
+	"taxiAPI/internal/service"
+)
+
+type PricingHandler struct {
+	surge *service.SurgeService
+}
+
+func NewPricingHandler(surge *service.SurgeService) *PricingHandler {
+	return &PricingHandler{
+		surge: surge,
+	}
+}
+
+func (h *PricingHandler) GetSurge(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	lat, err := parseFloatParam(q, "lat", true)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	lng, err := parseFloatParam(q, "lng", true)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	surge, err := h.surge.GetSurge(r.Context(), entity.Location{Latitude: lat, Longitude: lng})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(surge)
+}
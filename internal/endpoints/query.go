@@ -0,0 +1,98 @@
+package endpoints
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+func invalidParam(name string) error {
+	return &customErrors.FieldError{Field: name, Err: customErrors.ErrInvalidQueryParam}
+}
+
+func parseIntParam(q url.Values, name string) (int, error) {
+	raw := q.Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 0 {
+		return 0, invalidParam(name)
+	}
+	return v, nil
+}
+
+func parseFloatParam(q url.Values, name string, required bool) (float64, error) {
+	raw := q.Get(name)
+	if raw == "" {
+		if required {
+			return 0, invalidParam(name)
+		}
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, invalidParam(name)
+	}
+	return v, nil
+}
+
+func parseTimeParam(q url.Values, name string) (time.Time, error) {
+	raw := q.Get(name)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, invalidParam(name)
+	}
+	return t, nil
+}
+
+func parseBoolParam(q url.Values, name string) (*bool, error) {
+	raw := q.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, invalidParam(name)
+	}
+	return &v, nil
+}
+
+// parseSort reads "?sort=field" or "?sort=-field" for descending order.
+func parseSort(q url.Values, allowed []string) (entity.SortOrder, error) {
+	raw := q.Get("sort")
+	if raw == "" {
+		return entity.SortOrder{}, nil
+	}
+	order, ok := entity.ParseSort(raw, allowed)
+	if !ok {
+		return entity.SortOrder{}, invalidParam("sort")
+	}
+	return order, nil
+}
+
+func parsePage(q url.Values) (entity.Page, error) {
+	limit, err := parseIntParam(q, "limit")
+	if err != nil {
+		return entity.Page{}, err
+	}
+	if limit > entity.MaxPageLimit {
+		return entity.Page{}, invalidParam("limit")
+	}
+	offset, err := parseIntParam(q, "offset")
+	if err != nil {
+		return entity.Page{}, err
+	}
+	return entity.Page{Limit: limit, Offset: offset}, nil
+}
+
+func setTotalCount(w http.ResponseWriter, total int) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+}
@@ -0,0 +1,72 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"taxiAPI/internal/entity"
+	"taxiAPI/internal/service"
+	"taxiAPI/internal/storage"
+)
+
+func TestListQueryParams(t *testing.T) {
+	store := storage.NewPassenger()
+	for i, lastName := range []string{"Doe", "Adams", "Doe"} {
+		if _, err := store.RegisterPassenger(context.Background(), &entity.Passenger{FirstName: "Jo", LastName: lastName, PhoneNumber: fmt.Sprintf("+14155550%03d", i)}); err != nil {
+			t.Fatalf("RegisterPassenger: %v", err)
+		}
+	}
+	h := NewPassengerHandler(service.NewPassengerService(store, "US"), nil)
+
+	for _, tc := range []struct {
+		query  string
+		status int
+		field  string // of the rejected parameter
+		want   []int  // IDs listed
+	}{
+		{"", http.StatusOK, "", []int{1, 2, 3}},
+		{"?sort=-last_name&limit=2", http.StatusOK, "", []int{1, 3}},
+		{"?sort=last_name&offset=1", http.StatusOK, "", []int{1, 3}},
+		{"?limit=200&offset=3", http.StatusOK, "", []int{}},
+		{"?limit=201", http.StatusBadRequest, "limit", nil},
+		{"?limit=-1", http.StatusBadRequest, "limit", nil},
+		{"?limit=ten", http.StatusBadRequest, "limit", nil},
+		{"?offset=-5", http.StatusBadRequest, "offset", nil},
+		{"?sort=phone_number", http.StatusBadRequest, "sort", nil},
+		{"?sort=--last_name", http.StatusBadRequest, "sort", nil},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.GetAllPassengers(rec, httptest.NewRequest(http.MethodGet, "/passengers"+tc.query, nil))
+			if rec.Code != tc.status {
+				t.Fatalf("GET /passengers%s = %d, want %d", tc.query, rec.Code, tc.status)
+			}
+
+			if tc.status != http.StatusOK {
+				var resp errorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				if resp.Code != "invalid_query_param" || resp.Field != tc.field {
+					t.Fatalf("error = %+v, want invalid_query_param on %s", resp, tc.field)
+				}
+				return
+			}
+			var passengers []*entity.Passenger
+			if err := json.NewDecoder(rec.Body).Decode(&passengers); err != nil {
+				t.Fatal(err)
+			}
+			got := []int{}
+			for _, p := range passengers {
+				got = append(got, p.PassengerID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) || rec.Header().Get("X-Total-Count") != "3" {
+				t.Fatalf("listed %v of %s, want %v of 3", got, rec.Header().Get("X-Total-Count"), tc.want)
+			}
+		})
+	}
+}
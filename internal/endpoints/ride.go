@@ -4,41 +4,135 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"taxiAPI/internal/auth"
 	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
 	"taxiAPI/internal/service"
+	"time"
 
 	"github.com/gorilla/mux"
 )
 
 type RideHandler struct {
 	service *service.RideService
+	pricing *service.PricingService
+	ratings *service.RatingService
 }
 
-func NewRideHandler(service *service.RideService) *RideHandler {
+func NewRideHandler(service *service.RideService, pricing *service.PricingService, ratings *service.RatingService) *RideHandler {
 	return &RideHandler{
 		service: service,
+		pricing: pricing,
+		ratings: ratings,
 	}
 }
 
 type updateStatusRequest struct {
 	Status string `json:"status"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+type updateStatusResponse struct {
+	Message string        `json:"message"`
+	RideID  int           `json:"ride_id"`
+	Status  entity.Status `json:"status"`
+}
+
+type transitionsResponse struct {
+	RideID      int             `json:"ride_id"`
+	Status      entity.Status   `json:"status"`
+	Transitions []entity.Status `json:"transitions"`
+}
+
+type cancelRideRequest struct {
+	// Actor is only honoured for admins; everyone else cancels as
+	// themselves.
+	Actor      string `json:"actor"`
+	ReasonCode string `json:"reason_code"`
+	Note       string `json:"note"`
+}
+
+type rateRideRequest struct {
+	Score   int    `json:"score"`
+	Comment string `json:"comment"`
 }
 
 type createRideRequest struct {
-	PassengerID int    `json:"passenger_id"`
-	Origin      string `json:"origin"`
-	Destination string `json:"destination"`
+	PassengerID int             `json:"passenger_id"`
+	Origin      entity.Location `json:"origin"`
+	Destination entity.Location `json:"destination"`
+	CarType     string          `json:"car_type"`
+	QuoteID     int             `json:"quote_id"`
+	ScheduledAt *time.Time      `json:"scheduled_at"`
+}
+
+// editRideRequest changes only the fields that are present.
+type editRideRequest struct {
+	Origin      *entity.Location `json:"origin"`
+	Destination *entity.Location `json:"destination"`
+	CarType     *string          `json:"car_type"`
+	ScheduledAt *time.Time       `json:"scheduled_at"`
+	QuoteID     int              `json:"quote_id"`
+}
+
+type estimateRequest struct {
+	PassengerID int             `json:"passenger_id"`
+	Origin      entity.Location `json:"origin"`
+	Destination entity.Location `json:"destination"`
+	CarType     string          `json:"car_type"`
+}
+
+func (h *RideHandler) EstimateFare(w http.ResponseWriter, r *http.Request) {
+	var req estimateRequest
+	ctx, err := decodeValidated(r, &req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	passengerID, err := service.PassengerFor(caller(r), req.PassengerID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	quote, err := h.pricing.Estimate(ctx, &entity.Quote{
+		PassengerID: passengerID,
+		Origin:      req.Origin,
+		Destination: req.Destination,
+		CarType:     req.CarType,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(quote); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
 }
 
 func (h *RideHandler) CreateRide(w http.ResponseWriter, r *http.Request) {
 	var req createRideRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+	ctx, err := decodeValidated(r, &req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	passengerID, err := service.PassengerFor(caller(r), req.PassengerID)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
-	ride, err := h.service.CreateRide(r.Context(), req.PassengerID, req.Origin, req.Destination)
+	ride, err := h.service.CreateRide(ctx, &entity.Ride{
+		PassengerID: passengerID,
+		Origin:      req.Origin,
+		Destination: req.Destination,
+		CarType:     req.CarType,
+		ScheduledAt: req.ScheduledAt,
+	}, req.QuoteID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -49,19 +143,59 @@ func (h *RideHandler) CreateRide(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// EditRide changes a scheduled ride before it is released for dispatch.
+// Only the ride's passenger and admins may edit it.
+func (h *RideHandler) EditRide(w http.ResponseWriter, r *http.Request) {
+	rideID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidRideID)
+		return
+	}
+	var req editRideRequest
+	ctx, err := decodeValidated(r, &req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if _, err := h.authorizeRide(r, rideID); err != nil {
+		writeError(w, err)
+		return
+	}
+	if caller(r).Role == auth.RoleDriver {
+		writeError(w, customErrors.ErrForbidden)
+		return
+	}
+
+	ride, err := h.service.EditScheduledRide(ctx, rideID, entity.RideEdit{
+		Origin:      req.Origin,
+		Destination: req.Destination,
+		CarType:     req.CarType,
+		ScheduledAt: req.ScheduledAt,
+	}, req.QuoteID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(ride); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
 func (h *RideHandler) GetRide(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	idStr := vars["id"]
 
 	rideID, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid ride ID", http.StatusBadRequest)
+		writeError(w, customErrors.ErrInvalidRideID)
 		return
 	}
 
-	ride, err := h.service.GetRide(r.Context(), rideID)
+	ride, err := h.authorizeRide(r, rideID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -72,11 +206,21 @@ func (h *RideHandler) GetRide(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *RideHandler) GetAllRides(w http.ResponseWriter, r *http.Request) {
-	rides, err := h.service.GetAllRides(r.Context())
+	query, err := parseRideQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := service.RestrictRideQuery(caller(r), &query); err != nil {
+		writeError(w, err)
+		return
+	}
+	rides, total, err := h.service.ListRides(r.Context(), query)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
+	setTotalCount(w, total)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
@@ -85,68 +229,249 @@ func (h *RideHandler) GetAllRides(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *RideHandler) AssignDriverToRide(w http.ResponseWriter, r *http.Request) {
+func parseRideQuery(r *http.Request) (entity.RideQuery, error) {
+	q := r.URL.Query()
+	var (
+		query entity.RideQuery
+		err   error
+	)
+	query.Status = entity.Status(q.Get("status"))
+	if query.PassengerID, err = parseIntParam(q, "passenger_id"); err != nil {
+		return query, err
+	}
+	if query.DriverID, err = parseIntParam(q, "driver_id"); err != nil {
+		return query, err
+	}
+	if query.CreatedFrom, err = parseTimeParam(q, "created_from"); err != nil {
+		return query, err
+	}
+	if query.CreatedTo, err = parseTimeParam(q, "created_to"); err != nil {
+		return query, err
+	}
+	if query.Sort, err = parseSort(q, entity.RideSortFields); err != nil {
+		return query, err
+	}
+	query.Page, err = parsePage(q)
+	return query, err
+}
+
+func (h *RideHandler) UpdateRideStatus(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	idStr := vars["id"]
 
 	rideID, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid ride ID", http.StatusBadRequest)
+		writeError(w, customErrors.ErrInvalidRideID)
+		return
+	}
+	var req updateStatusRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
 		return
 	}
 
-	var req struct {
-		DriverID int `json:"driver_id"`
+	actor := entity.Actor(req.Actor)
+	if identity := caller(r); !identity.IsAdmin() {
+		ride, err := h.authorizeRide(r, rideID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if actor, err = service.StatusActor(identity, ride, entity.Status(req.Status), actor); err != nil {
+			writeError(w, err)
+			return
+		}
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+	if err := h.service.UpdateRideStatus(r.Context(), rideID, entity.Status(req.Status), actor, req.Reason); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(updateStatusResponse{
+		Message: "Ride status updated",
+		RideID:  rideID,
+		Status:  entity.Status(req.Status),
+	})
+	if err != nil {
 		return
 	}
+}
 
-	if err := h.service.AssignDriverToRide(r.Context(), rideID, req.DriverID); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+func (h *RideHandler) CancelRide(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+
+	rideID, err := strconv.Atoi(idStr)
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidRideID)
 		return
 	}
+	var req cancelRideRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	identity := caller(r)
+	if !identity.IsAdmin() {
+		if _, err := h.authorizeRide(r, rideID); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
 
+	actor := service.CancelActor(identity, entity.Actor(req.Actor))
+	ride, err := h.service.CancelRide(r.Context(), rideID, actor, entity.CancelReason(req.ReasonCode), req.Note)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(map[string]interface{}{
-		"message":   "Driver assigned successfully",
-		"ride_id":   rideID,
-		"driver_id": req.DriverID,
+	if err := json.NewEncoder(w).Encode(ride); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RideHandler) RateRide(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+
+	rideID, err := strconv.Atoi(idStr)
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidRideID)
+		return
+	}
+	var req rateRideRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Ratings are given by the people on the ride, never on their behalf.
+	identity := caller(r)
+	if identity.IsAdmin() {
+		writeError(w, customErrors.ErrForbidden)
+		return
+	}
+	if _, err := h.authorizeRide(r, rideID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	rating, err := h.ratings.RateRide(r.Context(), &entity.Rating{
+		RideID:  rideID,
+		Actor:   service.ActorOf(identity),
+		Score:   req.Score,
+		Comment: req.Comment,
 	})
 	if err != nil {
+		writeError(w, err)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(rating); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
 }
-func (h *RideHandler) UpdateRideStatus(w http.ResponseWriter, r *http.Request) {
+
+func (h *RideHandler) GetRideRatings(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	idStr := vars["id"]
 
 	rideID, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid ride ID", http.StatusBadRequest)
+		writeError(w, customErrors.ErrInvalidRideID)
 		return
 	}
-	var req updateStatusRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+
+	if _, err := h.authorizeRide(r, rideID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ratings, err := h.ratings.GetRideRatings(r.Context(), rideID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(ratings); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RideHandler) GetRideHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+
+	rideID, err := strconv.Atoi(idStr)
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidRideID)
 		return
 	}
 
-	if err := h.service.UpdateRideStatus(r.Context(), rideID, entity.Status(req.Status)); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if _, err := h.authorizeRide(r, rideID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	history, err := h.service.GetRideHistory(r.Context(), rideID)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(map[string]interface{}{
-		"message": "Ride status updated",
-		"ride_id": rideID,
-		"status":  req.Status,
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RideHandler) GetRideTransitions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+
+	rideID, err := strconv.Atoi(idStr)
+	if err != nil {
+		writeError(w, customErrors.ErrInvalidRideID)
+		return
+	}
+
+	if _, err := h.authorizeRide(r, rideID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ride, transitions, err := h.service.GetAllowedTransitions(r.Context(), rideID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(transitionsResponse{
+		RideID:      rideID,
+		Status:      ride.Status,
+		Transitions: transitions,
 	})
 	if err != nil {
 		return
 	}
 }
+
+// authorizeRide loads the ride if the caller may see it.
+func (h *RideHandler) authorizeRide(r *http.Request, rideID int) (*entity.Ride, error) {
+	ride, err := h.service.GetRide(r.Context(), rideID)
+	if err != nil {
+		return nil, err
+	}
+	if !service.CanSeeRide(caller(r), ride) {
+		return nil, customErrors.ErrForbidden
+	}
+	return ride, nil
+}
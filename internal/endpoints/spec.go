@@ -0,0 +1,152 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+)
+
+var (
+	public         []auth.Role
+	anyCaller      = []auth.Role{}
+	adminOnly      = []auth.Role{auth.RoleAdmin}
+	driversOnly    = []auth.Role{auth.RoleDriver, auth.RoleAdmin}
+	passengersOnly = []auth.Role{auth.RolePassenger, auth.RoleAdmin}
+	paging         = []parameter{
+		{name: "limit", in: "query", typ: "integer", description: "page size, default 50, at most 200"},
+		{name: "offset", in: "query", typ: "integer", description: "number of records to skip"},
+	}
+)
+
+func sortParam(fields ...string) parameter {
+	description := "sort field, prefixed with - for descending order: "
+	for i, field := range fields {
+		if i > 0 {
+			description += ", "
+		}
+		description += field
+	}
+	return parameter{name: "sort", in: "query", typ: "string", description: description}
+}
+
+func coordinateParams() []parameter {
+	return []parameter{
+		{name: "lat", in: "query", typ: "number", required: true},
+		{name: "lng", in: "query", typ: "number", required: true},
+	}
+}
+
+// apiOperations documents every route registered in cmd/main.go; the
+// OpenAPI document served at GET /openapi.json is built from it.
+var apiOperations = []operation{
+	// 🔐 Auth
+	{method: "POST", path: "/auth/login", tag: "Auth", summary: "Sign in and get an access token",
+		roles: public, request: loginRequest{}, status: http.StatusOK, response: auth.Session{}},
+
+	// 🧍 Passengers
+	{method: "POST", path: "/passengers", tag: "Passengers", summary: "Register a passenger",
+		roles: public, request: registerPassengerRequest{}, status: http.StatusCreated, response: passengerRegistration{}},
+	{method: "GET", path: "/passengers", tag: "Passengers", summary: "List passengers",
+		roles: adminOnly, params: append([]parameter{sortParam(entity.PassengerSortFields...)}, paging...),
+		status: http.StatusOK, response: []entity.Passenger{}, paged: true},
+	{method: "GET", path: "/passengers/{id}", tag: "Passengers", summary: "Get a passenger",
+		roles: passengersOnly, status: http.StatusOK, response: entity.Passenger{}},
+	{method: "DELETE", path: "/passengers/{id}", tag: "Passengers", summary: "Delete a passenger",
+		roles: adminOnly, status: http.StatusNoContent},
+
+	// 🚗 Drivers
+	{method: "POST", path: "/drivers", tag: "Drivers", summary: "Register a driver",
+		roles: public, request: registerDriverRequest{}, status: http.StatusCreated, response: driverRegistration{}},
+	{method: "GET", path: "/drivers", tag: "Drivers", summary: "List drivers",
+		roles: adminOnly, params: append([]parameter{
+			{name: "is_available", in: "query", typ: "boolean"},
+			{name: "car_type", in: "query", typ: "string"},
+			sortParam(entity.DriverSortFields...),
+		}, paging...),
+		status: http.StatusOK, response: []entity.Driver{}, paged: true},
+	{method: "GET", path: "/drivers/nearby", tag: "Drivers", summary: "Find available drivers near a point",
+		roles: passengersOnly, params: append(coordinateParams(),
+			parameter{name: "radius", in: "query", typ: "number", description: "search radius in km, default 5, at most 50"}),
+		status: http.StatusOK, response: []entity.NearbyDriver{}},
+	{method: "GET", path: "/drivers/{id}", tag: "Drivers", summary: "Get a driver",
+		roles: driversOnly, status: http.StatusOK, response: entity.Driver{}},
+	{method: "DELETE", path: "/drivers/{id}", tag: "Drivers", summary: "Delete a driver",
+		roles: adminOnly, status: http.StatusNoContent},
+	{method: "PUT", path: "/drivers/{id}/availability", tag: "Drivers", summary: "Go online or offline",
+		roles: driversOnly, request: updateAvailabilityRequest{}, status: http.StatusOK, response: entity.Driver{}},
+	{method: "PUT", path: "/drivers/{id}/location", tag: "Drivers", summary: "Report the driver's position",
+		roles: driversOnly, request: updateLocationRequest{}, status: http.StatusOK, response: entity.DriverLocation{}},
+
+	// 📨 Offers
+	{method: "GET", path: "/drivers/{id}/offers", tag: "Offers", summary: "List a driver's offers",
+		roles: driversOnly, params: []parameter{{name: "status", in: "query", typ: "string", description: "only offers in this status"}},
+		status: http.StatusOK, response: []entity.Offer{}},
+	{method: "POST", path: "/drivers/{id}/offers/{offerID}/accept", tag: "Offers", summary: "Accept an offer",
+		roles: driversOnly, status: http.StatusOK, response: entity.Offer{}},
+	{method: "POST", path: "/drivers/{id}/offers/{offerID}/decline", tag: "Offers", summary: "Decline an offer",
+		roles: driversOnly, status: http.StatusOK, response: entity.Offer{}},
+	{method: "PUT", path: "/rides/{id}/driver", tag: "Offers", summary: "Offer a ride to a specific driver",
+		roles: adminOnly, request: offerRideRequest{}, status: http.StatusCreated, response: entity.Offer{}},
+	{method: "GET", path: "/rides/{id}/offers", tag: "Offers", summary: "Offer history of a ride",
+		roles: adminOnly, status: http.StatusOK, response: []entity.Offer{}},
+
+	// 🚕 Rides
+	{method: "POST", path: "/rides", tag: "Rides", summary: "Book a ride, now or scheduled",
+		roles: passengersOnly, request: createRideRequest{}, status: http.StatusCreated, response: entity.Ride{}},
+	{method: "GET", path: "/rides", tag: "Rides", summary: "List rides",
+		roles: anyCaller, params: append([]parameter{
+			{name: "status", in: "query", typ: "string"},
+			{name: "passenger_id", in: "query", typ: "integer"},
+			{name: "driver_id", in: "query", typ: "integer"},
+			{name: "created_from", in: "query", typ: "string", format: "date-time"},
+			{name: "created_to", in: "query", typ: "string", format: "date-time"},
+			sortParam(entity.RideSortFields...),
+		}, paging...),
+		status: http.StatusOK, response: []entity.Ride{}, paged: true},
+	{method: "POST", path: "/rides/estimate", tag: "Rides", summary: "Get a fare quote",
+		roles: passengersOnly, request: estimateRequest{}, status: http.StatusOK, response: entity.Quote{}},
+	{method: "GET", path: "/rides/{id}", tag: "Rides", summary: "Get a ride",
+		roles: anyCaller, status: http.StatusOK, response: entity.Ride{}},
+	{method: "PATCH", path: "/rides/{id}", tag: "Rides", summary: "Edit a scheduled ride",
+		roles: passengersOnly, request: editRideRequest{}, status: http.StatusOK, response: entity.Ride{}},
+	{method: "POST", path: "/rides/{id}/cancel", tag: "Rides", summary: "Cancel a ride",
+		roles: anyCaller, request: cancelRideRequest{}, status: http.StatusOK, response: entity.Ride{}},
+	{method: "GET", path: "/rides/{id}/events", tag: "Rides", summary: "Stream live updates of a ride",
+		roles: anyCaller, params: []parameter{
+			{name: "last_event_id", in: "query", typ: "integer", format: "int64", description: "resume after this event"},
+			{name: "Last-Event-ID", in: "header", typ: "integer", format: "int64", description: "resume after this event"},
+			{name: "access_token", in: "query", typ: "string", description: "token for clients that cannot set headers"},
+		},
+		status: http.StatusOK, response: entity.RideEvent{}, stream: true},
+	{method: "GET", path: "/rides/{id}/history", tag: "Rides", summary: "Status history of a ride",
+		roles: anyCaller, status: http.StatusOK, response: []entity.StatusChange{}},
+	{method: "POST", path: "/rides/{id}/rating", tag: "Rides", summary: "Rate a completed ride",
+		roles: anyCaller, request: rateRideRequest{}, status: http.StatusCreated, response: entity.Rating{}},
+	{method: "GET", path: "/rides/{id}/ratings", tag: "Rides", summary: "Ratings of a ride",
+		roles: anyCaller, status: http.StatusOK, response: []entity.Rating{}},
+	{method: "PUT", path: "/rides/{id}/status", tag: "Rides", summary: "Update the status of a ride",
+		roles: driversOnly, request: updateStatusRequest{}, status: http.StatusOK, response: updateStatusResponse{}},
+	{method: "GET", path: "/rides/{id}/transitions", tag: "Rides", summary: "Allowed next statuses of a ride",
+		roles: anyCaller, status: http.StatusOK, response: transitionsResponse{}},
+
+	// 💰 Pricing
+	{method: "GET", path: "/pricing/surge", tag: "Pricing", summary: "Surge multiplier at a point",
+		roles: anyCaller, params: coordinateParams(), status: http.StatusOK, response: entity.Surge{}},
+
+	// 🪝 Webhooks
+	{method: "POST", path: "/webhooks", tag: "Webhooks", summary: "Subscribe to ride events",
+		roles: adminOnly, request: createWebhookRequest{}, status: http.StatusCreated, response: entity.Webhook{}},
+	{method: "GET", path: "/webhooks", tag: "Webhooks", summary: "List subscriptions",
+		roles: adminOnly, status: http.StatusOK, response: []entity.Webhook{}},
+	{method: "GET", path: "/webhooks/{id}", tag: "Webhooks", summary: "Get a subscription",
+		roles: adminOnly, status: http.StatusOK, response: entity.Webhook{}},
+	{method: "DELETE", path: "/webhooks/{id}", tag: "Webhooks", summary: "Delete a subscription",
+		roles: adminOnly, status: http.StatusNoContent},
+	{method: "GET", path: "/webhooks/{id}/deliveries", tag: "Webhooks", summary: "Delivery log of a subscription",
+		roles: adminOnly, status: http.StatusOK, response: []entity.WebhookDelivery{}},
+
+	// 📖 Docs
+	{method: "GET", path: "/openapi.json", tag: "Docs", summary: "This OpenAPI document",
+		roles: public, status: http.StatusOK, response: map[string]any{}},
+}
@@ -0,0 +1,32 @@
+package entity
+
+// CancelReason explains why a ride was cancelled.
+type CancelReason string
+
+const (
+	CancelChangedPlans         CancelReason = "changed_plans"
+	CancelDriverLate           CancelReason = "driver_late"
+	CancelWrongPickup          CancelReason = "wrong_pickup"
+	CancelFoundAlternative     CancelReason = "found_alternative"
+	CancelPassengerUnreachable CancelReason = "passenger_unreachable"
+	CancelVehicleProblem       CancelReason = "vehicle_problem"
+	CancelUnsafePickup         CancelReason = "unsafe_pickup"
+	CancelNoDriverFound        CancelReason = "no_driver_found"
+	CancelOther                CancelReason = "other"
+)
+
+// cancelReasons lists the reason codes each party may give.
+var cancelReasons = map[Actor][]CancelReason{
+	ActorPassenger: {CancelChangedPlans, CancelDriverLate, CancelWrongPickup, CancelFoundAlternative, CancelOther},
+	ActorDriver:    {CancelPassengerUnreachable, CancelVehicleProblem, CancelUnsafePickup, CancelOther},
+	ActorSystem:    {CancelNoDriverFound, CancelOther},
+}
+
+func (r CancelReason) ValidFor(actor Actor) bool {
+	for _, reason := range cancelReasons[actor] {
+		if reason == r {
+			return true
+		}
+	}
+	return false
+}
@@ -4,8 +4,15 @@ type Driver struct {
 	DriverID     int    `json:"driver_id"`
 	FirstName    string `json:"first_name"`
 	LastName     string `json:"last_name"`
-	PhoneNumber  int    `json:"phone_number"`
+	PhoneNumber  string `json:"phone_number,omitempty"` // E.164, e.g. "+14155550100"; empty in nearby searches, e.g. "+14155550100"
+	IsOnline     bool   `json:"is_online"`
 	IsAvailable  bool   `json:"is_available"`
 	CarType      string `json:"car_type"`
 	LicensePlate int    `json:"license_plate"`
+	// Rating is the average score passengers gave the driver, 0 until the
+	// first rating.
+	Rating      float64 `json:"rating"`
+	RatingCount int     `json:"rating_count"`
+	// PasswordHash is the bcrypt hash of the account password.
+	PasswordHash string `json:"-"`
 }
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type DriverLocation struct {
+	DriverID  int       `json:"driver_id"`
+	Latitude  float64   `json:"lat"`
+	Longitude float64   `json:"lng"`
+	Heading   float64   `json:"heading"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (l *DriverLocation) Point() Location {
+	return Location{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+type NearbyDriver struct {
+	Driver     *Driver         `json:"driver"`
+	Location   *DriverLocation `json:"location"`
+	DistanceKm float64         `json:"distance_km"`
+}
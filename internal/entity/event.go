@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+type RideEventType string
+
+const (
+	EventRideCreated       RideEventType = "ride.created"
+	EventRideUpdated       RideEventType = "ride.updated"
+	EventRideAssigned      RideEventType = "ride.assigned"
+	EventRideStatusChanged RideEventType = "ride.status_changed"
+	EventDriverLocation    RideEventType = "driver.location"
+)
+
+// RideEvent is something that happened to a ride, as pushed to the ride's
+// subscribers. Which of the optional fields is set depends on Type: Ride for
+// ride.created and ride.updated, Change (and DriverID) for ride.assigned and
+// ride.status_changed, Location for driver.location.
+type RideEvent struct {
+	ID       uint64          `json:"id"`
+	RideID   int             `json:"ride_id"`
+	Type     RideEventType   `json:"type"`
+	At       time.Time       `json:"at"`
+	Ride     *Ride           `json:"ride,omitempty"`
+	Change   *StatusChange   `json:"change,omitempty"`
+	DriverID int             `json:"driver_id,omitempty"`
+	Location *DriverLocation `json:"location,omitempty"`
+}
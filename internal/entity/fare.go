@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// FareBreakdown explains how a fare was computed. Amounts are in minor
+// currency units.
+type FareBreakdown struct {
+	Currency       string  `json:"currency"`
+	BaseFare       int64   `json:"base_fare"`
+	DistanceCharge int64   `json:"distance_charge"`
+	TimeCharge     int64   `json:"time_charge"`
+	Multiplier     float64 `json:"multiplier"`
+	Surge          float64 `json:"surge_multiplier"`
+	Total          int64   `json:"total"`
+}
+
+// Quote is a fare estimate that can be locked in by booking the ride before
+// ExpiresAt.
+type Quote struct {
+	QuoteID         int           `json:"quote_id"`
+	PassengerID     int           `json:"passenger_id,omitempty"`
+	Origin          Location      `json:"origin"`
+	Destination     Location      `json:"destination"`
+	CarType         string        `json:"car_type,omitempty"`
+	DistanceKm      float64       `json:"distance_km"`
+	DurationMinutes float64       `json:"duration_minutes"`
+	Fare            FareBreakdown `json:"fare"`
+	CreatedAt       time.Time     `json:"created_at"`
+	ExpiresAt       time.Time     `json:"expires_at"`
+	// UsedAt is when a ride was booked with the quote; a quote books one
+	// ride only.
+	UsedAt *time.Time `json:"used_at,omitempty"`
+}
+
+// Surge is the current surge multiplier of one pricing cell together with
+// the demand and supply it was computed from.
+type Surge struct {
+	Multiplier       float64   `json:"multiplier"`
+	PendingRides     int       `json:"pending_rides"`
+	AvailableDrivers int       `json:"available_drivers"`
+	ComputedAt       time.Time `json:"computed_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
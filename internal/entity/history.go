@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Actor is the party that changed a ride's status.
+type Actor string
+
+const (
+	ActorPassenger Actor = "passenger"
+	ActorDriver    Actor = "driver"
+	ActorSystem    Actor = "system"
+)
+
+func (a Actor) IsValid() bool {
+	switch a {
+	case ActorPassenger, ActorDriver, ActorSystem:
+		return true
+	}
+	return false
+}
+
+// StatusChange is one entry of a ride's status history. The first entry of
+// every ride has an empty From.
+type StatusChange struct {
+	From   Status    `json:"from,omitempty"`
+	To     Status    `json:"to"`
+	Actor  Actor     `json:"actor"`
+	Reason string    `json:"reason,omitempty"`
+	At     time.Time `json:"at"`
+}
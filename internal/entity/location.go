@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"encoding/json"
+	"math"
+)
+
+const earthRadiusKm = 6371.0
+
+// Location is a point on the map with an optional human readable address.
+// A location without coordinates (lat and lng both zero) is address-only.
+type Location struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lng"`
+	Address   string  `json:"address,omitempty"`
+}
+
+// UnmarshalJSON accepts either a location object or a bare string, which is
+// treated as an address-only location.
+func (l *Location) UnmarshalJSON(data []byte) error {
+	var address string
+	if err := json.Unmarshal(data, &address); err == nil {
+		*l = Location{Address: address}
+		return nil
+	}
+	type plain Location
+	return json.Unmarshal(data, (*plain)(l))
+}
+
+func (l Location) HasCoordinates() bool {
+	return l.Latitude != 0 || l.Longitude != 0
+}
+
+func (l Location) IsZero() bool {
+	return !l.HasCoordinates() && l.Address == ""
+}
+
+func (l Location) ValidLatitude() bool {
+	return l.Latitude >= -90 && l.Latitude <= 90
+}
+
+func (l Location) ValidLongitude() bool {
+	return l.Longitude >= -180 && l.Longitude <= 180
+}
+
+// DistanceKm returns the great-circle distance between two locations using
+// the haversine formula.
+func (l Location) DistanceKm(to Location) float64 {
+	lat1 := l.Latitude * math.Pi / 180
+	lat2 := to.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (to.Longitude - l.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+type OfferStatus string
+
+const (
+	OfferPending   OfferStatus = "pending"
+	OfferAccepted  OfferStatus = "accepted"
+	OfferDeclined  OfferStatus = "declined"
+	OfferExpired   OfferStatus = "expired"
+	OfferCancelled OfferStatus = "cancelled"
+)
+
+func (s OfferStatus) IsValid() bool {
+	switch s {
+	case OfferPending, OfferAccepted, OfferDeclined, OfferExpired, OfferCancelled:
+		return true
+	}
+	return false
+}
+
+// Offer proposes a pending ride to a single driver until it is answered or
+// ExpiresAt passes.
+type Offer struct {
+	OfferID     int         `json:"offer_id"`
+	RideID      int         `json:"ride_id"`
+	DriverID    int         `json:"driver_id"`
+	DistanceKm  float64     `json:"distance_km"`
+	Status      OfferStatus `json:"status"`
+	CreatedAt   time.Time   `json:"created_at"`
+	ExpiresAt   time.Time   `json:"expires_at"`
+	RespondedAt *time.Time  `json:"responded_at,omitempty"`
+}
+
+func (o *Offer) IsExpired(now time.Time) bool {
+	return o.Status == OfferPending && !now.Before(o.ExpiresAt)
+}
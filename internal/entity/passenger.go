@@ -4,5 +4,11 @@ type Passenger struct {
 	PassengerID int    `json:"passenger_id"`
 	FirstName   string `json:"first_name"`
 	LastName    string `json:"last_name"`
-	PhoneNumber int    `json:"phone_number"`
+	PhoneNumber string `json:"phone_number"` // E.164, e.g. "+14155550100"
+	// Rating is the average score drivers gave the passenger, 0 until the
+	// first rating.
+	Rating      float64 `json:"rating"`
+	RatingCount int     `json:"rating_count"`
+	// PasswordHash is the bcrypt hash of the account password.
+	PasswordHash string `json:"-"`
 }
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// SortOrder names the field a list is ordered by. Ties are always broken by
+// ascending ID so that paging through a list is stable.
+type SortOrder struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort reads a sort field, prefixed with "-" for descending order. It
+// reports false for a field that is not allowed.
+func ParseSort(raw string, allowed []string) (SortOrder, bool) {
+	order := SortOrder{Field: strings.TrimPrefix(raw, "-"), Desc: strings.HasPrefix(raw, "-")}
+	if !slices.Contains(allowed, order.Field) {
+		return SortOrder{}, false
+	}
+	return order, true
+}
+
+type RideQuery struct {
+	Status      Status
+	PassengerID int
+	DriverID    int
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Sort        SortOrder
+	Page        Page
+}
+
+func (q RideQuery) Matches(r *Ride) bool {
+	if q.Status != "" && r.Status != q.Status {
+		return false
+	}
+	if q.PassengerID != 0 && r.PassengerID != q.PassengerID {
+		return false
+	}
+	if q.DriverID != 0 && r.DriverID != q.DriverID {
+		return false
+	}
+	if !q.CreatedFrom.IsZero() && r.CreatedAt.Before(q.CreatedFrom) {
+		return false
+	}
+	if !q.CreatedTo.IsZero() && !r.CreatedAt.Before(q.CreatedTo) {
+		return false
+	}
+	return true
+}
+
+type DriverQuery struct {
+	IsAvailable *bool
+	CarType     string
+	Sort        SortOrder
+	Page        Page
+}
+
+func (q DriverQuery) Matches(d *Driver) bool {
+	if q.IsAvailable != nil && d.IsAvailable != *q.IsAvailable {
+		return false
+	}
+	if q.CarType != "" && d.CarType != q.CarType {
+		return false
+	}
+	return true
+}
+
+type PassengerQuery struct {
+	Sort SortOrder
+	Page Page
+}
+
+var (
+	RideSortFields      = []string{"ride_id", "created_at"}
+	DriverSortFields    = []string{"driver_id", "last_name"}
+	PassengerSortFields = []string{"passenger_id", "last_name"}
+)
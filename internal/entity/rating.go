@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+const (
+	MinRatingScore = 1
+	MaxRatingScore = 5
+)
+
+// Rating is one side's feedback on a completed ride: the passenger rates the
+// driver and the driver rates the passenger.
+type Rating struct {
+	RideID    int       `json:"ride_id"`
+	Actor     Actor     `json:"actor"`
+	Score     int       `json:"score"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
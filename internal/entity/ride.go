@@ -1,28 +1,111 @@
 package entity
 
+import "time"
+
 type Ride struct {
 	RideID      int        `json:"ride_id"`
 	PassengerID int        `json:"-"`
 	Passenger   *Passenger `json:"passenger,omitempty"`
 	Driver      *Driver    `json:"driver,omitempty"`
 	DriverID    int        `json:"-"`
-	Origin      string     `json:"origin"`
-	Destination string     `json:"destination"`
+	Origin      Location   `json:"origin"`
+	Destination Location   `json:"destination"`
+	DistanceKm  float64    `json:"distance_km,omitempty"`
+	CarType     string     `json:"car_type,omitempty"`
 	Status      Status     `json:"status"`
+	// ScheduledAt is the requested pickup time of a ride booked in advance;
+	// it is nil for immediate rides.
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+	// Fares are in minor units of Currency. QuotedFare and SurgeMultiplier
+	// are locked in when the ride is booked, FinalFare is computed when it
+	// is completed using the same surge.
+	Currency        string  `json:"currency,omitempty"`
+	QuotedFare      int64   `json:"quoted_fare,omitempty"`
+	SurgeMultiplier float64 `json:"surge_multiplier,omitempty"`
+	FinalFare       int64   `json:"final_fare,omitempty"`
+	// CancellationFee is charged to a passenger who cancels too long after a
+	// driver accepted the ride.
+	CancellationFee int64        `json:"cancellation_fee,omitempty"`
+	CancelledBy     Actor        `json:"cancelled_by,omitempty"`
+	CancelReason    CancelReason `json:"cancel_reason,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+	AcceptedAt      *time.Time   `json:"accepted_at,omitempty"`
+	StartedAt       *time.Time   `json:"started_at,omitempty"`
+	CompletedAt     *time.Time   `json:"completed_at,omitempty"`
+	CancelledAt     *time.Time   `json:"cancelled_at,omitempty"`
 }
 type Status string
 
 const (
-	StatusPending   Status = "pending"
-	StatusAccepted  Status = "accepted"
-	StatusCompleted Status = "completed"
-	StatusCancelled Status = "cancelled"
+	StatusScheduled      Status = "scheduled"
+	StatusPending        Status = "pending"
+	StatusAccepted       Status = "accepted"
+	StatusDriverArriving Status = "driver_arriving"
+	StatusInProgress     Status = "in_progress"
+	StatusCompleted      Status = "completed"
+	StatusCancelled      Status = "cancelled"
+	StatusNoShow         Status = "no_show"
 )
 
+// transitions lists, for every status, the statuses a ride may move to next.
+// Statuses without an entry are terminal.
+var transitions = map[Status][]Status{
+	StatusScheduled:      {StatusPending, StatusCancelled},
+	StatusPending:        {StatusAccepted, StatusCancelled},
+	StatusAccepted:       {StatusDriverArriving, StatusInProgress, StatusCancelled},
+	StatusDriverArriving: {StatusInProgress, StatusNoShow, StatusCancelled},
+	StatusInProgress:     {StatusCompleted},
+}
+
 func (s Status) IsValid() bool {
 	switch s {
-	case StatusPending, StatusAccepted, StatusCompleted, StatusCancelled:
+	case StatusScheduled, StatusPending, StatusAccepted, StatusDriverArriving, StatusInProgress,
+		StatusCompleted, StatusCancelled, StatusNoShow:
 		return true
 	}
 	return false
 }
+
+// TerminalStatuses returns every status a ride cannot leave.
+func TerminalStatuses() []Status {
+	return []Status{StatusCompleted, StatusCancelled, StatusNoShow}
+}
+
+func (s Status) IsTerminal() bool {
+	return s.IsValid() && len(transitions[s]) == 0
+}
+
+func (s Status) NextStatuses() []Status {
+	next := make([]Status, len(transitions[s]))
+	copy(next, transitions[s])
+	return next
+}
+
+// RequiresDriver reports whether a ride in this status must have a driver.
+// Rides only get one by assignment, which is also what moves them to
+// accepted.
+func (s Status) RequiresDriver() bool {
+	switch s {
+	case StatusAccepted, StatusDriverArriving, StatusInProgress:
+		return true
+	}
+	return false
+}
+
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// RideEdit holds the changes to a scheduled ride; nil fields are left as
+// they are.
+type RideEdit struct {
+	Origin      *Location
+	Destination *Location
+	CarType     *string
+	ScheduledAt *time.Time
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// RideEventTypes lists every event a webhook can subscribe to.
+var RideEventTypes = []RideEventType{EventRideCreated, EventRideUpdated, EventRideAssigned, EventRideStatusChanged, EventDriverLocation}
+
+func (t RideEventType) IsValid() bool {
+	for _, valid := range RideEventTypes {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook is a subscription of an outside service to ride events. Secret
+// signs every delivery and is never returned by the API.
+type Webhook struct {
+	WebhookID int             `json:"webhook_id"`
+	URL       string          `json:"url"`
+	Events    []RideEventType `json:"events"`
+	Secret    string          `json:"-"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (w *Webhook) Wants(eventType RideEventType) bool {
+	for _, t := range w.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery records one attempt to deliver an event to a webhook.
+// NextAttemptAt is set when the attempt failed and will be retried.
+type WebhookDelivery struct {
+	DeliveryID    int           `json:"delivery_id"`
+	WebhookID     int           `json:"webhook_id"`
+	EventID       uint64        `json:"event_id"`
+	EventType     RideEventType `json:"event_type"`
+	RideID        int           `json:"ride_id"`
+	Attempt       int           `json:"attempt"`
+	Succeeded     bool          `json:"succeeded"`
+	StatusCode    int           `json:"status_code,omitempty"`
+	Error         string        `json:"error,omitempty"`
+	AttemptedAt   time.Time     `json:"attempted_at"`
+	NextAttemptAt *time.Time    `json:"next_attempt_at,omitempty"`
+}
@@ -1,16 +1,57 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
+	ErrInvalidPayload                     = errors.New("invalid request payload")
+	ErrValidation                         = errors.New("request validation failed")
+	ErrUnknownField                       = errors.New("unknown field")
+	ErrInvalidFieldType                   = errors.New("value has the wrong type")
+	ErrInvalidRideID                      = errors.New("invalid ride ID")
+	ErrInvalidPassengerID                 = errors.New("invalid passenger ID")
+	ErrInvalidDriverID                    = errors.New("invalid driver ID")
+	ErrInvalidQueryParam                  = errors.New("invalid query parameter")
+	ErrInvalidLastEventID                 = errors.New("invalid last event ID")
+	ErrInvalidWebhookID                   = errors.New("invalid webhook ID")
+	ErrWebhookNotFound                    = errors.New("webhook not found")
+	ErrInvalidWebhookURL                  = errors.New("url must be an absolute http or https URL")
+	ErrWebhookEventsRequired              = errors.New("at least one event type is required")
+	ErrInvalidEventType                   = errors.New("unknown event type")
+	ErrWebhookSecretRequired              = errors.New("secret is required")
+	ErrInvalidSignature                   = errors.New("invalid webhook signature")
 	ErrRideNotFound                       = errors.New("ride not found")
 	ErrRideIDRequired                     = errors.New("ride ID is required")
+	ErrRideDataRequired                   = errors.New("ride data is required")
 	ErrOriginRequired                     = errors.New("origin is required")
 	ErrDestinationRequired                = errors.New("destination is required")
+	ErrSameOriginDestination              = errors.New("destination must differ from origin")
+	ErrInvalidLatitude                    = errors.New("latitude must be between -90 and 90")
+	ErrInvalidLongitude                   = errors.New("longitude must be between -180 and 180")
 	ErrPassengerIDRequired                = errors.New("passenger ID is required")
 	ErrInvalidRideStatus                  = errors.New("invalid ride status")
-	ErrCannotChangeCompletedRide          = errors.New("cannot change completed ride")
-	ErrDriverIDRequired                   = errors.New("drive ID is required")
+	ErrInvalidActor                       = errors.New("actor must be passenger, driver or system")
+	ErrInvalidCancelReason                = errors.New("invalid cancellation reason for this actor")
+	ErrRideNotAssigned                    = errors.New("ride has no driver assigned")
+	ErrRideNotCompleted                   = errors.New("ride is not completed")
+	ErrRideNotScheduled                   = errors.New("ride is not scheduled or was already released for dispatch")
+	ErrInvalidScheduledAt                 = errors.New("scheduled_at must be in the future and at most 30 days ahead")
+	ErrInvalidRater                       = errors.New("actor must be passenger or driver")
+	ErrInvalidScore                       = errors.New("score must be between 1 and 5")
+	ErrAlreadyRated                       = errors.New("ride already rated by this party")
+	ErrAuthenticationRequired             = errors.New("authentication required")
+	ErrInvalidToken                       = errors.New("invalid or expired token")
+	ErrInvalidCredentials                 = errors.New("invalid phone number or password")
+	ErrForbidden                          = errors.New("not allowed for this account")
+	ErrPasswordRequired                   = errors.New("password is required")
+	ErrPasswordTooShort                   = errors.New("password must be at least 8 characters")
+	ErrPasswordTooLong                    = errors.New("password must be at most 72 bytes")
+	ErrInvalidRole                        = errors.New("role must be passenger, driver or admin")
+	ErrInvalidStatusTransition            = errors.New("invalid ride status transition")
+	ErrDriverIDRequired                   = errors.New("driver ID is required")
 	ErrCannotAssignDriverToNonPendingRide = errors.New("cannot assign driver to non pending ride")
 	ErrRideAlreadyAssigned                = errors.New("ride already assigned")
 	ErrDriverAlreadyAssignedToRide        = errors.New("this driver already assigned to this ride")
@@ -18,6 +59,9 @@ var (
 	ErrFirstName                          = errors.New("first name is required")
 	ErrLastName                           = errors.New("last name is required")
 	ErrPhoneNumber                        = errors.New("phone number is required")
+	ErrInvalidName                        = errors.New("name must be at most 50 characters of letters, spaces, hyphens, apostrophes or periods, starting with a letter")
+	ErrInvalidPhoneNumber                 = errors.New("phone number must be in international format, such as +14155550100, or a local number, with 8 to 15 digits including the country code")
+	ErrInvalidLicensePlate                = errors.New("license plate must be a positive number of at most 8 digits")
 	ErrPassengerDataRequired              = errors.New("passenger data required")
 	ErrPhoneNumberExists                  = errors.New("phone number exists")
 	ErrDriverNotFound                     = errors.New("driver not found")
@@ -25,4 +69,77 @@ var (
 	ErrCarTypeRequired                    = errors.New("car type is required")
 	ErrLicensePlateRequired               = errors.New("license plate is required")
 	ErrDriverAlreadyOnActiveRide          = errors.New("driver already on active ride")
+	ErrDriverUnavailable                  = errors.New("driver is not available")
+	ErrOnlineRequired                     = errors.New("is_online is required")
+	ErrOfferNotFound                      = errors.New("offer not found")
+	ErrOfferNotPending                    = errors.New("offer is no longer pending")
+	ErrOfferExpired                       = errors.New("offer has expired")
+	ErrOfferAlreadyPending                = errors.New("ride or driver already has a pending offer")
+	ErrOfferIDRequired                    = errors.New("offer ID is required")
+	ErrInvalidOfferID                     = errors.New("invalid offer ID")
+	ErrLocationNotFound                   = errors.New("driver location not found")
+	ErrInvalidHeading                     = errors.New("heading must be between 0 and 360")
+	ErrTimestampInFuture                  = errors.New("timestamp must not be in the future")
+	ErrInvalidRadius                      = errors.New("radius must be greater than 0 and at most 50 km")
+	ErrCoordinatesRequired                = errors.New("origin and destination coordinates are required for a fare estimate")
+	ErrQuoteNotFound                      = errors.New("quote not found")
+	ErrQuoteExpired                       = errors.New("quote has expired")
+	ErrQuoteUsed                          = errors.New("quote has already been used")
+	ErrQuoteMismatch                      = errors.New("quote does not match the ride")
 )
+
+// FieldError attaches the name of the offending request field to err.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError reports every problem found in a request at once, each
+// as a FieldError. It matches ErrValidation, and any of its field errors,
+// with errors.Is.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		msgs[i] = fieldErr.Error()
+	}
+	return ErrValidation.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		errs[i] = fieldErr
+	}
+	return errs
+}
+
+// TransitionError reports a ride status change that the ride lifecycle does
+// not allow. It matches ErrInvalidStatusTransition with errors.Is.
+type TransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot change ride status from %q to %q", e.From, e.To)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidStatusTransition
+}
@@ -0,0 +1,93 @@
+package geo
+
+import (
+	"math"
+	"taxiAPI/internal/entity"
+)
+
+// kmPerDegree is the length of one degree of latitude, and of longitude at the
+// equator.
+const kmPerDegree = 111.32
+
+// Cell identifies one square of a fixed-size latitude/longitude grid.
+type Cell struct {
+	Row int
+	Col int
+}
+
+// CellAt returns the cell containing loc. Longitude 180 falls in the same
+// cell as -180.
+func CellAt(loc entity.Location, sizeDeg float64) Cell {
+	return Cell{
+		Row: int(math.Floor(loc.Latitude / sizeDeg)),
+		Col: wrapCol(int(math.Floor(loc.Longitude/sizeDeg)), sizeDeg),
+	}
+}
+
+// CellsWithin returns every cell that may contain a point within radiusKm of
+// center. The result over-approximates the circle; callers still have to
+// check the exact distance. Circles crossing the antimeridian include the
+// cells on its far side, and circles reaching a pole include every
+// longitude, each cell once. If that takes more than maxCells cells,
+// CellsWithin returns false instead, and the caller is better off scanning
+// everything it has.
+func CellsWithin(center entity.Location, radiusKm, sizeDeg float64, maxCells int) ([]Cell, bool) {
+	latSpan := radiusKm / kmPerDegree
+	minLat := math.Max(center.Latitude-latSpan, -90)
+	maxLat := math.Min(center.Latitude+latSpan, 90)
+
+	// A degree of longitude is shortest on the edge of the circle nearest
+	// the pole, so the span measured there covers the whole circle.
+	cols := colsAround(sizeDeg)
+	minCol, maxCol := 0, cols-1
+	if edgeLat := math.Max(math.Abs(minLat), math.Abs(maxLat)); edgeLat < 90 {
+		lngSpan := radiusKm / (kmPerDegree * math.Cos(edgeLat*math.Pi/180))
+		if lngSpan < 180 {
+			minCol = int(math.Floor((center.Longitude - lngSpan) / sizeDeg))
+			maxCol = int(math.Floor((center.Longitude + lngSpan) / sizeDeg))
+		}
+	}
+	if maxCol-minCol+1 > cols {
+		maxCol = minCol + cols - 1
+	}
+
+	minRow := int(math.Floor(minLat / sizeDeg))
+	maxRow := int(math.Floor(maxLat / sizeDeg))
+	if (maxRow-minRow+1)*(maxCol-minCol+1) > maxCells {
+		return nil, false
+	}
+	cells := make([]Cell, 0, (maxRow-minRow+1)*(maxCol-minCol+1))
+	for row := minRow; row <= maxRow; row++ {
+		for col := minCol; col <= maxCol; col++ {
+			cells = append(cells, Cell{Row: row, Col: wrapCol(col, sizeDeg)})
+		}
+	}
+	return cells, true
+}
+
+// colsAround is the number of columns in one full turn of longitude.
+func colsAround(sizeDeg float64) int {
+	return int(math.Ceil(360/sizeDeg - 1e-9))
+}
+
+// wrapCol maps a column past the antimeridian back onto the one holding
+// the same longitudes in [-180, 180).
+func wrapCol(col int, sizeDeg float64) int {
+	cols := colsAround(sizeDeg)
+	first := int(math.Floor(-180/sizeDeg + 1e-9))
+	return first + ((col-first)%cols+cols)%cols
+}
+
+// Center returns the midpoint of the cell.
+func (c Cell) Center(sizeDeg float64) entity.Location {
+	return entity.Location{
+		Latitude:  (float64(c.Row) + 0.5) * sizeDeg,
+		Longitude: (float64(c.Col) + 0.5) * sizeDeg,
+	}
+}
+
+// CellRadiusKm is the distance from the center of a cell to its corners, an
+// upper bound that holds at any latitude.
+func CellRadiusKm(sizeDeg float64) float64 {
+	return sizeDeg * kmPerDegree * math.Sqrt2 / 2
+}
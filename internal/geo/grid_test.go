@@ -0,0 +1,64 @@
+package geo
+
+import (
+	"testing"
+
+	"taxiAPI/internal/entity"
+)
+
+func TestCellAtWrapsAntimeridian(t *testing.T) {
+	east := CellAt(entity.Location{Latitude: 10, Longitude: 180}, 0.01)
+	west := CellAt(entity.Location{Latitude: 10, Longitude: -180}, 0.01)
+	if east != west {
+		t.Fatalf("CellAt(lng 180) = %v, CellAt(lng -180) = %v; want the same cell", east, west)
+	}
+}
+
+func TestCellsWithin(t *testing.T) {
+	const size = 0.01
+	for _, tc := range []struct {
+		name     string
+		center   entity.Location
+		radiusKm float64
+		want     []entity.Location
+	}{
+		{"around the center", entity.Location{Latitude: 52.52, Longitude: 13.405}, 2,
+			[]entity.Location{{Latitude: 52.53, Longitude: 13.42}, {Latitude: 52.51, Longitude: 13.39}}},
+		{"across the antimeridian from the east", entity.Location{Latitude: -17.7, Longitude: 179.99}, 5,
+			[]entity.Location{{Latitude: -17.7, Longitude: -179.98}}},
+		{"across the antimeridian from the west", entity.Location{Latitude: -17.7, Longitude: -179.99}, 5,
+			[]entity.Location{{Latitude: -17.7, Longitude: 179.98}}},
+		{"over the pole", entity.Location{Latitude: 89.99, Longitude: 0}, 5,
+			[]entity.Location{{Latitude: 89.995, Longitude: 179.5}, {Latitude: 89.99, Longitude: -90}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cells, ok := CellsWithin(tc.center, tc.radiusKm, size, 1<<30)
+			if !ok {
+				t.Fatal("CellsWithin gave up below its limit")
+			}
+			seen := make(map[Cell]bool, len(cells))
+			for _, cell := range cells {
+				if seen[cell] {
+					t.Fatalf("cell %v returned twice", cell)
+				}
+				seen[cell] = true
+			}
+			for _, loc := range append(tc.want, tc.center) {
+				if !seen[CellAt(loc, size)] {
+					t.Errorf("cell of %+v (%.2f km away) is missing", loc, tc.center.DistanceKm(loc))
+				}
+			}
+		})
+	}
+}
+
+func TestCellsWithinLimit(t *testing.T) {
+	polar := entity.Location{Latitude: 89.9, Longitude: 0}
+	if cells, ok := CellsWithin(polar, 50, 0.01, 10_000); ok {
+		t.Fatalf("CellsWithin near the pole returned %d cells, want it to give up", len(cells))
+	}
+	cells, ok := CellsWithin(entity.Location{Latitude: 0, Longitude: 0}, 1, 0.01, 10_000)
+	if !ok || len(cells) > 10_000 {
+		t.Fatalf("CellsWithin = %d cells, %v; want a handful", len(cells), ok)
+	}
+}
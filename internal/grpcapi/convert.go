@@ -0,0 +1,175 @@
+package grpcapi
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+	"taxiAPI/internal/grpcapi/taxipb"
+)
+
+// timestamp converts t, leaving the zero time unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
+
+// fromTimestamp converts ts, returning the zero time when it is unset.
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func fromOptionalTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func protoSession(s *auth.Session) *taxipb.Session {
+	return &taxipb.Session{
+		Token:     s.Token,
+		ExpiresAt: timestamp(s.ExpiresAt),
+		Role:      string(s.Role),
+		Id:        int64(s.ID),
+	}
+}
+
+func protoLocation(l entity.Location) *taxipb.Location {
+	return &taxipb.Location{Lat: l.Latitude, Lng: l.Longitude, Address: l.Address}
+}
+
+func fromLocation(l *taxipb.Location) entity.Location {
+	return entity.Location{Latitude: l.GetLat(), Longitude: l.GetLng(), Address: l.GetAddress()}
+}
+
+func fromOptionalLocation(l *taxipb.Location) *entity.Location {
+	if l == nil {
+		return nil
+	}
+	loc := fromLocation(l)
+	return &loc
+}
+
+func protoPassenger(p *entity.Passenger) *taxipb.Passenger {
+	if p == nil {
+		return nil
+	}
+	return &taxipb.Passenger{
+		PassengerId: int64(p.PassengerID),
+		FirstName:   p.FirstName,
+		LastName:    p.LastName,
+		PhoneNumber: p.PhoneNumber,
+		Rating:      p.Rating,
+		RatingCount: int64(p.RatingCount),
+	}
+}
+
+func protoDriver(d *entity.Driver) *taxipb.Driver {
+	if d == nil {
+		return nil
+	}
+	return &taxipb.Driver{
+		DriverId:     int64(d.DriverID),
+		FirstName:    d.FirstName,
+		LastName:     d.LastName,
+		PhoneNumber:  d.PhoneNumber,
+		IsOnline:     d.IsOnline,
+		IsAvailable:  d.IsAvailable,
+		CarType:      d.CarType,
+		LicensePlate: int64(d.LicensePlate),
+		Rating:       d.Rating,
+		RatingCount:  int64(d.RatingCount),
+	}
+}
+
+func protoDriverLocation(l *entity.DriverLocation) *taxipb.DriverLocation {
+	if l == nil {
+		return nil
+	}
+	return &taxipb.DriverLocation{
+		DriverId:  int64(l.DriverID),
+		Lat:       l.Latitude,
+		Lng:       l.Longitude,
+		Heading:   l.Heading,
+		Timestamp: timestamp(l.Timestamp),
+	}
+}
+
+func protoRide(r *entity.Ride) *taxipb.Ride {
+	if r == nil {
+		return nil
+	}
+	return &taxipb.Ride{
+		RideId:          int64(r.RideID),
+		Passenger:       protoPassenger(r.Passenger),
+		Driver:          protoDriver(r.Driver),
+		Origin:          protoLocation(r.Origin),
+		Destination:     protoLocation(r.Destination),
+		DistanceKm:      r.DistanceKm,
+		CarType:         r.CarType,
+		Status:          string(r.Status),
+		ScheduledAt:     optionalTimestamp(r.ScheduledAt),
+		Currency:        r.Currency,
+		QuotedFare:      r.QuotedFare,
+		SurgeMultiplier: r.SurgeMultiplier,
+		FinalFare:       r.FinalFare,
+		CancellationFee: r.CancellationFee,
+		CancelledBy:     string(r.CancelledBy),
+		CancelReason:    string(r.CancelReason),
+		CreatedAt:       timestamp(r.CreatedAt),
+		AcceptedAt:      optionalTimestamp(r.AcceptedAt),
+		StartedAt:       optionalTimestamp(r.StartedAt),
+		CompletedAt:     optionalTimestamp(r.CompletedAt),
+		CancelledAt:     optionalTimestamp(r.CancelledAt),
+	}
+}
+
+func protoStatusChange(c *entity.StatusChange) *taxipb.StatusChange {
+	if c == nil {
+		return nil
+	}
+	return &taxipb.StatusChange{
+		From:   string(c.From),
+		To:     string(c.To),
+		Actor:  string(c.Actor),
+		Reason: c.Reason,
+		At:     timestamp(c.At),
+	}
+}
+
+func protoRideEvent(e entity.RideEvent) *taxipb.RideEvent {
+	return &taxipb.RideEvent{
+		Id:       e.ID,
+		RideId:   int64(e.RideID),
+		Type:     string(e.Type),
+		At:       timestamp(e.At),
+		Ride:     protoRide(e.Ride),
+		Change:   protoStatusChange(e.Change),
+		DriverId: int64(e.DriverID),
+		Location: protoDriverLocation(e.Location),
+	}
+}
+
+func protoStatuses(statuses []entity.Status) []string {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return names
+}
@@ -0,0 +1,121 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+	"taxiAPI/internal/grpcapi/taxipb"
+	"taxiAPI/internal/service"
+)
+
+type driverServer struct {
+	taxipb.UnimplementedDriverServiceServer
+	service *service.DriverService
+	auth    *service.AuthService
+}
+
+func (s *driverServer) RegisterDriver(ctx context.Context, req *taxipb.RegisterDriverRequest) (*taxipb.DriverRegistration, error) {
+	created, err := s.service.RegisterDriver(ctx, &entity.Driver{
+		FirstName:    req.GetFirstName(),
+		LastName:     req.GetLastName(),
+		PhoneNumber:  req.GetPhoneNumber(),
+		CarType:      req.GetCarType(),
+		LicensePlate: int(req.GetLicensePlate()),
+	}, req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.auth.IssueSession(auth.Identity{Role: auth.RoleDriver, ID: created.DriverID})
+	if err != nil {
+		return nil, err
+	}
+	return &taxipb.DriverRegistration{Driver: protoDriver(created), Session: protoSession(session)}, nil
+}
+
+func (s *driverServer) GetDriver(ctx context.Context, req *taxipb.GetDriverRequest) (*taxipb.Driver, error) {
+	id := int(req.GetDriverId())
+	if err := service.RequireSelf(caller(ctx), auth.RoleDriver, id); err != nil {
+		return nil, err
+	}
+	driver, err := s.service.GetDriverByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return protoDriver(driver), nil
+}
+
+func (s *driverServer) ListDrivers(ctx context.Context, req *taxipb.ListDriversRequest) (*taxipb.ListDriversResponse, error) {
+	query := entity.DriverQuery{IsAvailable: req.IsAvailable, CarType: req.GetCarType()}
+	var err error
+	if query.Sort, err = sortOrder(req.GetSort(), entity.DriverSortFields); err != nil {
+		return nil, err
+	}
+	if query.Page, err = page(req.GetLimit(), req.GetOffset()); err != nil {
+		return nil, err
+	}
+	drivers, total, err := s.service.ListDrivers(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	resp := &taxipb.ListDriversResponse{TotalCount: int64(total)}
+	for _, d := range drivers {
+		resp.Drivers = append(resp.Drivers, protoDriver(d))
+	}
+	return resp, nil
+}
+
+func (s *driverServer) DeleteDriver(ctx context.Context, req *taxipb.DeleteDriverRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteDriver(ctx, int(req.GetDriverId())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *driverServer) SetAvailability(ctx context.Context, req *taxipb.SetAvailabilityRequest) (*taxipb.Driver, error) {
+	id := int(req.GetDriverId())
+	if err := service.RequireSelf(caller(ctx), auth.RoleDriver, id); err != nil {
+		return nil, err
+	}
+	driver, err := s.service.SetOnline(ctx, id, req.GetIsOnline())
+	if err != nil {
+		return nil, err
+	}
+	return protoDriver(driver), nil
+}
+
+func (s *driverServer) UpdateLocation(ctx context.Context, req *taxipb.UpdateLocationRequest) (*taxipb.DriverLocation, error) {
+	id := int(req.GetDriverId())
+	if err := service.RequireSelf(caller(ctx), auth.RoleDriver, id); err != nil {
+		return nil, err
+	}
+	loc, err := s.service.UpdateLocation(ctx, &entity.DriverLocation{
+		DriverID:  id,
+		Latitude:  req.GetLat(),
+		Longitude: req.GetLng(),
+		Heading:   req.GetHeading(),
+		Timestamp: fromTimestamp(req.GetTimestamp()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return protoDriverLocation(loc), nil
+}
+
+func (s *driverServer) FindNearbyDrivers(ctx context.Context, req *taxipb.FindNearbyDriversRequest) (*taxipb.FindNearbyDriversResponse, error) {
+	nearby, err := s.service.FindNearbyDrivers(ctx, entity.Location{Latitude: req.GetLat(), Longitude: req.GetLng()}, req.GetRadiusKm())
+	if err != nil {
+		return nil, err
+	}
+	resp := &taxipb.FindNearbyDriversResponse{}
+	for _, n := range nearby {
+		resp.Drivers = append(resp.Drivers, &taxipb.NearbyDriver{
+			Driver:     protoDriver(n.Driver),
+			Location:   protoDriverLocation(n.Location),
+			DistanceKm: n.DistanceKm,
+		})
+	}
+	return resp, nil
+}
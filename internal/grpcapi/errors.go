@@ -0,0 +1,71 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"taxiAPI/internal/endpoints"
+	customErrors "taxiAPI/internal/errors"
+)
+
+// errorDomain is the ErrorInfo domain of the errors the API reports.
+const errorDomain = "taxiAPI"
+
+// httpCodes maps the HTTP statuses the REST API reports errors with to
+// gRPC codes.
+var httpCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+}
+
+// toStatus turns an error from the service layer into a gRPC status. The
+// status is classified like the REST API's error responses and carries the
+// same error code and field in an ErrorInfo detail; a validation error also
+// lists its problems in a BadRequest detail.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	httpStatus, code, field := endpoints.ErrorStatus(err)
+	grpcCode, ok := httpCodes[httpStatus]
+	if !ok {
+		slog.Error("unhandled error", "err", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+	if errors.Is(err, customErrors.ErrPhoneNumberExists) {
+		grpcCode = codes.AlreadyExists
+	}
+	info := &errdetails.ErrorInfo{Reason: code, Domain: errorDomain}
+	if field != "" {
+		info.Metadata = map[string]string{"field": field}
+	}
+	details := []protoadapt.MessageV1{info}
+	if violations := endpoints.Violations(err); violations != nil {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field: v.Field, Reason: v.Code, Description: v.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	st, detailErr := status.New(grpcCode, err.Error()).WithDetails(details...)
+	if detailErr != nil {
+		return status.Error(grpcCode, err.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,88 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+	"taxiAPI/internal/grpcapi/taxipb"
+	"taxiAPI/internal/service"
+)
+
+type authServer struct {
+	taxipb.UnimplementedAuthServiceServer
+	auth *service.AuthService
+}
+
+func (s *authServer) Login(ctx context.Context, req *taxipb.LoginRequest) (*taxipb.Session, error) {
+	session, err := s.auth.Login(ctx, auth.Role(req.GetRole()), req.GetPhoneNumber(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	return protoSession(session), nil
+}
+
+type passengerServer struct {
+	taxipb.UnimplementedPassengerServiceServer
+	service *service.PassengerService
+	auth    *service.AuthService
+}
+
+func (s *passengerServer) RegisterPassenger(ctx context.Context, req *taxipb.RegisterPassengerRequest) (*taxipb.PassengerRegistration, error) {
+	created, err := s.service.RegisterPassenger(ctx, &entity.Passenger{
+		FirstName:   req.GetFirstName(),
+		LastName:    req.GetLastName(),
+		PhoneNumber: req.GetPhoneNumber(),
+	}, req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.auth.IssueSession(auth.Identity{Role: auth.RolePassenger, ID: created.PassengerID})
+	if err != nil {
+		return nil, err
+	}
+	return &taxipb.PassengerRegistration{Passenger: protoPassenger(created), Session: protoSession(session)}, nil
+}
+
+func (s *passengerServer) GetPassenger(ctx context.Context, req *taxipb.GetPassengerRequest) (*taxipb.Passenger, error) {
+	id := int(req.GetPassengerId())
+	if err := service.RequireSelf(caller(ctx), auth.RolePassenger, id); err != nil {
+		return nil, err
+	}
+	passenger, err := s.service.GetPassengerByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return protoPassenger(passenger), nil
+}
+
+func (s *passengerServer) ListPassengers(ctx context.Context, req *taxipb.ListPassengersRequest) (*taxipb.ListPassengersResponse, error) {
+	var (
+		query entity.PassengerQuery
+		err   error
+	)
+	if query.Sort, err = sortOrder(req.GetSort(), entity.PassengerSortFields); err != nil {
+		return nil, err
+	}
+	if query.Page, err = page(req.GetLimit(), req.GetOffset()); err != nil {
+		return nil, err
+	}
+	passengers, total, err := s.service.ListPassengers(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	resp := &taxipb.ListPassengersResponse{TotalCount: int64(total)}
+	for _, p := range passengers {
+		resp.Passengers = append(resp.Passengers, protoPassenger(p))
+	}
+	return resp, nil
+}
+
+func (s *passengerServer) DeletePassenger(ctx context.Context, req *taxipb.DeletePassengerRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeletePassenger(ctx, int(req.GetPassengerId())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,33 @@
+package grpcapi
+
+import (
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+func invalidField(name string) error {
+	return &customErrors.FieldError{Field: name, Err: customErrors.ErrInvalidQueryParam}
+}
+
+// sortOrder reads "field" or "-field" for descending order; empty keeps
+// the default order.
+func sortOrder(raw string, allowed []string) (entity.SortOrder, error) {
+	if raw == "" {
+		return entity.SortOrder{}, nil
+	}
+	order, ok := entity.ParseSort(raw, allowed)
+	if !ok {
+		return entity.SortOrder{}, invalidField("sort")
+	}
+	return order, nil
+}
+
+func page(limit, offset int32) (entity.Page, error) {
+	if limit < 0 || limit > entity.MaxPageLimit {
+		return entity.Page{}, invalidField("limit")
+	}
+	if offset < 0 {
+		return entity.Page{}, invalidField("offset")
+	}
+	return entity.Page{Limit: int(limit), Offset: int(offset)}, nil
+}
@@ -0,0 +1,214 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/grpcapi/taxipb"
+	"taxiAPI/internal/service"
+)
+
+type rideServer struct {
+	taxipb.UnimplementedRideServiceServer
+	service *service.RideService
+}
+
+func (s *rideServer) CreateRide(ctx context.Context, req *taxipb.CreateRideRequest) (*taxipb.Ride, error) {
+	passengerID, err := service.PassengerFor(caller(ctx), int(req.GetPassengerId()))
+	if err != nil {
+		return nil, err
+	}
+	ride, err := s.service.CreateRide(ctx, &entity.Ride{
+		PassengerID: passengerID,
+		Origin:      fromLocation(req.GetOrigin()),
+		Destination: fromLocation(req.GetDestination()),
+		CarType:     req.GetCarType(),
+		ScheduledAt: fromOptionalTimestamp(req.GetScheduledAt()),
+	}, int(req.GetQuoteId()))
+	if err != nil {
+		return nil, err
+	}
+	return protoRide(ride), nil
+}
+
+func (s *rideServer) GetRide(ctx context.Context, req *taxipb.GetRideRequest) (*taxipb.Ride, error) {
+	ride, err := s.authorizeRide(ctx, int(req.GetRideId()))
+	if err != nil {
+		return nil, err
+	}
+	return protoRide(ride), nil
+}
+
+func (s *rideServer) ListRides(ctx context.Context, req *taxipb.ListRidesRequest) (*taxipb.ListRidesResponse, error) {
+	query := entity.RideQuery{
+		Status:      entity.Status(req.GetStatus()),
+		PassengerID: int(req.GetPassengerId()),
+		DriverID:    int(req.GetDriverId()),
+		CreatedFrom: fromTimestamp(req.GetCreatedFrom()),
+		CreatedTo:   fromTimestamp(req.GetCreatedTo()),
+	}
+	var err error
+	if query.Sort, err = sortOrder(req.GetSort(), entity.RideSortFields); err != nil {
+		return nil, err
+	}
+	if query.Page, err = page(req.GetLimit(), req.GetOffset()); err != nil {
+		return nil, err
+	}
+	if err := service.RestrictRideQuery(caller(ctx), &query); err != nil {
+		return nil, err
+	}
+	rides, total, err := s.service.ListRides(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	resp := &taxipb.ListRidesResponse{TotalCount: int64(total)}
+	for _, r := range rides {
+		resp.Rides = append(resp.Rides, protoRide(r))
+	}
+	return resp, nil
+}
+
+func (s *rideServer) EditRide(ctx context.Context, req *taxipb.EditRideRequest) (*taxipb.Ride, error) {
+	rideID := int(req.GetRideId())
+	if _, err := s.authorizeRide(ctx, rideID); err != nil {
+		return nil, err
+	}
+	if caller(ctx).Role == auth.RoleDriver {
+		return nil, customErrors.ErrForbidden
+	}
+	ride, err := s.service.EditScheduledRide(ctx, rideID, entity.RideEdit{
+		Origin:      fromOptionalLocation(req.GetOrigin()),
+		Destination: fromOptionalLocation(req.GetDestination()),
+		CarType:     req.CarType,
+		ScheduledAt: fromOptionalTimestamp(req.GetScheduledAt()),
+	}, int(req.GetQuoteId()))
+	if err != nil {
+		return nil, err
+	}
+	return protoRide(ride), nil
+}
+
+func (s *rideServer) UpdateRideStatus(ctx context.Context, req *taxipb.UpdateRideStatusRequest) (*taxipb.Ride, error) {
+	rideID := int(req.GetRideId())
+	status := entity.Status(req.GetStatus())
+	actor := entity.Actor(req.GetActor())
+	if identity := caller(ctx); !identity.IsAdmin() {
+		ride, err := s.authorizeRide(ctx, rideID)
+		if err != nil {
+			return nil, err
+		}
+		if actor, err = service.StatusActor(identity, ride, status, actor); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.service.UpdateRideStatus(ctx, rideID, status, actor, req.GetReason()); err != nil {
+		return nil, err
+	}
+	ride, err := s.service.GetRide(ctx, rideID)
+	if err != nil {
+		return nil, err
+	}
+	return protoRide(ride), nil
+}
+
+func (s *rideServer) CancelRide(ctx context.Context, req *taxipb.CancelRideRequest) (*taxipb.Ride, error) {
+	rideID := int(req.GetRideId())
+	identity := caller(ctx)
+	if !identity.IsAdmin() {
+		if _, err := s.authorizeRide(ctx, rideID); err != nil {
+			return nil, err
+		}
+	}
+	actor := service.CancelActor(identity, entity.Actor(req.GetActor()))
+	ride, err := s.service.CancelRide(ctx, rideID, actor, entity.CancelReason(req.GetReasonCode()), req.GetNote())
+	if err != nil {
+		return nil, err
+	}
+	return protoRide(ride), nil
+}
+
+func (s *rideServer) GetRideHistory(ctx context.Context, req *taxipb.GetRideHistoryRequest) (*taxipb.GetRideHistoryResponse, error) {
+	rideID := int(req.GetRideId())
+	if _, err := s.authorizeRide(ctx, rideID); err != nil {
+		return nil, err
+	}
+	history, err := s.service.GetRideHistory(ctx, rideID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &taxipb.GetRideHistoryResponse{}
+	for i := range history {
+		resp.Changes = append(resp.Changes, protoStatusChange(&history[i]))
+	}
+	return resp, nil
+}
+
+func (s *rideServer) GetRideTransitions(ctx context.Context, req *taxipb.GetRideTransitionsRequest) (*taxipb.GetRideTransitionsResponse, error) {
+	rideID := int(req.GetRideId())
+	if _, err := s.authorizeRide(ctx, rideID); err != nil {
+		return nil, err
+	}
+	ride, transitions, err := s.service.GetAllowedTransitions(ctx, rideID)
+	if err != nil {
+		return nil, err
+	}
+	return &taxipb.GetRideTransitionsResponse{
+		RideId:      int64(rideID),
+		Status:      string(ride.Status),
+		Transitions: protoStatuses(transitions),
+	}, nil
+}
+
+// WatchRide sends the events of a ride until the client goes away. It
+// shares the event bus, and so the resume semantics, of the SSE and
+// WebSocket streams. A driver who loses the ride is cut off with
+// PermissionDenied.
+func (s *rideServer) WatchRide(req *taxipb.WatchRideRequest, stream taxipb.RideService_WatchRideServer) error {
+	ctx := stream.Context()
+	sub, err := s.service.SubscribeRide(ctx, caller(ctx), int(req.GetRideId()), req.GetLastEventId())
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+	for _, event := range sub.Missed {
+		if err := stream.Send(protoRideEvent(event)); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-sub.C:
+			if !ok {
+				switch {
+				case sub.BusClosed():
+					return status.Error(codes.Unavailable, "server shutting down")
+				case sub.Revoked():
+					return customErrors.ErrForbidden
+				}
+				return status.Error(codes.ResourceExhausted, "too slow, resume with last_event_id")
+			}
+			if err := stream.Send(protoRideEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// authorizeRide loads the ride if the caller may see it.
+func (s *rideServer) authorizeRide(ctx context.Context, rideID int) (*entity.Ride, error) {
+	ride, err := s.service.GetRide(ctx, rideID)
+	if err != nil {
+		return nil, err
+	}
+	if !service.CanSeeRide(caller(ctx), ride) {
+		return nil, customErrors.ErrForbidden
+	}
+	return ride, nil
+}
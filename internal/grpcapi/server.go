@@ -0,0 +1,163 @@
+// Package grpcapi serves the Taxi API over gRPC. The RPCs are thin
+// adapters: they translate messages and call the same services and access
+// rules as the REST endpoints.
+package grpcapi
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"taxiAPI/internal/auth"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/grpcapi/taxipb"
+	"taxiAPI/internal/service"
+)
+
+// Services are the services the RPCs call.
+type Services struct {
+	Auth       *service.AuthService
+	Passengers *service.PassengerService
+	Drivers    *service.DriverService
+	Rides      *service.RideService
+}
+
+// NewServer returns a gRPC server with every service of taxi.proto
+// registered. Calls are authenticated from their "authorization" metadata
+// and checked against methodRoles before they reach the services.
+func NewServer(services Services, opts ...grpc.ServerOption) *grpc.Server {
+	g := &gate{auth: services.Auth}
+	opts = append(opts, grpc.ChainUnaryInterceptor(g.unary), grpc.ChainStreamInterceptor(g.stream))
+	server := grpc.NewServer(opts...)
+	taxipb.RegisterAuthServiceServer(server, &authServer{auth: services.Auth})
+	taxipb.RegisterPassengerServiceServer(server, &passengerServer{service: services.Passengers, auth: services.Auth})
+	taxipb.RegisterDriverServiceServer(server, &driverServer{service: services.Drivers, auth: services.Auth})
+	taxipb.RegisterRideServiceServer(server, &rideServer{service: services.Rides})
+	return server
+}
+
+var (
+	public         []auth.Role
+	anyCaller      = []auth.Role{}
+	adminOnly      = []auth.Role{auth.RoleAdmin}
+	driversOnly    = []auth.Role{auth.RoleDriver, auth.RoleAdmin}
+	passengersOnly = []auth.Role{auth.RolePassenger, auth.RoleAdmin}
+)
+
+// methodRoles lists the roles that may call each RPC: nil for public RPCs
+// and empty for any signed-in caller. They match the REST routes; RPCs
+// missing from the table are refused.
+var methodRoles = map[string][]auth.Role{
+	taxipb.AuthService_Login_FullMethodName: public,
+
+	taxipb.PassengerService_RegisterPassenger_FullMethodName: public,
+	taxipb.PassengerService_GetPassenger_FullMethodName:      passengersOnly,
+	taxipb.PassengerService_ListPassengers_FullMethodName:    adminOnly,
+	taxipb.PassengerService_DeletePassenger_FullMethodName:   adminOnly,
+
+	taxipb.DriverService_RegisterDriver_FullMethodName:    public,
+	taxipb.DriverService_GetDriver_FullMethodName:         driversOnly,
+	taxipb.DriverService_ListDrivers_FullMethodName:       adminOnly,
+	taxipb.DriverService_DeleteDriver_FullMethodName:      adminOnly,
+	taxipb.DriverService_SetAvailability_FullMethodName:   driversOnly,
+	taxipb.DriverService_UpdateLocation_FullMethodName:    driversOnly,
+	taxipb.DriverService_FindNearbyDrivers_FullMethodName: passengersOnly,
+
+	taxipb.RideService_CreateRide_FullMethodName:         passengersOnly,
+	taxipb.RideService_GetRide_FullMethodName:            anyCaller,
+	taxipb.RideService_ListRides_FullMethodName:          anyCaller,
+	taxipb.RideService_EditRide_FullMethodName:           passengersOnly,
+	taxipb.RideService_UpdateRideStatus_FullMethodName:   driversOnly,
+	taxipb.RideService_CancelRide_FullMethodName:         anyCaller,
+	taxipb.RideService_GetRideHistory_FullMethodName:     anyCaller,
+	taxipb.RideService_GetRideTransitions_FullMethodName: anyCaller,
+	taxipb.RideService_WatchRide_FullMethodName:          anyCaller,
+}
+
+// gate authenticates calls and enforces methodRoles.
+type gate struct {
+	auth *service.AuthService
+}
+
+func (g *gate) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := g.admit(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+func (g *gate) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := g.admit(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	if err := handler(srv, &identityStream{ServerStream: ss, ctx: ctx}); err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
+
+// admit puts the caller's identity into ctx when a token was sent and
+// checks that the caller may use the method. As with the REST API, an
+// invalid token is rejected even on public methods.
+func (g *gate) admit(ctx context.Context, method string) (context.Context, error) {
+	roles, ok := methodRoles[method]
+	if !ok {
+		return nil, toStatus(customErrors.ErrForbidden)
+	}
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return nil, toStatus(customErrors.ErrInvalidToken)
+	}
+	if token == "" {
+		if roles != nil {
+			return nil, toStatus(customErrors.ErrAuthenticationRequired)
+		}
+		return ctx, nil
+	}
+	identity, err := g.auth.Authenticate(ctx, token)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if len(roles) > 0 && !slices.Contains(roles, identity.Role) {
+		return nil, toStatus(customErrors.ErrForbidden)
+	}
+	return auth.NewContext(ctx, identity), nil
+}
+
+// bearerToken returns the token in the call's authorization metadata, or ""
+// when there is none. It reports false for a malformed value.
+func bearerToken(ctx context.Context) (string, bool) {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 {
+		return "", true
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	token = strings.TrimSpace(token)
+	return token, ok && token != ""
+}
+
+// identityStream hands the authenticated context to streaming RPCs.
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+// caller returns the identity admit let through; it is the zero identity on
+// public methods called without a token.
+func caller(ctx context.Context) auth.Identity {
+	identity, _ := auth.FromContext(ctx)
+	return identity
+}
@@ -0,0 +1,306 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/grpcapi/taxipb"
+	"taxiAPI/internal/pricing"
+	"taxiAPI/internal/service"
+	"taxiAPI/internal/storage"
+)
+
+type testAPI struct {
+	rides      *service.RideService
+	events     *service.EventBus
+	auth       taxipb.AuthServiceClient
+	passengers taxipb.PassengerServiceClient
+	drivers    taxipb.DriverServiceClient
+	ridesRPC   taxipb.RideServiceClient
+}
+
+// newTestAPI serves the API over an in-memory connection, backed by the
+// memory stores.
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+	rideStore, passengerStore, driverStore, locationStore := storage.NewRide(), storage.NewPassenger(), storage.NewDriver(), storage.NewLocation()
+	transactor := storage.NewTransactor()
+	engine := pricing.NewEngine(pricing.DefaultConfig())
+	surge := service.NewSurgeService(engine, rideStore, driverStore, locationStore)
+	prices := service.NewPricingService(engine, storage.NewQuote(), surge, service.DefaultQuoteTTL)
+	rides := service.NewRideService(rideStore, passengerStore, driverStore, transactor, prices)
+	drivers := service.NewDriverService(driverStore, rideStore, locationStore, transactor, "US")
+	events := service.NewEventBus(service.DefaultEventBacklog)
+	rides.SetEventBus(events)
+	drivers.SetEventBus(events)
+	authService := service.NewAuthService(passengerStore, driverStore, auth.NewTokens([]byte("test secret"), auth.DefaultTokenTTL), "", "US")
+
+	server := NewServer(Services{
+		Auth:       authService,
+		Passengers: service.NewPassengerService(passengerStore, "US"),
+		Drivers:    drivers,
+		Rides:      rides,
+	})
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &testAPI{
+		rides:      rides,
+		events:     events,
+		auth:       taxipb.NewAuthServiceClient(conn),
+		passengers: taxipb.NewPassengerServiceClient(conn),
+		drivers:    taxipb.NewDriverServiceClient(conn),
+		ridesRPC:   taxipb.NewRideServiceClient(conn),
+	}
+}
+
+func withToken(session *taxipb.Session) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+session.GetToken())
+}
+
+// wantError checks the code of a failed call and the REST error code in its
+// ErrorInfo detail.
+func wantError(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	st, _ := status.FromError(err)
+	if st.Code() != code {
+		t.Fatalf("error = %v, want code %s", err, code)
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			if info.GetReason() != reason {
+				t.Fatalf("reason = %q, want %q", info.GetReason(), reason)
+			}
+			return
+		}
+	}
+	t.Fatalf("error %v has no ErrorInfo detail", err)
+}
+
+// wantViolations checks the fields listed in the BadRequest detail of a
+// failed call.
+func wantViolations(t *testing.T, err error, fields ...string) {
+	t.Helper()
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			var got []string
+			for _, v := range badRequest.GetFieldViolations() {
+				got = append(got, v.GetField())
+			}
+			if strings.Join(got, ",") != strings.Join(fields, ",") {
+				t.Fatalf("violations = %v, want %v", got, fields)
+			}
+			return
+		}
+	}
+	t.Fatalf("error %v has no BadRequest detail", err)
+}
+
+// TestMethodRolesCoverEveryRPC fails when an RPC is added to taxi.proto
+// without deciding who may call it, or methodRoles lists one that does not
+// exist.
+func TestMethodRolesCoverEveryRPC(t *testing.T) {
+	server := NewServer(Services{})
+	served := make(map[string]bool)
+	for name, info := range server.GetServiceInfo() {
+		for _, method := range info.Methods {
+			fullName := "/" + name + "/" + method.Name
+			served[fullName] = true
+			if _, ok := methodRoles[fullName]; !ok {
+				t.Errorf("%s has no entry in methodRoles", fullName)
+			}
+		}
+	}
+	for method := range methodRoles {
+		if !served[method] {
+			t.Errorf("methodRoles lists %s, which is not served", method)
+		}
+	}
+}
+
+func TestRideOverGRPC(t *testing.T) {
+	api := newTestAPI(t)
+
+	passenger, err := api.passengers.RegisterPassenger(context.Background(), &taxipb.RegisterPassengerRequest{
+		FirstName: "John", LastName: "Doe", PhoneNumber: "+1 415 555 0100", Password: "secret123",
+	})
+	if err != nil {
+		t.Fatalf("RegisterPassenger: %v", err)
+	}
+	driver, err := api.drivers.RegisterDriver(context.Background(), &taxipb.RegisterDriverRequest{
+		FirstName: "Alex", LastName: "Smith", PhoneNumber: "+14155550200", CarType: "Prius", LicensePlate: 1234, Password: "secret123",
+	})
+	if err != nil {
+		t.Fatalf("RegisterDriver: %v", err)
+	}
+	_, err = api.passengers.RegisterPassenger(context.Background(), &taxipb.RegisterPassengerRequest{
+		LastName: "D0e", PhoneNumber: "555", Password: "secret123",
+	})
+	wantError(t, err, codes.InvalidArgument, "validation_failed")
+	wantViolations(t, err, "first_name", "last_name", "phone_number")
+	if got := passenger.GetPassenger().GetPhoneNumber(); got != "+14155550100" {
+		t.Fatalf("phone number = %q, want it in E.164 form", got)
+	}
+	_, err = api.auth.Login(context.Background(), &taxipb.LoginRequest{Role: "passenger", PhoneNumber: "(415) 555-0100", Password: "wrong"})
+	wantError(t, err, codes.Unauthenticated, "invalid_credentials")
+	if _, err := api.auth.Login(context.Background(), &taxipb.LoginRequest{Role: "passenger", PhoneNumber: "(415) 555-0100", Password: "secret123"}); err != nil {
+		t.Fatalf("Login with a local number: %v", err)
+	}
+
+	asPassenger := withToken(passenger.GetSession())
+	asDriver := withToken(driver.GetSession())
+	ride, err := api.ridesRPC.CreateRide(asPassenger, &taxipb.CreateRideRequest{
+		Origin:      &taxipb.Location{Lat: 52.52, Lng: 13.405},
+		Destination: &taxipb.Location{Lat: 52.50, Lng: 13.45},
+	})
+	if err != nil {
+		t.Fatalf("CreateRide: %v", err)
+	}
+	if ride.GetStatus() != "pending" || ride.GetPassenger().GetPassengerId() != passenger.GetPassenger().GetPassengerId() {
+		t.Fatalf("CreateRide = %v, want a pending ride of the caller", ride)
+	}
+
+	_, err = api.ridesRPC.GetRide(context.Background(), &taxipb.GetRideRequest{RideId: ride.GetRideId()})
+	wantError(t, err, codes.Unauthenticated, "authentication_required")
+	_, err = api.ridesRPC.GetRide(asDriver, &taxipb.GetRideRequest{RideId: ride.GetRideId()})
+	wantError(t, err, codes.PermissionDenied, "forbidden")
+	_, err = api.ridesRPC.GetRide(asPassenger, &taxipb.GetRideRequest{RideId: ride.GetRideId() + 100})
+	wantError(t, err, codes.NotFound, "ride_not_found")
+	_, err = api.passengers.ListPassengers(asPassenger, &taxipb.ListPassengersRequest{})
+	wantError(t, err, codes.PermissionDenied, "forbidden")
+	_, err = api.drivers.GetDriver(asPassenger, &taxipb.GetDriverRequest{DriverId: driver.GetDriver().GetDriverId()})
+	wantError(t, err, codes.PermissionDenied, "forbidden")
+	if _, err := api.drivers.GetDriver(asDriver, &taxipb.GetDriverRequest{DriverId: driver.GetDriver().GetDriverId()}); err != nil {
+		t.Fatalf("GetDriver of the caller: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(asPassenger, 10*time.Second)
+	defer cancel()
+	// Resuming from the first possible ID replays the backlog, so no event
+	// is lost however late the server handles the call.
+	stream, err := api.ridesRPC.WatchRide(ctx, &taxipb.WatchRideRequest{RideId: ride.GetRideId(), LastEventId: 1})
+	if err != nil {
+		t.Fatalf("WatchRide: %v", err)
+	}
+
+	if err := api.rides.AssignDriverToRide(context.Background(), int(ride.GetRideId()), int(driver.GetDriver().GetDriverId())); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+	updated, err := api.ridesRPC.UpdateRideStatus(asDriver, &taxipb.UpdateRideStatusRequest{RideId: ride.GetRideId(), Status: "driver_arriving"})
+	if err != nil {
+		t.Fatalf("UpdateRideStatus: %v", err)
+	}
+	if updated.GetStatus() != "driver_arriving" || updated.GetDriver().GetDriverId() != driver.GetDriver().GetDriverId() {
+		t.Fatalf("UpdateRideStatus = %v", updated)
+	}
+
+	for _, wantType := range []string{"ride.created", "ride.assigned", "ride.status_changed"} {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if event.GetType() != wantType || event.GetRideId() != ride.GetRideId() {
+			t.Fatalf("event = %v, want %s", event, wantType)
+		}
+	}
+
+	api.events.Close()
+	_, err = stream.Recv()
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("Recv after the bus closed: %v, want Unavailable", err)
+	}
+}
+
+func TestWatchRideOverGRPC(t *testing.T) {
+	api := newTestAPI(t)
+	var sessions []*taxipb.Session
+	for _, phone := range []string{"+14155550100", "+14155550101"} {
+		passenger, err := api.passengers.RegisterPassenger(context.Background(), &taxipb.RegisterPassengerRequest{
+			FirstName: "John", LastName: "Doe", PhoneNumber: phone, Password: "secret123",
+		})
+		if err != nil {
+			t.Fatalf("RegisterPassenger: %v", err)
+		}
+		sessions = append(sessions, passenger.GetSession())
+	}
+	driver, err := api.drivers.RegisterDriver(context.Background(), &taxipb.RegisterDriverRequest{
+		FirstName: "Alex", LastName: "Smith", PhoneNumber: "+14155550200", CarType: "Prius", LicensePlate: 1234, Password: "secret123",
+	})
+	if err != nil {
+		t.Fatalf("RegisterDriver: %v", err)
+	}
+	asPassenger, asOther, asDriver := withToken(sessions[0]), withToken(sessions[1]), withToken(driver.GetSession())
+	ride, err := api.ridesRPC.CreateRide(asPassenger, &taxipb.CreateRideRequest{
+		Origin:      &taxipb.Location{Lat: 52.52, Lng: 13.405},
+		Destination: &taxipb.Location{Lat: 52.50, Lng: 13.45},
+	})
+	if err != nil {
+		t.Fatalf("CreateRide: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// watch opens a stream from lastEventID and reads the wanted event types.
+	watch := func(caller context.Context, lastEventID uint64, wantTypes ...string) (taxipb.RideService_WatchRideClient, []*taxipb.RideEvent) {
+		t.Helper()
+		md, _ := metadata.FromOutgoingContext(caller)
+		stream, err := api.ridesRPC.WatchRide(metadata.NewOutgoingContext(ctx, md), &taxipb.WatchRideRequest{RideId: ride.GetRideId(), LastEventId: lastEventID})
+		if err != nil {
+			t.Fatalf("WatchRide: %v", err)
+		}
+		var events []*taxipb.RideEvent
+		for _, wantType := range wantTypes {
+			event, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("Recv: %v", err)
+			}
+			if event.GetType() != wantType {
+				t.Fatalf("event = %v, want %s", event, wantType)
+			}
+			events = append(events, event)
+		}
+		return stream, events
+	}
+
+	other, _ := watch(asOther, 0)
+	_, err = other.Recv()
+	wantError(t, err, codes.PermissionDenied, "forbidden")
+
+	_, created := watch(asPassenger, 1, "ride.created")
+	if err := api.rides.AssignDriverToRide(context.Background(), int(ride.GetRideId()), int(driver.GetDriver().GetDriverId())); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+	if _, err := api.ridesRPC.UpdateRideStatus(asDriver, &taxipb.UpdateRideStatusRequest{RideId: ride.GetRideId(), Status: "driver_arriving"}); err != nil {
+		t.Fatalf("UpdateRideStatus: %v", err)
+	}
+	// Resuming after the first event replays only what came after it.
+	watch(asPassenger, created[0].GetId(), "ride.assigned", "ride.status_changed")
+
+	// A driver who gives the ride up may no longer watch it.
+	driverStream, _ := watch(asDriver, 1, "ride.created", "ride.assigned", "ride.status_changed")
+	if _, err := api.ridesRPC.CancelRide(asDriver, &taxipb.CancelRideRequest{RideId: ride.GetRideId(), ReasonCode: "vehicle_problem"}); err != nil {
+		t.Fatalf("CancelRide: %v", err)
+	}
+	_, err = driverStream.Recv()
+	wantError(t, err, codes.PermissionDenied, "forbidden")
+}
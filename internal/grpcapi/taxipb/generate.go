@@ -0,0 +1,5 @@
+// Package taxipb holds the protobuf messages and gRPC service stubs
+// generated from taxi.proto.
+package taxipb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative taxi.proto
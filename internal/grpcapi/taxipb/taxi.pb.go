@@ -0,0 +1,3015 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: taxi.proto
+
+package taxipb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LoginRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "passenger", "driver" or "admin".
+	Role string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	// Not needed for admins. International, or local to the server's default
+	// region.
+	PhoneNumber   string `protobuf:"bytes,2,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Password      string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_taxi_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LoginRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Id            int64                  `protobuf:"varint,4,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_taxi_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Session) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Session) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Session) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng           float64                `protobuf:"fixed64,2,opt,name=lng,proto3" json:"lng,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_taxi_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Location) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Location) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+func (x *Location) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type Passenger struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PassengerId int64                  `protobuf:"varint,1,opt,name=passenger_id,json=passengerId,proto3" json:"passenger_id,omitempty"`
+	FirstName   string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName    string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// E.164, e.g. "+14155550100".
+	PhoneNumber   string  `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Rating        float64 `protobuf:"fixed64,5,opt,name=rating,proto3" json:"rating,omitempty"`
+	RatingCount   int64   `protobuf:"varint,6,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Passenger) Reset() {
+	*x = Passenger{}
+	mi := &file_taxi_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Passenger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passenger) ProtoMessage() {}
+
+func (x *Passenger) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passenger.ProtoReflect.Descriptor instead.
+func (*Passenger) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Passenger) GetPassengerId() int64 {
+	if x != nil {
+		return x.PassengerId
+	}
+	return 0
+}
+
+func (x *Passenger) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Passenger) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Passenger) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *Passenger) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Passenger) GetRatingCount() int64 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+type RegisterPassengerRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	FirstName string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string                 `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// International, or local to the server's default region.
+	PhoneNumber   string `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Password      string `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPassengerRequest) Reset() {
+	*x = RegisterPassengerRequest{}
+	mi := &file_taxi_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPassengerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPassengerRequest) ProtoMessage() {}
+
+func (x *RegisterPassengerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPassengerRequest.ProtoReflect.Descriptor instead.
+func (*RegisterPassengerRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RegisterPassengerRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *RegisterPassengerRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *RegisterPassengerRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *RegisterPassengerRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type PassengerRegistration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passenger     *Passenger             `protobuf:"bytes,1,opt,name=passenger,proto3" json:"passenger,omitempty"`
+	Session       *Session               `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PassengerRegistration) Reset() {
+	*x = PassengerRegistration{}
+	mi := &file_taxi_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PassengerRegistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PassengerRegistration) ProtoMessage() {}
+
+func (x *PassengerRegistration) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PassengerRegistration.ProtoReflect.Descriptor instead.
+func (*PassengerRegistration) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PassengerRegistration) GetPassenger() *Passenger {
+	if x != nil {
+		return x.Passenger
+	}
+	return nil
+}
+
+func (x *PassengerRegistration) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type GetPassengerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PassengerId   int64                  `protobuf:"varint,1,opt,name=passenger_id,json=passengerId,proto3" json:"passenger_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPassengerRequest) Reset() {
+	*x = GetPassengerRequest{}
+	mi := &file_taxi_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPassengerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPassengerRequest) ProtoMessage() {}
+
+func (x *GetPassengerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPassengerRequest.ProtoReflect.Descriptor instead.
+func (*GetPassengerRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPassengerRequest) GetPassengerId() int64 {
+	if x != nil {
+		return x.PassengerId
+	}
+	return 0
+}
+
+type ListPassengersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "passenger_id" or "last_name", prefixed with "-" for descending order.
+	Sort          string `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	Limit         int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPassengersRequest) Reset() {
+	*x = ListPassengersRequest{}
+	mi := &file_taxi_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPassengersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPassengersRequest) ProtoMessage() {}
+
+func (x *ListPassengersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPassengersRequest.ProtoReflect.Descriptor instead.
+func (*ListPassengersRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListPassengersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListPassengersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPassengersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListPassengersResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Passengers []*Passenger           `protobuf:"bytes,1,rep,name=passengers,proto3" json:"passengers,omitempty"`
+	// Number of matching passengers on all pages.
+	TotalCount    int64 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPassengersResponse) Reset() {
+	*x = ListPassengersResponse{}
+	mi := &file_taxi_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPassengersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPassengersResponse) ProtoMessage() {}
+
+func (x *ListPassengersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPassengersResponse.ProtoReflect.Descriptor instead.
+func (*ListPassengersResponse) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListPassengersResponse) GetPassengers() []*Passenger {
+	if x != nil {
+		return x.Passengers
+	}
+	return nil
+}
+
+func (x *ListPassengersResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type DeletePassengerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PassengerId   int64                  `protobuf:"varint,1,opt,name=passenger_id,json=passengerId,proto3" json:"passenger_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePassengerRequest) Reset() {
+	*x = DeletePassengerRequest{}
+	mi := &file_taxi_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePassengerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePassengerRequest) ProtoMessage() {}
+
+func (x *DeletePassengerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePassengerRequest.ProtoReflect.Descriptor instead.
+func (*DeletePassengerRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeletePassengerRequest) GetPassengerId() int64 {
+	if x != nil {
+		return x.PassengerId
+	}
+	return 0
+}
+
+type Driver struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	DriverId  int64                  `protobuf:"varint,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	FirstName string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// E.164, e.g. "+14155550100".
+	PhoneNumber   string  `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	IsOnline      bool    `protobuf:"varint,5,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
+	IsAvailable   bool    `protobuf:"varint,6,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`
+	CarType       string  `protobuf:"bytes,7,opt,name=car_type,json=carType,proto3" json:"car_type,omitempty"`
+	LicensePlate  int64   `protobuf:"varint,8,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
+	Rating        float64 `protobuf:"fixed64,9,opt,name=rating,proto3" json:"rating,omitempty"`
+	RatingCount   int64   `protobuf:"varint,10,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Driver) Reset() {
+	*x = Driver{}
+	mi := &file_taxi_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Driver) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Driver) ProtoMessage() {}
+
+func (x *Driver) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Driver.ProtoReflect.Descriptor instead.
+func (*Driver) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Driver) GetDriverId() int64 {
+	if x != nil {
+		return x.DriverId
+	}
+	return 0
+}
+
+func (x *Driver) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Driver) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Driver) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *Driver) GetIsOnline() bool {
+	if x != nil {
+		return x.IsOnline
+	}
+	return false
+}
+
+func (x *Driver) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+func (x *Driver) GetCarType() string {
+	if x != nil {
+		return x.CarType
+	}
+	return ""
+}
+
+func (x *Driver) GetLicensePlate() int64 {
+	if x != nil {
+		return x.LicensePlate
+	}
+	return 0
+}
+
+func (x *Driver) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Driver) GetRatingCount() int64 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+type RegisterDriverRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	FirstName string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string                 `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// International, or local to the server's default region.
+	PhoneNumber   string `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	CarType       string `protobuf:"bytes,4,opt,name=car_type,json=carType,proto3" json:"car_type,omitempty"`
+	LicensePlate  int64  `protobuf:"varint,5,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
+	Password      string `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterDriverRequest) Reset() {
+	*x = RegisterDriverRequest{}
+	mi := &file_taxi_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterDriverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterDriverRequest) ProtoMessage() {}
+
+func (x *RegisterDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterDriverRequest.ProtoReflect.Descriptor instead.
+func (*RegisterDriverRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RegisterDriverRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *RegisterDriverRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *RegisterDriverRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *RegisterDriverRequest) GetCarType() string {
+	if x != nil {
+		return x.CarType
+	}
+	return ""
+}
+
+func (x *RegisterDriverRequest) GetLicensePlate() int64 {
+	if x != nil {
+		return x.LicensePlate
+	}
+	return 0
+}
+
+func (x *RegisterDriverRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type DriverRegistration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	Session       *Session               `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverRegistration) Reset() {
+	*x = DriverRegistration{}
+	mi := &file_taxi_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverRegistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverRegistration) ProtoMessage() {}
+
+func (x *DriverRegistration) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverRegistration.ProtoReflect.Descriptor instead.
+func (*DriverRegistration) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DriverRegistration) GetDriver() *Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+func (x *DriverRegistration) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type GetDriverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      int64                  `protobuf:"varint,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverRequest) Reset() {
+	*x = GetDriverRequest{}
+	mi := &file_taxi_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverRequest) ProtoMessage() {}
+
+func (x *GetDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetDriverRequest) GetDriverId() int64 {
+	if x != nil {
+		return x.DriverId
+	}
+	return 0
+}
+
+type ListDriversRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	IsAvailable *bool                  `protobuf:"varint,1,opt,name=is_available,json=isAvailable,proto3,oneof" json:"is_available,omitempty"`
+	CarType     string                 `protobuf:"bytes,2,opt,name=car_type,json=carType,proto3" json:"car_type,omitempty"`
+	// "driver_id" or "last_name", prefixed with "-" for descending order.
+	Sort          string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDriversRequest) Reset() {
+	*x = ListDriversRequest{}
+	mi := &file_taxi_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDriversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDriversRequest) ProtoMessage() {}
+
+func (x *ListDriversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDriversRequest.ProtoReflect.Descriptor instead.
+func (*ListDriversRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListDriversRequest) GetIsAvailable() bool {
+	if x != nil && x.IsAvailable != nil {
+		return *x.IsAvailable
+	}
+	return false
+}
+
+func (x *ListDriversRequest) GetCarType() string {
+	if x != nil {
+		return x.CarType
+	}
+	return ""
+}
+
+func (x *ListDriversRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListDriversRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDriversRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListDriversResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Drivers []*Driver              `protobuf:"bytes,1,rep,name=drivers,proto3" json:"drivers,omitempty"`
+	// Number of matching drivers on all pages.
+	TotalCount    int64 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDriversResponse) Reset() {
+	*x = ListDriversResponse{}
+	mi := &file_taxi_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDriversResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDriversResponse) ProtoMessage() {}
+
+func (x *ListDriversResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDriversResponse.ProtoReflect.Descriptor instead.
+func (*ListDriversResponse) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListDriversResponse) GetDrivers() []*Driver {
+	if x != nil {
+		return x.Drivers
+	}
+	return nil
+}
+
+func (x *ListDriversResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type DeleteDriverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      int64                  `protobuf:"varint,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDriverRequest) Reset() {
+	*x = DeleteDriverRequest{}
+	mi := &file_taxi_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDriverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDriverRequest) ProtoMessage() {}
+
+func (x *DeleteDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDriverRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDriverRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteDriverRequest) GetDriverId() int64 {
+	if x != nil {
+		return x.DriverId
+	}
+	return 0
+}
+
+type SetAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      int64                  `protobuf:"varint,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	IsOnline      bool                   `protobuf:"varint,2,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAvailabilityRequest) Reset() {
+	*x = SetAvailabilityRequest{}
+	mi := &file_taxi_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAvailabilityRequest) ProtoMessage() {}
+
+func (x *SetAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*SetAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetAvailabilityRequest) GetDriverId() int64 {
+	if x != nil {
+		return x.DriverId
+	}
+	return 0
+}
+
+func (x *SetAvailabilityRequest) GetIsOnline() bool {
+	if x != nil {
+		return x.IsOnline
+	}
+	return false
+}
+
+type DriverLocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      int64                  `protobuf:"varint,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Lat           float64                `protobuf:"fixed64,2,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng           float64                `protobuf:"fixed64,3,opt,name=lng,proto3" json:"lng,omitempty"`
+	Heading       float64                `protobuf:"fixed64,4,opt,name=heading,proto3" json:"heading,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverLocation) Reset() {
+	*x = DriverLocation{}
+	mi := &file_taxi_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverLocation) ProtoMessage() {}
+
+func (x *DriverLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverLocation.ProtoReflect.Descriptor instead.
+func (*DriverLocation) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DriverLocation) GetDriverId() int64 {
+	if x != nil {
+		return x.DriverId
+	}
+	return 0
+}
+
+func (x *DriverLocation) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *DriverLocation) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+func (x *DriverLocation) GetHeading() float64 {
+	if x != nil {
+		return x.Heading
+	}
+	return 0
+}
+
+func (x *DriverLocation) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type UpdateLocationRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId int64                  `protobuf:"varint,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Lat      float64                `protobuf:"fixed64,2,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng      float64                `protobuf:"fixed64,3,opt,name=lng,proto3" json:"lng,omitempty"`
+	Heading  float64                `protobuf:"fixed64,4,opt,name=heading,proto3" json:"heading,omitempty"`
+	// Defaults to the time the report is received.
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLocationRequest) Reset() {
+	*x = UpdateLocationRequest{}
+	mi := &file_taxi_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLocationRequest) ProtoMessage() {}
+
+func (x *UpdateLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLocationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLocationRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateLocationRequest) GetDriverId() int64 {
+	if x != nil {
+		return x.DriverId
+	}
+	return 0
+}
+
+func (x *UpdateLocationRequest) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *UpdateLocationRequest) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+func (x *UpdateLocationRequest) GetHeading() float64 {
+	if x != nil {
+		return x.Heading
+	}
+	return 0
+}
+
+func (x *UpdateLocationRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type FindNearbyDriversRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Lat   float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng   float64                `protobuf:"fixed64,2,opt,name=lng,proto3" json:"lng,omitempty"`
+	// Search radius in km, default 5, at most 50.
+	RadiusKm      float64 `protobuf:"fixed64,3,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindNearbyDriversRequest) Reset() {
+	*x = FindNearbyDriversRequest{}
+	mi := &file_taxi_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindNearbyDriversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindNearbyDriversRequest) ProtoMessage() {}
+
+func (x *FindNearbyDriversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindNearbyDriversRequest.ProtoReflect.Descriptor instead.
+func (*FindNearbyDriversRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FindNearbyDriversRequest) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *FindNearbyDriversRequest) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+func (x *FindNearbyDriversRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+type NearbyDriver struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	Location      *DriverLocation        `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	DistanceKm    float64                `protobuf:"fixed64,3,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyDriver) Reset() {
+	*x = NearbyDriver{}
+	mi := &file_taxi_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyDriver) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyDriver) ProtoMessage() {}
+
+func (x *NearbyDriver) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyDriver.ProtoReflect.Descriptor instead.
+func (*NearbyDriver) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *NearbyDriver) GetDriver() *Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+func (x *NearbyDriver) GetLocation() *DriverLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *NearbyDriver) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+type FindNearbyDriversResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drivers       []*NearbyDriver        `protobuf:"bytes,1,rep,name=drivers,proto3" json:"drivers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindNearbyDriversResponse) Reset() {
+	*x = FindNearbyDriversResponse{}
+	mi := &file_taxi_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindNearbyDriversResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindNearbyDriversResponse) ProtoMessage() {}
+
+func (x *FindNearbyDriversResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindNearbyDriversResponse.ProtoReflect.Descriptor instead.
+func (*FindNearbyDriversResponse) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *FindNearbyDriversResponse) GetDrivers() []*NearbyDriver {
+	if x != nil {
+		return x.Drivers
+	}
+	return nil
+}
+
+type Ride struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RideId      int64                  `protobuf:"varint,1,opt,name=ride_id,json=rideId,proto3" json:"ride_id,omitempty"`
+	Passenger   *Passenger             `protobuf:"bytes,2,opt,name=passenger,proto3" json:"passenger,omitempty"`
+	Driver      *Driver                `protobuf:"bytes,3,opt,name=driver,proto3" json:"driver,omitempty"`
+	Origin      *Location              `protobuf:"bytes,4,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination *Location              `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	DistanceKm  float64                `protobuf:"fixed64,6,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	CarType     string                 `protobuf:"bytes,7,opt,name=car_type,json=carType,proto3" json:"car_type,omitempty"`
+	Status      string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	ScheduledAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	// Fares are in minor units of currency.
+	Currency        string                 `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	QuotedFare      int64                  `protobuf:"varint,11,opt,name=quoted_fare,json=quotedFare,proto3" json:"quoted_fare,omitempty"`
+	SurgeMultiplier float64                `protobuf:"fixed64,12,opt,name=surge_multiplier,json=surgeMultiplier,proto3" json:"surge_multiplier,omitempty"`
+	FinalFare       int64                  `protobuf:"varint,13,opt,name=final_fare,json=finalFare,proto3" json:"final_fare,omitempty"`
+	CancellationFee int64                  `protobuf:"varint,14,opt,name=cancellation_fee,json=cancellationFee,proto3" json:"cancellation_fee,omitempty"`
+	CancelledBy     string                 `protobuf:"bytes,15,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`
+	CancelReason    string                 `protobuf:"bytes,16,opt,name=cancel_reason,json=cancelReason,proto3" json:"cancel_reason,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AcceptedAt      *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=accepted_at,json=acceptedAt,proto3" json:"accepted_at,omitempty"`
+	StartedAt       *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt     *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	CancelledAt     *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Ride) Reset() {
+	*x = Ride{}
+	mi := &file_taxi_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ride) ProtoMessage() {}
+
+func (x *Ride) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ride.ProtoReflect.Descriptor instead.
+func (*Ride) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Ride) GetRideId() int64 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+func (x *Ride) GetPassenger() *Passenger {
+	if x != nil {
+		return x.Passenger
+	}
+	return nil
+}
+
+func (x *Ride) GetDriver() *Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+func (x *Ride) GetOrigin() *Location {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *Ride) GetDestination() *Location {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *Ride) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *Ride) GetCarType() string {
+	if x != nil {
+		return x.CarType
+	}
+	return ""
+}
+
+func (x *Ride) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Ride) GetScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return nil
+}
+
+func (x *Ride) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Ride) GetQuotedFare() int64 {
+	if x != nil {
+		return x.QuotedFare
+	}
+	return 0
+}
+
+func (x *Ride) GetSurgeMultiplier() float64 {
+	if x != nil {
+		return x.SurgeMultiplier
+	}
+	return 0
+}
+
+func (x *Ride) GetFinalFare() int64 {
+	if x != nil {
+		return x.FinalFare
+	}
+	return 0
+}
+
+func (x *Ride) GetCancellationFee() int64 {
+	if x != nil {
+		return x.CancellationFee
+	}
+	return 0
+}
+
+func (x *Ride) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+func (x *Ride) GetCancelReason() string {
+	if x != nil {
+		return x.CancelReason
+	}
+	return ""
+}
+
+func (x *Ride) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Ride) GetAcceptedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return nil
+}
+
+func (x *Ride) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Ride) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Ride) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+type CreateRideRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only admins name the passenger; passengers book for themselves.
+	PassengerId int64     `protobuf:"varint,1,opt,name=passenger_id,json=passengerId,proto3" json:"passenger_id,omitempty"`
+	Origin      *Location `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination *Location `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	CarType     string    `protobuf:"bytes,4,opt,name=car_type,json=carType,proto3" json:"car_type,omitempty"`
+	// Books the ride at the fare of this quote.
+	QuoteId int64 `protobuf:"varint,5,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	// Requested pickup time of a ride booked in advance.
+	ScheduledAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRideRequest) Reset() {
+	*x = CreateRideRequest{}
+	mi := &file_taxi_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRideRequest) ProtoMessage() {}
+
+func (x *CreateRideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRideRequest.ProtoReflect.Descriptor instead.
+func (*CreateRideRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateRideRequest) GetPassengerId() int64 {
+	if x != nil {
+		return x.PassengerId
+	}
+	return 0
+}
+
+func (x *CreateRideRequest) GetOrigin() *Location {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *CreateRideRequest) GetDestination() *Location {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *CreateRideRequest) GetCarType() string {
+	if x != nil {
+		return x.CarType
+	}
+	return ""
+}
+
+func (x *CreateRideRequest) GetQuoteId() int64 {
+	if x != nil {
+		return x.QuoteId
+	}
+	return 0
+}
+
+func (x *CreateRideRequest) GetScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return nil
+}
+
+type GetRideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RideId        int64                  `protobuf:"varint,1,opt,name=ride_id,json=rideId,proto3" json:"ride_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRideRequest) Reset() {
+	*x = GetRideRequest{}
+	mi := &file_taxi_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRideRequest) ProtoMessage() {}
+
+func (x *GetRideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRideRequest.ProtoReflect.Descriptor instead.
+func (*GetRideRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetRideRequest) GetRideId() int64 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+type ListRidesRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Status      string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	PassengerId int64                  `protobuf:"varint,2,opt,name=passenger_id,json=passengerId,proto3" json:"passenger_id,omitempty"`
+	DriverId    int64                  `protobuf:"varint,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// "ride_id" or "created_at", prefixed with "-" for descending order.
+	Sort          string `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	Limit         int32  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32  `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRidesRequest) Reset() {
+	*x = ListRidesRequest{}
+	mi := &file_taxi_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRidesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRidesRequest) ProtoMessage() {}
+
+func (x *ListRidesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRidesRequest.ProtoReflect.Descriptor instead.
+func (*ListRidesRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListRidesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListRidesRequest) GetPassengerId() int64 {
+	if x != nil {
+		return x.PassengerId
+	}
+	return 0
+}
+
+func (x *ListRidesRequest) GetDriverId() int64 {
+	if x != nil {
+		return x.DriverId
+	}
+	return 0
+}
+
+func (x *ListRidesRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListRidesRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListRidesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListRidesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRidesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListRidesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rides []*Ride                `protobuf:"bytes,1,rep,name=rides,proto3" json:"rides,omitempty"`
+	// Number of matching rides on all pages.
+	TotalCount    int64 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRidesResponse) Reset() {
+	*x = ListRidesResponse{}
+	mi := &file_taxi_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRidesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRidesResponse) ProtoMessage() {}
+
+func (x *ListRidesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRidesResponse.ProtoReflect.Descriptor instead.
+func (*ListRidesResponse) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListRidesResponse) GetRides() []*Ride {
+	if x != nil {
+		return x.Rides
+	}
+	return nil
+}
+
+func (x *ListRidesResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// EditRideRequest changes only the fields that are set.
+type EditRideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RideId        int64                  `protobuf:"varint,1,opt,name=ride_id,json=rideId,proto3" json:"ride_id,omitempty"`
+	Origin        *Location              `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination   *Location              `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	CarType       *string                `protobuf:"bytes,4,opt,name=car_type,json=carType,proto3,oneof" json:"car_type,omitempty"`
+	ScheduledAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	QuoteId       int64                  `protobuf:"varint,6,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditRideRequest) Reset() {
+	*x = EditRideRequest{}
+	mi := &file_taxi_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditRideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditRideRequest) ProtoMessage() {}
+
+func (x *EditRideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditRideRequest.ProtoReflect.Descriptor instead.
+func (*EditRideRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EditRideRequest) GetRideId() int64 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+func (x *EditRideRequest) GetOrigin() *Location {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *EditRideRequest) GetDestination() *Location {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *EditRideRequest) GetCarType() string {
+	if x != nil && x.CarType != nil {
+		return *x.CarType
+	}
+	return ""
+}
+
+func (x *EditRideRequest) GetScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return nil
+}
+
+func (x *EditRideRequest) GetQuoteId() int64 {
+	if x != nil {
+		return x.QuoteId
+	}
+	return 0
+}
+
+type UpdateRideStatusRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RideId int64                  `protobuf:"varint,1,opt,name=ride_id,json=rideId,proto3" json:"ride_id,omitempty"`
+	Status string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Only honoured for admins; drivers always act as "driver".
+	Actor         string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRideStatusRequest) Reset() {
+	*x = UpdateRideStatusRequest{}
+	mi := &file_taxi_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRideStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRideStatusRequest) ProtoMessage() {}
+
+func (x *UpdateRideStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRideStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRideStatusRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateRideStatusRequest) GetRideId() int64 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+func (x *UpdateRideStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateRideStatusRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *UpdateRideStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelRideRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RideId int64                  `protobuf:"varint,1,opt,name=ride_id,json=rideId,proto3" json:"ride_id,omitempty"`
+	// Only honoured for admins; everyone else cancels as themselves.
+	Actor         string `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	ReasonCode    string `protobuf:"bytes,3,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+	Note          string `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRideRequest) Reset() {
+	*x = CancelRideRequest{}
+	mi := &file_taxi_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRideRequest) ProtoMessage() {}
+
+func (x *CancelRideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRideRequest.ProtoReflect.Descriptor instead.
+func (*CancelRideRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CancelRideRequest) GetRideId() int64 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+func (x *CancelRideRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *CancelRideRequest) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *CancelRideRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type StatusChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty for the first entry of every ride.
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusChange) Reset() {
+	*x = StatusChange{}
+	mi := &file_taxi_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusChange) ProtoMessage() {}
+
+func (x *StatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusChange.ProtoReflect.Descriptor instead.
+func (*StatusChange) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StatusChange) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *StatusChange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *StatusChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *StatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StatusChange) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+type GetRideHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RideId        int64                  `protobuf:"varint,1,opt,name=ride_id,json=rideId,proto3" json:"ride_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRideHistoryRequest) Reset() {
+	*x = GetRideHistoryRequest{}
+	mi := &file_taxi_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRideHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRideHistoryRequest) ProtoMessage() {}
+
+func (x *GetRideHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRideHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetRideHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetRideHistoryRequest) GetRideId() int64 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+type GetRideHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*StatusChange        `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRideHistoryResponse) Reset() {
+	*x = GetRideHistoryResponse{}
+	mi := &file_taxi_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRideHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRideHistoryResponse) ProtoMessage() {}
+
+func (x *GetRideHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRideHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetRideHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetRideHistoryResponse) GetChanges() []*StatusChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type GetRideTransitionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RideId        int64                  `protobuf:"varint,1,opt,name=ride_id,json=rideId,proto3" json:"ride_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRideTransitionsRequest) Reset() {
+	*x = GetRideTransitionsRequest{}
+	mi := &file_taxi_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRideTransitionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRideTransitionsRequest) ProtoMessage() {}
+
+func (x *GetRideTransitionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRideTransitionsRequest.ProtoReflect.Descriptor instead.
+func (*GetRideTransitionsRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetRideTransitionsRequest) GetRideId() int64 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+type GetRideTransitionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RideId        int64                  `protobuf:"varint,1,opt,name=ride_id,json=rideId,proto3" json:"ride_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Transitions   []string               `protobuf:"bytes,3,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRideTransitionsResponse) Reset() {
+	*x = GetRideTransitionsResponse{}
+	mi := &file_taxi_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRideTransitionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRideTransitionsResponse) ProtoMessage() {}
+
+func (x *GetRideTransitionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRideTransitionsResponse.ProtoReflect.Descriptor instead.
+func (*GetRideTransitionsResponse) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetRideTransitionsResponse) GetRideId() int64 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+func (x *GetRideTransitionsResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetRideTransitionsResponse) GetTransitions() []string {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+type WatchRideRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RideId int64                  `protobuf:"varint,1,opt,name=ride_id,json=rideId,proto3" json:"ride_id,omitempty"`
+	// Resume after this event; 0 starts with new events only.
+	LastEventId   uint64 `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRideRequest) Reset() {
+	*x = WatchRideRequest{}
+	mi := &file_taxi_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRideRequest) ProtoMessage() {}
+
+func (x *WatchRideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRideRequest.ProtoReflect.Descriptor instead.
+func (*WatchRideRequest) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *WatchRideRequest) GetRideId() int64 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+func (x *WatchRideRequest) GetLastEventId() uint64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+// RideEvent is something that happened to a ride. Which of the optional
+// fields is set depends on type: ride for ride.created and ride.updated,
+// change (and driver_id) for ride.assigned and ride.status_changed,
+// location for driver.location.
+type RideEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RideId        int64                  `protobuf:"varint,2,opt,name=ride_id,json=rideId,proto3" json:"ride_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=at,proto3" json:"at,omitempty"`
+	Ride          *Ride                  `protobuf:"bytes,5,opt,name=ride,proto3" json:"ride,omitempty"`
+	Change        *StatusChange          `protobuf:"bytes,6,opt,name=change,proto3" json:"change,omitempty"`
+	DriverId      int64                  `protobuf:"varint,7,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Location      *DriverLocation        `protobuf:"bytes,8,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RideEvent) Reset() {
+	*x = RideEvent{}
+	mi := &file_taxi_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RideEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RideEvent) ProtoMessage() {}
+
+func (x *RideEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_taxi_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RideEvent.ProtoReflect.Descriptor instead.
+func (*RideEvent) Descriptor() ([]byte, []int) {
+	return file_taxi_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RideEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RideEvent) GetRideId() int64 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+func (x *RideEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RideEvent) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *RideEvent) GetRide() *Ride {
+	if x != nil {
+		return x.Ride
+	}
+	return nil
+}
+
+func (x *RideEvent) GetChange() *StatusChange {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+func (x *RideEvent) GetDriverId() int64 {
+	if x != nil {
+		return x.DriverId
+	}
+	return 0
+}
+
+func (x *RideEvent) GetLocation() *DriverLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+var File_taxi_proto protoreflect.FileDescriptor
+
+const file_taxi_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"taxi.proto\x12\ataxi.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"a\n" +
+	"\fLoginRequest\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12!\n" +
+	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"~\n" +
+	"\aSession\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\x03R\x02id\"H\n" +
+	"\bLocation\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lng\x18\x02 \x01(\x01R\x03lng\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\"\xc8\x01\n" +
+	"\tPassenger\x12!\n" +
+	"\fpassenger_id\x18\x01 \x01(\x03R\vpassengerId\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12!\n" +
+	"\fphone_number\x18\x04 \x01(\tR\vphoneNumber\x12\x16\n" +
+	"\x06rating\x18\x05 \x01(\x01R\x06rating\x12!\n" +
+	"\frating_count\x18\x06 \x01(\x03R\vratingCount\"\x95\x01\n" +
+	"\x18RegisterPassengerRequest\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x02 \x01(\tR\blastName\x12!\n" +
+	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\"u\n" +
+	"\x15PassengerRegistration\x120\n" +
+	"\tpassenger\x18\x01 \x01(\v2\x12.taxi.v1.PassengerR\tpassenger\x12*\n" +
+	"\asession\x18\x02 \x01(\v2\x10.taxi.v1.SessionR\asession\"8\n" +
+	"\x13GetPassengerRequest\x12!\n" +
+	"\fpassenger_id\x18\x01 \x01(\x03R\vpassengerId\"Y\n" +
+	"\x15ListPassengersRequest\x12\x12\n" +
+	"\x04sort\x18\x01 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"m\n" +
+	"\x16ListPassengersResponse\x122\n" +
+	"\n" +
+	"passengers\x18\x01 \x03(\v2\x12.taxi.v1.PassengerR\n" +
+	"passengers\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\";\n" +
+	"\x16DeletePassengerRequest\x12!\n" +
+	"\fpassenger_id\x18\x01 \x01(\x03R\vpassengerId\"\xbf\x02\n" +
+	"\x06Driver\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\x03R\bdriverId\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12!\n" +
+	"\fphone_number\x18\x04 \x01(\tR\vphoneNumber\x12\x1b\n" +
+	"\tis_online\x18\x05 \x01(\bR\bisOnline\x12!\n" +
+	"\fis_available\x18\x06 \x01(\bR\visAvailable\x12\x19\n" +
+	"\bcar_type\x18\a \x01(\tR\acarType\x12#\n" +
+	"\rlicense_plate\x18\b \x01(\x03R\flicensePlate\x12\x16\n" +
+	"\x06rating\x18\t \x01(\x01R\x06rating\x12!\n" +
+	"\frating_count\x18\n" +
+	" \x01(\x03R\vratingCount\"\xd2\x01\n" +
+	"\x15RegisterDriverRequest\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x02 \x01(\tR\blastName\x12!\n" +
+	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\x12\x19\n" +
+	"\bcar_type\x18\x04 \x01(\tR\acarType\x12#\n" +
+	"\rlicense_plate\x18\x05 \x01(\x03R\flicensePlate\x12\x1a\n" +
+	"\bpassword\x18\x06 \x01(\tR\bpassword\"i\n" +
+	"\x12DriverRegistration\x12'\n" +
+	"\x06driver\x18\x01 \x01(\v2\x0f.taxi.v1.DriverR\x06driver\x12*\n" +
+	"\asession\x18\x02 \x01(\v2\x10.taxi.v1.SessionR\asession\"/\n" +
+	"\x10GetDriverRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\x03R\bdriverId\"\xaa\x01\n" +
+	"\x12ListDriversRequest\x12&\n" +
+	"\fis_available\x18\x01 \x01(\bH\x00R\visAvailable\x88\x01\x01\x12\x19\n" +
+	"\bcar_type\x18\x02 \x01(\tR\acarType\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offsetB\x0f\n" +
+	"\r_is_available\"a\n" +
+	"\x13ListDriversResponse\x12)\n" +
+	"\adrivers\x18\x01 \x03(\v2\x0f.taxi.v1.DriverR\adrivers\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\"2\n" +
+	"\x13DeleteDriverRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\x03R\bdriverId\"R\n" +
+	"\x16SetAvailabilityRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\x03R\bdriverId\x12\x1b\n" +
+	"\tis_online\x18\x02 \x01(\bR\bisOnline\"\xa5\x01\n" +
+	"\x0eDriverLocation\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\x03R\bdriverId\x12\x10\n" +
+	"\x03lat\x18\x02 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lng\x18\x03 \x01(\x01R\x03lng\x12\x18\n" +
+	"\aheading\x18\x04 \x01(\x01R\aheading\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xac\x01\n" +
+	"\x15UpdateLocationRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\x03R\bdriverId\x12\x10\n" +
+	"\x03lat\x18\x02 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lng\x18\x03 \x01(\x01R\x03lng\x12\x18\n" +
+	"\aheading\x18\x04 \x01(\x01R\aheading\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"[\n" +
+	"\x18FindNearbyDriversRequest\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lng\x18\x02 \x01(\x01R\x03lng\x12\x1b\n" +
+	"\tradius_km\x18\x03 \x01(\x01R\bradiusKm\"\x8d\x01\n" +
+	"\fNearbyDriver\x12'\n" +
+	"\x06driver\x18\x01 \x01(\v2\x0f.taxi.v1.DriverR\x06driver\x123\n" +
+	"\blocation\x18\x02 \x01(\v2\x17.taxi.v1.DriverLocationR\blocation\x12\x1f\n" +
+	"\vdistance_km\x18\x03 \x01(\x01R\n" +
+	"distanceKm\"L\n" +
+	"\x19FindNearbyDriversResponse\x12/\n" +
+	"\adrivers\x18\x01 \x03(\v2\x15.taxi.v1.NearbyDriverR\adrivers\"\x98\a\n" +
+	"\x04Ride\x12\x17\n" +
+	"\aride_id\x18\x01 \x01(\x03R\x06rideId\x120\n" +
+	"\tpassenger\x18\x02 \x01(\v2\x12.taxi.v1.PassengerR\tpassenger\x12'\n" +
+	"\x06driver\x18\x03 \x01(\v2\x0f.taxi.v1.DriverR\x06driver\x12)\n" +
+	"\x06origin\x18\x04 \x01(\v2\x11.taxi.v1.LocationR\x06origin\x123\n" +
+	"\vdestination\x18\x05 \x01(\v2\x11.taxi.v1.LocationR\vdestination\x12\x1f\n" +
+	"\vdistance_km\x18\x06 \x01(\x01R\n" +
+	"distanceKm\x12\x19\n" +
+	"\bcar_type\x18\a \x01(\tR\acarType\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12=\n" +
+	"\fscheduled_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\x12\x1f\n" +
+	"\vquoted_fare\x18\v \x01(\x03R\n" +
+	"quotedFare\x12)\n" +
+	"\x10surge_multiplier\x18\f \x01(\x01R\x0fsurgeMultiplier\x12\x1d\n" +
+	"\n" +
+	"final_fare\x18\r \x01(\x03R\tfinalFare\x12)\n" +
+	"\x10cancellation_fee\x18\x0e \x01(\x03R\x0fcancellationFee\x12!\n" +
+	"\fcancelled_by\x18\x0f \x01(\tR\vcancelledBy\x12#\n" +
+	"\rcancel_reason\x18\x10 \x01(\tR\fcancelReason\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vaccepted_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"acceptedAt\x129\n" +
+	"\n" +
+	"started_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12=\n" +
+	"\fcancelled_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\"\x8b\x02\n" +
+	"\x11CreateRideRequest\x12!\n" +
+	"\fpassenger_id\x18\x01 \x01(\x03R\vpassengerId\x12)\n" +
+	"\x06origin\x18\x02 \x01(\v2\x11.taxi.v1.LocationR\x06origin\x123\n" +
+	"\vdestination\x18\x03 \x01(\v2\x11.taxi.v1.LocationR\vdestination\x12\x19\n" +
+	"\bcar_type\x18\x04 \x01(\tR\acarType\x12\x19\n" +
+	"\bquote_id\x18\x05 \x01(\x03R\aquoteId\x12=\n" +
+	"\fscheduled_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\")\n" +
+	"\x0eGetRideRequest\x12\x17\n" +
+	"\aride_id\x18\x01 \x01(\x03R\x06rideId\"\xa6\x02\n" +
+	"\x10ListRidesRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12!\n" +
+	"\fpassenger_id\x18\x02 \x01(\x03R\vpassengerId\x12\x1b\n" +
+	"\tdriver_id\x18\x03 \x01(\x03R\bdriverId\x12=\n" +
+	"\fcreated_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\b \x01(\x05R\x06offset\"Y\n" +
+	"\x11ListRidesResponse\x12#\n" +
+	"\x05rides\x18\x01 \x03(\v2\r.taxi.v1.RideR\x05rides\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\"\x91\x02\n" +
+	"\x0fEditRideRequest\x12\x17\n" +
+	"\aride_id\x18\x01 \x01(\x03R\x06rideId\x12)\n" +
+	"\x06origin\x18\x02 \x01(\v2\x11.taxi.v1.LocationR\x06origin\x123\n" +
+	"\vdestination\x18\x03 \x01(\v2\x11.taxi.v1.LocationR\vdestination\x12\x1e\n" +
+	"\bcar_type\x18\x04 \x01(\tH\x00R\acarType\x88\x01\x01\x12=\n" +
+	"\fscheduled_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\x12\x19\n" +
+	"\bquote_id\x18\x06 \x01(\x03R\aquoteIdB\v\n" +
+	"\t_car_type\"x\n" +
+	"\x17UpdateRideStatusRequest\x12\x17\n" +
+	"\aride_id\x18\x01 \x01(\x03R\x06rideId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"w\n" +
+	"\x11CancelRideRequest\x12\x17\n" +
+	"\aride_id\x18\x01 \x01(\x03R\x06rideId\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x1f\n" +
+	"\vreason_code\x18\x03 \x01(\tR\n" +
+	"reasonCode\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"\x8c\x01\n" +
+	"\fStatusChange\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12*\n" +
+	"\x02at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\"0\n" +
+	"\x15GetRideHistoryRequest\x12\x17\n" +
+	"\aride_id\x18\x01 \x01(\x03R\x06rideId\"I\n" +
+	"\x16GetRideHistoryResponse\x12/\n" +
+	"\achanges\x18\x01 \x03(\v2\x15.taxi.v1.StatusChangeR\achanges\"4\n" +
+	"\x19GetRideTransitionsRequest\x12\x17\n" +
+	"\aride_id\x18\x01 \x01(\x03R\x06rideId\"o\n" +
+	"\x1aGetRideTransitionsResponse\x12\x17\n" +
+	"\aride_id\x18\x01 \x01(\x03R\x06rideId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12 \n" +
+	"\vtransitions\x18\x03 \x03(\tR\vtransitions\"O\n" +
+	"\x10WatchRideRequest\x12\x17\n" +
+	"\aride_id\x18\x01 \x01(\x03R\x06rideId\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\x04R\vlastEventId\"\x98\x02\n" +
+	"\tRideEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\aride_id\x18\x02 \x01(\x03R\x06rideId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12*\n" +
+	"\x02at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12!\n" +
+	"\x04ride\x18\x05 \x01(\v2\r.taxi.v1.RideR\x04ride\x12-\n" +
+	"\x06change\x18\x06 \x01(\v2\x15.taxi.v1.StatusChangeR\x06change\x12\x1b\n" +
+	"\tdriver_id\x18\a \x01(\x03R\bdriverId\x123\n" +
+	"\blocation\x18\b \x01(\v2\x17.taxi.v1.DriverLocationR\blocation2?\n" +
+	"\vAuthService\x120\n" +
+	"\x05Login\x12\x15.taxi.v1.LoginRequest\x1a\x10.taxi.v1.Session2\xcb\x02\n" +
+	"\x10PassengerService\x12V\n" +
+	"\x11RegisterPassenger\x12!.taxi.v1.RegisterPassengerRequest\x1a\x1e.taxi.v1.PassengerRegistration\x12@\n" +
+	"\fGetPassenger\x12\x1c.taxi.v1.GetPassengerRequest\x1a\x12.taxi.v1.Passenger\x12Q\n" +
+	"\x0eListPassengers\x12\x1e.taxi.v1.ListPassengersRequest\x1a\x1f.taxi.v1.ListPassengersResponse\x12J\n" +
+	"\x0fDeletePassenger\x12\x1f.taxi.v1.DeletePassengerRequest\x1a\x16.google.protobuf.Empty2\x93\x04\n" +
+	"\rDriverService\x12M\n" +
+	"\x0eRegisterDriver\x12\x1e.taxi.v1.RegisterDriverRequest\x1a\x1b.taxi.v1.DriverRegistration\x127\n" +
+	"\tGetDriver\x12\x19.taxi.v1.GetDriverRequest\x1a\x0f.taxi.v1.Driver\x12H\n" +
+	"\vListDrivers\x12\x1b.taxi.v1.ListDriversRequest\x1a\x1c.taxi.v1.ListDriversResponse\x12D\n" +
+	"\fDeleteDriver\x12\x1c.taxi.v1.DeleteDriverRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\x0fSetAvailability\x12\x1f.taxi.v1.SetAvailabilityRequest\x1a\x0f.taxi.v1.Driver\x12I\n" +
+	"\x0eUpdateLocation\x12\x1e.taxi.v1.UpdateLocationRequest\x1a\x17.taxi.v1.DriverLocation\x12Z\n" +
+	"\x11FindNearbyDrivers\x12!.taxi.v1.FindNearbyDriversRequest\x1a\".taxi.v1.FindNearbyDriversResponse2\xe0\x04\n" +
+	"\vRideService\x127\n" +
+	"\n" +
+	"CreateRide\x12\x1a.taxi.v1.CreateRideRequest\x1a\r.taxi.v1.Ride\x121\n" +
+	"\aGetRide\x12\x17.taxi.v1.GetRideRequest\x1a\r.taxi.v1.Ride\x12B\n" +
+	"\tListRides\x12\x19.taxi.v1.ListRidesRequest\x1a\x1a.taxi.v1.ListRidesResponse\x123\n" +
+	"\bEditRide\x12\x18.taxi.v1.EditRideRequest\x1a\r.taxi.v1.Ride\x12C\n" +
+	"\x10UpdateRideStatus\x12 .taxi.v1.UpdateRideStatusRequest\x1a\r.taxi.v1.Ride\x127\n" +
+	"\n" +
+	"CancelRide\x12\x1a.taxi.v1.CancelRideRequest\x1a\r.taxi.v1.Ride\x12Q\n" +
+	"\x0eGetRideHistory\x12\x1e.taxi.v1.GetRideHistoryRequest\x1a\x1f.taxi.v1.GetRideHistoryResponse\x12]\n" +
+	"\x12GetRideTransitions\x12\".taxi.v1.GetRideTransitionsRequest\x1a#.taxi.v1.GetRideTransitionsResponse\x12<\n" +
+	"\tWatchRide\x12\x19.taxi.v1.WatchRideRequest\x1a\x12.taxi.v1.RideEvent0\x01B!Z\x1ftaxiAPI/internal/grpcapi/taxipbb\x06proto3"
+
+var (
+	file_taxi_proto_rawDescOnce sync.Once
+	file_taxi_proto_rawDescData []byte
+)
+
+func file_taxi_proto_rawDescGZIP() []byte {
+	file_taxi_proto_rawDescOnce.Do(func() {
+		file_taxi_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_taxi_proto_rawDesc), len(file_taxi_proto_rawDesc)))
+	})
+	return file_taxi_proto_rawDescData
+}
+
+var file_taxi_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
+var file_taxi_proto_goTypes = []any{
+	(*LoginRequest)(nil),               // 0: taxi.v1.LoginRequest
+	(*Session)(nil),                    // 1: taxi.v1.Session
+	(*Location)(nil),                   // 2: taxi.v1.Location
+	(*Passenger)(nil),                  // 3: taxi.v1.Passenger
+	(*RegisterPassengerRequest)(nil),   // 4: taxi.v1.RegisterPassengerRequest
+	(*PassengerRegistration)(nil),      // 5: taxi.v1.PassengerRegistration
+	(*GetPassengerRequest)(nil),        // 6: taxi.v1.GetPassengerRequest
+	(*ListPassengersRequest)(nil),      // 7: taxi.v1.ListPassengersRequest
+	(*ListPassengersResponse)(nil),     // 8: taxi.v1.ListPassengersResponse
+	(*DeletePassengerRequest)(nil),     // 9: taxi.v1.DeletePassengerRequest
+	(*Driver)(nil),                     // 10: taxi.v1.Driver
+	(*RegisterDriverRequest)(nil),      // 11: taxi.v1.RegisterDriverRequest
+	(*DriverRegistration)(nil),         // 12: taxi.v1.DriverRegistration
+	(*GetDriverRequest)(nil),           // 13: taxi.v1.GetDriverRequest
+	(*ListDriversRequest)(nil),         // 14: taxi.v1.ListDriversRequest
+	(*ListDriversResponse)(nil),        // 15: taxi.v1.ListDriversResponse
+	(*DeleteDriverRequest)(nil),        // 16: taxi.v1.DeleteDriverRequest
+	(*SetAvailabilityRequest)(nil),     // 17: taxi.v1.SetAvailabilityRequest
+	(*DriverLocation)(nil),             // 18: taxi.v1.DriverLocation
+	(*UpdateLocationRequest)(nil),      // 19: taxi.v1.UpdateLocationRequest
+	(*FindNearbyDriversRequest)(nil),   // 20: taxi.v1.FindNearbyDriversRequest
+	(*NearbyDriver)(nil),               // 21: taxi.v1.NearbyDriver
+	(*FindNearbyDriversResponse)(nil),  // 22: taxi.v1.FindNearbyDriversResponse
+	(*Ride)(nil),                       // 23: taxi.v1.Ride
+	(*CreateRideRequest)(nil),          // 24: taxi.v1.CreateRideRequest
+	(*GetRideRequest)(nil),             // 25: taxi.v1.GetRideRequest
+	(*ListRidesRequest)(nil),           // 26: taxi.v1.ListRidesRequest
+	(*ListRidesResponse)(nil),          // 27: taxi.v1.ListRidesResponse
+	(*EditRideRequest)(nil),            // 28: taxi.v1.EditRideRequest
+	(*UpdateRideStatusRequest)(nil),    // 29: taxi.v1.UpdateRideStatusRequest
+	(*CancelRideRequest)(nil),          // 30: taxi.v1.CancelRideRequest
+	(*StatusChange)(nil),               // 31: taxi.v1.StatusChange
+	(*GetRideHistoryRequest)(nil),      // 32: taxi.v1.GetRideHistoryRequest
+	(*GetRideHistoryResponse)(nil),     // 33: taxi.v1.GetRideHistoryResponse
+	(*GetRideTransitionsRequest)(nil),  // 34: taxi.v1.GetRideTransitionsRequest
+	(*GetRideTransitionsResponse)(nil), // 35: taxi.v1.GetRideTransitionsResponse
+	(*WatchRideRequest)(nil),           // 36: taxi.v1.WatchRideRequest
+	(*RideEvent)(nil),                  // 37: taxi.v1.RideEvent
+	(*timestamppb.Timestamp)(nil),      // 38: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),              // 39: google.protobuf.Empty
+}
+var file_taxi_proto_depIdxs = []int32{
+	38, // 0: taxi.v1.Session.expires_at:type_name -> google.protobuf.Timestamp
+	3,  // 1: taxi.v1.PassengerRegistration.passenger:type_name -> taxi.v1.Passenger
+	1,  // 2: taxi.v1.PassengerRegistration.session:type_name -> taxi.v1.Session
+	3,  // 3: taxi.v1.ListPassengersResponse.passengers:type_name -> taxi.v1.Passenger
+	10, // 4: taxi.v1.DriverRegistration.driver:type_name -> taxi.v1.Driver
+	1,  // 5: taxi.v1.DriverRegistration.session:type_name -> taxi.v1.Session
+	10, // 6: taxi.v1.ListDriversResponse.drivers:type_name -> taxi.v1.Driver
+	38, // 7: taxi.v1.DriverLocation.timestamp:type_name -> google.protobuf.Timestamp
+	38, // 8: taxi.v1.UpdateLocationRequest.timestamp:type_name -> google.protobuf.Timestamp
+	10, // 9: taxi.v1.NearbyDriver.driver:type_name -> taxi.v1.Driver
+	18, // 10: taxi.v1.NearbyDriver.location:type_name -> taxi.v1.DriverLocation
+	21, // 11: taxi.v1.FindNearbyDriversResponse.drivers:type_name -> taxi.v1.NearbyDriver
+	3,  // 12: taxi.v1.Ride.passenger:type_name -> taxi.v1.Passenger
+	10, // 13: taxi.v1.Ride.driver:type_name -> taxi.v1.Driver
+	2,  // 14: taxi.v1.Ride.origin:type_name -> taxi.v1.Location
+	2,  // 15: taxi.v1.Ride.destination:type_name -> taxi.v1.Location
+	38, // 16: taxi.v1.Ride.scheduled_at:type_name -> google.protobuf.Timestamp
+	38, // 17: taxi.v1.Ride.created_at:type_name -> google.protobuf.Timestamp
+	38, // 18: taxi.v1.Ride.accepted_at:type_name -> google.protobuf.Timestamp
+	38, // 19: taxi.v1.Ride.started_at:type_name -> google.protobuf.Timestamp
+	38, // 20: taxi.v1.Ride.completed_at:type_name -> google.protobuf.Timestamp
+	38, // 21: taxi.v1.Ride.cancelled_at:type_name -> google.protobuf.Timestamp
+	2,  // 22: taxi.v1.CreateRideRequest.origin:type_name -> taxi.v1.Location
+	2,  // 23: taxi.v1.CreateRideRequest.destination:type_name -> taxi.v1.Location
+	38, // 24: taxi.v1.CreateRideRequest.scheduled_at:type_name -> google.protobuf.Timestamp
+	38, // 25: taxi.v1.ListRidesRequest.created_from:type_name -> google.protobuf.Timestamp
+	38, // 26: taxi.v1.ListRidesRequest.created_to:type_name -> google.protobuf.Timestamp
+	23, // 27: taxi.v1.ListRidesResponse.rides:type_name -> taxi.v1.Ride
+	2,  // 28: taxi.v1.EditRideRequest.origin:type_name -> taxi.v1.Location
+	2,  // 29: taxi.v1.EditRideRequest.destination:type_name -> taxi.v1.Location
+	38, // 30: taxi.v1.EditRideRequest.scheduled_at:type_name -> google.protobuf.Timestamp
+	38, // 31: taxi.v1.StatusChange.at:type_name -> google.protobuf.Timestamp
+	31, // 32: taxi.v1.GetRideHistoryResponse.changes:type_name -> taxi.v1.StatusChange
+	38, // 33: taxi.v1.RideEvent.at:type_name -> google.protobuf.Timestamp
+	23, // 34: taxi.v1.RideEvent.ride:type_name -> taxi.v1.Ride
+	31, // 35: taxi.v1.RideEvent.change:type_name -> taxi.v1.StatusChange
+	18, // 36: taxi.v1.RideEvent.location:type_name -> taxi.v1.DriverLocation
+	0,  // 37: taxi.v1.AuthService.Login:input_type -> taxi.v1.LoginRequest
+	4,  // 38: taxi.v1.PassengerService.RegisterPassenger:input_type -> taxi.v1.RegisterPassengerRequest
+	6,  // 39: taxi.v1.PassengerService.GetPassenger:input_type -> taxi.v1.GetPassengerRequest
+	7,  // 40: taxi.v1.PassengerService.ListPassengers:input_type -> taxi.v1.ListPassengersRequest
+	9,  // 41: taxi.v1.PassengerService.DeletePassenger:input_type -> taxi.v1.DeletePassengerRequest
+	11, // 42: taxi.v1.DriverService.RegisterDriver:input_type -> taxi.v1.RegisterDriverRequest
+	13, // 43: taxi.v1.DriverService.GetDriver:input_type -> taxi.v1.GetDriverRequest
+	14, // 44: taxi.v1.DriverService.ListDrivers:input_type -> taxi.v1.ListDriversRequest
+	16, // 45: taxi.v1.DriverService.DeleteDriver:input_type -> taxi.v1.DeleteDriverRequest
+	17, // 46: taxi.v1.DriverService.SetAvailability:input_type -> taxi.v1.SetAvailabilityRequest
+	19, // 47: taxi.v1.DriverService.UpdateLocation:input_type -> taxi.v1.UpdateLocationRequest
+	20, // 48: taxi.v1.DriverService.FindNearbyDrivers:input_type -> taxi.v1.FindNearbyDriversRequest
+	24, // 49: taxi.v1.RideService.CreateRide:input_type -> taxi.v1.CreateRideRequest
+	25, // 50: taxi.v1.RideService.GetRide:input_type -> taxi.v1.GetRideRequest
+	26, // 51: taxi.v1.RideService.ListRides:input_type -> taxi.v1.ListRidesRequest
+	28, // 52: taxi.v1.RideService.EditRide:input_type -> taxi.v1.EditRideRequest
+	29, // 53: taxi.v1.RideService.UpdateRideStatus:input_type -> taxi.v1.UpdateRideStatusRequest
+	30, // 54: taxi.v1.RideService.CancelRide:input_type -> taxi.v1.CancelRideRequest
+	32, // 55: taxi.v1.RideService.GetRideHistory:input_type -> taxi.v1.GetRideHistoryRequest
+	34, // 56: taxi.v1.RideService.GetRideTransitions:input_type -> taxi.v1.GetRideTransitionsRequest
+	36, // 57: taxi.v1.RideService.WatchRide:input_type -> taxi.v1.WatchRideRequest
+	1,  // 58: taxi.v1.AuthService.Login:output_type -> taxi.v1.Session
+	5,  // 59: taxi.v1.PassengerService.RegisterPassenger:output_type -> taxi.v1.PassengerRegistration
+	3,  // 60: taxi.v1.PassengerService.GetPassenger:output_type -> taxi.v1.Passenger
+	8,  // 61: taxi.v1.PassengerService.ListPassengers:output_type -> taxi.v1.ListPassengersResponse
+	39, // 62: taxi.v1.PassengerService.DeletePassenger:output_type -> google.protobuf.Empty
+	12, // 63: taxi.v1.DriverService.RegisterDriver:output_type -> taxi.v1.DriverRegistration
+	10, // 64: taxi.v1.DriverService.GetDriver:output_type -> taxi.v1.Driver
+	15, // 65: taxi.v1.DriverService.ListDrivers:output_type -> taxi.v1.ListDriversResponse
+	39, // 66: taxi.v1.DriverService.DeleteDriver:output_type -> google.protobuf.Empty
+	10, // 67: taxi.v1.DriverService.SetAvailability:output_type -> taxi.v1.Driver
+	18, // 68: taxi.v1.DriverService.UpdateLocation:output_type -> taxi.v1.DriverLocation
+	22, // 69: taxi.v1.DriverService.FindNearbyDrivers:output_type -> taxi.v1.FindNearbyDriversResponse
+	23, // 70: taxi.v1.RideService.CreateRide:output_type -> taxi.v1.Ride
+	23, // 71: taxi.v1.RideService.GetRide:output_type -> taxi.v1.Ride
+	27, // 72: taxi.v1.RideService.ListRides:output_type -> taxi.v1.ListRidesResponse
+	23, // 73: taxi.v1.RideService.EditRide:output_type -> taxi.v1.Ride
+	23, // 74: taxi.v1.RideService.UpdateRideStatus:output_type -> taxi.v1.Ride
+	23, // 75: taxi.v1.RideService.CancelRide:output_type -> taxi.v1.Ride
+	33, // 76: taxi.v1.RideService.GetRideHistory:output_type -> taxi.v1.GetRideHistoryResponse
+	35, // 77: taxi.v1.RideService.GetRideTransitions:output_type -> taxi.v1.GetRideTransitionsResponse
+	37, // 78: taxi.v1.RideService.WatchRide:output_type -> taxi.v1.RideEvent
+	58, // [58:79] is the sub-list for method output_type
+	37, // [37:58] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
+}
+
+func init() { file_taxi_proto_init() }
+func file_taxi_proto_init() {
+	if File_taxi_proto != nil {
+		return
+	}
+	file_taxi_proto_msgTypes[14].OneofWrappers = []any{}
+	file_taxi_proto_msgTypes[28].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_taxi_proto_rawDesc), len(file_taxi_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   38,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_taxi_proto_goTypes,
+		DependencyIndexes: file_taxi_proto_depIdxs,
+		MessageInfos:      file_taxi_proto_msgTypes,
+	}.Build()
+	File_taxi_proto = out.File
+	file_taxi_proto_goTypes = nil
+	file_taxi_proto_depIdxs = nil
+}
@@ -0,0 +1,1096 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: taxi.proto
+
+package taxipb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AuthService_Login_FullMethodName = "/taxi.v1.AuthService/Login"
+)
+
+// AuthServiceClient is the client API for AuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthServiceClient interface {
+	// Login signs a passenger, driver or admin in.
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*Session, error)
+}
+
+type authServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
+	return &authServiceClient{cc}
+}
+
+func (c *authServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*Session, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Session)
+	err := c.cc.Invoke(ctx, AuthService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility.
+type AuthServiceServer interface {
+	// Login signs a passenger, driver or admin in.
+	Login(context.Context, *LoginRequest) (*Session, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthServiceServer struct{}
+
+func (UnimplementedAuthServiceServer) Login(context.Context, *LoginRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuthServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "taxi.v1.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Login",
+			Handler:    _AuthService_Login_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "taxi.proto",
+}
+
+const (
+	PassengerService_RegisterPassenger_FullMethodName = "/taxi.v1.PassengerService/RegisterPassenger"
+	PassengerService_GetPassenger_FullMethodName      = "/taxi.v1.PassengerService/GetPassenger"
+	PassengerService_ListPassengers_FullMethodName    = "/taxi.v1.PassengerService/ListPassengers"
+	PassengerService_DeletePassenger_FullMethodName   = "/taxi.v1.PassengerService/DeletePassenger"
+)
+
+// PassengerServiceClient is the client API for PassengerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PassengerServiceClient interface {
+	RegisterPassenger(ctx context.Context, in *RegisterPassengerRequest, opts ...grpc.CallOption) (*PassengerRegistration, error)
+	GetPassenger(ctx context.Context, in *GetPassengerRequest, opts ...grpc.CallOption) (*Passenger, error)
+	ListPassengers(ctx context.Context, in *ListPassengersRequest, opts ...grpc.CallOption) (*ListPassengersResponse, error)
+	DeletePassenger(ctx context.Context, in *DeletePassengerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type passengerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPassengerServiceClient(cc grpc.ClientConnInterface) PassengerServiceClient {
+	return &passengerServiceClient{cc}
+}
+
+func (c *passengerServiceClient) RegisterPassenger(ctx context.Context, in *RegisterPassengerRequest, opts ...grpc.CallOption) (*PassengerRegistration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PassengerRegistration)
+	err := c.cc.Invoke(ctx, PassengerService_RegisterPassenger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passengerServiceClient) GetPassenger(ctx context.Context, in *GetPassengerRequest, opts ...grpc.CallOption) (*Passenger, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Passenger)
+	err := c.cc.Invoke(ctx, PassengerService_GetPassenger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passengerServiceClient) ListPassengers(ctx context.Context, in *ListPassengersRequest, opts ...grpc.CallOption) (*ListPassengersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPassengersResponse)
+	err := c.cc.Invoke(ctx, PassengerService_ListPassengers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passengerServiceClient) DeletePassenger(ctx context.Context, in *DeletePassengerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PassengerService_DeletePassenger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PassengerServiceServer is the server API for PassengerService service.
+// All implementations must embed UnimplementedPassengerServiceServer
+// for forward compatibility.
+type PassengerServiceServer interface {
+	RegisterPassenger(context.Context, *RegisterPassengerRequest) (*PassengerRegistration, error)
+	GetPassenger(context.Context, *GetPassengerRequest) (*Passenger, error)
+	ListPassengers(context.Context, *ListPassengersRequest) (*ListPassengersResponse, error)
+	DeletePassenger(context.Context, *DeletePassengerRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPassengerServiceServer()
+}
+
+// UnimplementedPassengerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPassengerServiceServer struct{}
+
+func (UnimplementedPassengerServiceServer) RegisterPassenger(context.Context, *RegisterPassengerRequest) (*PassengerRegistration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterPassenger not implemented")
+}
+func (UnimplementedPassengerServiceServer) GetPassenger(context.Context, *GetPassengerRequest) (*Passenger, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPassenger not implemented")
+}
+func (UnimplementedPassengerServiceServer) ListPassengers(context.Context, *ListPassengersRequest) (*ListPassengersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPassengers not implemented")
+}
+func (UnimplementedPassengerServiceServer) DeletePassenger(context.Context, *DeletePassengerRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePassenger not implemented")
+}
+func (UnimplementedPassengerServiceServer) mustEmbedUnimplementedPassengerServiceServer() {}
+func (UnimplementedPassengerServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafePassengerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PassengerServiceServer will
+// result in compilation errors.
+type UnsafePassengerServiceServer interface {
+	mustEmbedUnimplementedPassengerServiceServer()
+}
+
+func RegisterPassengerServiceServer(s grpc.ServiceRegistrar, srv PassengerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPassengerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PassengerService_ServiceDesc, srv)
+}
+
+func _PassengerService_RegisterPassenger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterPassengerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).RegisterPassenger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_RegisterPassenger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).RegisterPassenger(ctx, req.(*RegisterPassengerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassengerService_GetPassenger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPassengerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).GetPassenger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_GetPassenger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).GetPassenger(ctx, req.(*GetPassengerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassengerService_ListPassengers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPassengersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).ListPassengers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_ListPassengers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).ListPassengers(ctx, req.(*ListPassengersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassengerService_DeletePassenger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePassengerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).DeletePassenger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_DeletePassenger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).DeletePassenger(ctx, req.(*DeletePassengerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PassengerService_ServiceDesc is the grpc.ServiceDesc for PassengerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PassengerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "taxi.v1.PassengerService",
+	HandlerType: (*PassengerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterPassenger",
+			Handler:    _PassengerService_RegisterPassenger_Handler,
+		},
+		{
+			MethodName: "GetPassenger",
+			Handler:    _PassengerService_GetPassenger_Handler,
+		},
+		{
+			MethodName: "ListPassengers",
+			Handler:    _PassengerService_ListPassengers_Handler,
+		},
+		{
+			MethodName: "DeletePassenger",
+			Handler:    _PassengerService_DeletePassenger_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "taxi.proto",
+}
+
+const (
+	DriverService_RegisterDriver_FullMethodName    = "/taxi.v1.DriverService/RegisterDriver"
+	DriverService_GetDriver_FullMethodName         = "/taxi.v1.DriverService/GetDriver"
+	DriverService_ListDrivers_FullMethodName       = "/taxi.v1.DriverService/ListDrivers"
+	DriverService_DeleteDriver_FullMethodName      = "/taxi.v1.DriverService/DeleteDriver"
+	DriverService_SetAvailability_FullMethodName   = "/taxi.v1.DriverService/SetAvailability"
+	DriverService_UpdateLocation_FullMethodName    = "/taxi.v1.DriverService/UpdateLocation"
+	DriverService_FindNearbyDrivers_FullMethodName = "/taxi.v1.DriverService/FindNearbyDrivers"
+)
+
+// DriverServiceClient is the client API for DriverService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DriverServiceClient interface {
+	RegisterDriver(ctx context.Context, in *RegisterDriverRequest, opts ...grpc.CallOption) (*DriverRegistration, error)
+	GetDriver(ctx context.Context, in *GetDriverRequest, opts ...grpc.CallOption) (*Driver, error)
+	ListDrivers(ctx context.Context, in *ListDriversRequest, opts ...grpc.CallOption) (*ListDriversResponse, error)
+	DeleteDriver(ctx context.Context, in *DeleteDriverRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// SetAvailability takes the driver online or offline.
+	SetAvailability(ctx context.Context, in *SetAvailabilityRequest, opts ...grpc.CallOption) (*Driver, error)
+	UpdateLocation(ctx context.Context, in *UpdateLocationRequest, opts ...grpc.CallOption) (*DriverLocation, error)
+	FindNearbyDrivers(ctx context.Context, in *FindNearbyDriversRequest, opts ...grpc.CallOption) (*FindNearbyDriversResponse, error)
+}
+
+type driverServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDriverServiceClient(cc grpc.ClientConnInterface) DriverServiceClient {
+	return &driverServiceClient{cc}
+}
+
+func (c *driverServiceClient) RegisterDriver(ctx context.Context, in *RegisterDriverRequest, opts ...grpc.CallOption) (*DriverRegistration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DriverRegistration)
+	err := c.cc.Invoke(ctx, DriverService_RegisterDriver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) GetDriver(ctx context.Context, in *GetDriverRequest, opts ...grpc.CallOption) (*Driver, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Driver)
+	err := c.cc.Invoke(ctx, DriverService_GetDriver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) ListDrivers(ctx context.Context, in *ListDriversRequest, opts ...grpc.CallOption) (*ListDriversResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDriversResponse)
+	err := c.cc.Invoke(ctx, DriverService_ListDrivers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) DeleteDriver(ctx context.Context, in *DeleteDriverRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DriverService_DeleteDriver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) SetAvailability(ctx context.Context, in *SetAvailabilityRequest, opts ...grpc.CallOption) (*Driver, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Driver)
+	err := c.cc.Invoke(ctx, DriverService_SetAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) UpdateLocation(ctx context.Context, in *UpdateLocationRequest, opts ...grpc.CallOption) (*DriverLocation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DriverLocation)
+	err := c.cc.Invoke(ctx, DriverService_UpdateLocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) FindNearbyDrivers(ctx context.Context, in *FindNearbyDriversRequest, opts ...grpc.CallOption) (*FindNearbyDriversResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindNearbyDriversResponse)
+	err := c.cc.Invoke(ctx, DriverService_FindNearbyDrivers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DriverServiceServer is the server API for DriverService service.
+// All implementations must embed UnimplementedDriverServiceServer
+// for forward compatibility.
+type DriverServiceServer interface {
+	RegisterDriver(context.Context, *RegisterDriverRequest) (*DriverRegistration, error)
+	GetDriver(context.Context, *GetDriverRequest) (*Driver, error)
+	ListDrivers(context.Context, *ListDriversRequest) (*ListDriversResponse, error)
+	DeleteDriver(context.Context, *DeleteDriverRequest) (*emptypb.Empty, error)
+	// SetAvailability takes the driver online or offline.
+	SetAvailability(context.Context, *SetAvailabilityRequest) (*Driver, error)
+	UpdateLocation(context.Context, *UpdateLocationRequest) (*DriverLocation, error)
+	FindNearbyDrivers(context.Context, *FindNearbyDriversRequest) (*FindNearbyDriversResponse, error)
+	mustEmbedUnimplementedDriverServiceServer()
+}
+
+// UnimplementedDriverServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDriverServiceServer struct{}
+
+func (UnimplementedDriverServiceServer) RegisterDriver(context.Context, *RegisterDriverRequest) (*DriverRegistration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterDriver not implemented")
+}
+func (UnimplementedDriverServiceServer) GetDriver(context.Context, *GetDriverRequest) (*Driver, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriver not implemented")
+}
+func (UnimplementedDriverServiceServer) ListDrivers(context.Context, *ListDriversRequest) (*ListDriversResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDrivers not implemented")
+}
+func (UnimplementedDriverServiceServer) DeleteDriver(context.Context, *DeleteDriverRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDriver not implemented")
+}
+func (UnimplementedDriverServiceServer) SetAvailability(context.Context, *SetAvailabilityRequest) (*Driver, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAvailability not implemented")
+}
+func (UnimplementedDriverServiceServer) UpdateLocation(context.Context, *UpdateLocationRequest) (*DriverLocation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLocation not implemented")
+}
+func (UnimplementedDriverServiceServer) FindNearbyDrivers(context.Context, *FindNearbyDriversRequest) (*FindNearbyDriversResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindNearbyDrivers not implemented")
+}
+func (UnimplementedDriverServiceServer) mustEmbedUnimplementedDriverServiceServer() {}
+func (UnimplementedDriverServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeDriverServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DriverServiceServer will
+// result in compilation errors.
+type UnsafeDriverServiceServer interface {
+	mustEmbedUnimplementedDriverServiceServer()
+}
+
+func RegisterDriverServiceServer(s grpc.ServiceRegistrar, srv DriverServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDriverServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DriverService_ServiceDesc, srv)
+}
+
+func _DriverService_RegisterDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).RegisterDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_RegisterDriver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).RegisterDriver(ctx, req.(*RegisterDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_GetDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).GetDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_GetDriver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).GetDriver(ctx, req.(*GetDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_ListDrivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDriversRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).ListDrivers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_ListDrivers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).ListDrivers(ctx, req.(*ListDriversRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_DeleteDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).DeleteDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_DeleteDriver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).DeleteDriver(ctx, req.(*DeleteDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_SetAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).SetAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_SetAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).SetAvailability(ctx, req.(*SetAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_UpdateLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).UpdateLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_UpdateLocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).UpdateLocation(ctx, req.(*UpdateLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_FindNearbyDrivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindNearbyDriversRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).FindNearbyDrivers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_FindNearbyDrivers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).FindNearbyDrivers(ctx, req.(*FindNearbyDriversRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DriverService_ServiceDesc is the grpc.ServiceDesc for DriverService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DriverService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "taxi.v1.DriverService",
+	HandlerType: (*DriverServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterDriver",
+			Handler:    _DriverService_RegisterDriver_Handler,
+		},
+		{
+			MethodName: "GetDriver",
+			Handler:    _DriverService_GetDriver_Handler,
+		},
+		{
+			MethodName: "ListDrivers",
+			Handler:    _DriverService_ListDrivers_Handler,
+		},
+		{
+			MethodName: "DeleteDriver",
+			Handler:    _DriverService_DeleteDriver_Handler,
+		},
+		{
+			MethodName: "SetAvailability",
+			Handler:    _DriverService_SetAvailability_Handler,
+		},
+		{
+			MethodName: "UpdateLocation",
+			Handler:    _DriverService_UpdateLocation_Handler,
+		},
+		{
+			MethodName: "FindNearbyDrivers",
+			Handler:    _DriverService_FindNearbyDrivers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "taxi.proto",
+}
+
+const (
+	RideService_CreateRide_FullMethodName         = "/taxi.v1.RideService/CreateRide"
+	RideService_GetRide_FullMethodName            = "/taxi.v1.RideService/GetRide"
+	RideService_ListRides_FullMethodName          = "/taxi.v1.RideService/ListRides"
+	RideService_EditRide_FullMethodName           = "/taxi.v1.RideService/EditRide"
+	RideService_UpdateRideStatus_FullMethodName   = "/taxi.v1.RideService/UpdateRideStatus"
+	RideService_CancelRide_FullMethodName         = "/taxi.v1.RideService/CancelRide"
+	RideService_GetRideHistory_FullMethodName     = "/taxi.v1.RideService/GetRideHistory"
+	RideService_GetRideTransitions_FullMethodName = "/taxi.v1.RideService/GetRideTransitions"
+	RideService_WatchRide_FullMethodName          = "/taxi.v1.RideService/WatchRide"
+)
+
+// RideServiceClient is the client API for RideService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RideServiceClient interface {
+	// CreateRide books a ride, or schedules it when scheduled_at is set.
+	CreateRide(ctx context.Context, in *CreateRideRequest, opts ...grpc.CallOption) (*Ride, error)
+	GetRide(ctx context.Context, in *GetRideRequest, opts ...grpc.CallOption) (*Ride, error)
+	ListRides(ctx context.Context, in *ListRidesRequest, opts ...grpc.CallOption) (*ListRidesResponse, error)
+	// EditRide changes a scheduled ride before it is released for dispatch.
+	EditRide(ctx context.Context, in *EditRideRequest, opts ...grpc.CallOption) (*Ride, error)
+	UpdateRideStatus(ctx context.Context, in *UpdateRideStatusRequest, opts ...grpc.CallOption) (*Ride, error)
+	CancelRide(ctx context.Context, in *CancelRideRequest, opts ...grpc.CallOption) (*Ride, error)
+	GetRideHistory(ctx context.Context, in *GetRideHistoryRequest, opts ...grpc.CallOption) (*GetRideHistoryResponse, error)
+	GetRideTransitions(ctx context.Context, in *GetRideTransitionsRequest, opts ...grpc.CallOption) (*GetRideTransitionsResponse, error)
+	// WatchRide streams the events of a ride as they happen. A client that
+	// was cut off resumes by passing the ID of the last event it received.
+	// The stream ends with UNAVAILABLE when the server shuts down, with
+	// PERMISSION_DENIED when a watching driver loses the ride and with
+	// RESOURCE_EXHAUSTED when the client falls too far behind.
+	WatchRide(ctx context.Context, in *WatchRideRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RideEvent], error)
+}
+
+type rideServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRideServiceClient(cc grpc.ClientConnInterface) RideServiceClient {
+	return &rideServiceClient{cc}
+}
+
+func (c *rideServiceClient) CreateRide(ctx context.Context, in *CreateRideRequest, opts ...grpc.CallOption) (*Ride, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ride)
+	err := c.cc.Invoke(ctx, RideService_CreateRide_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) GetRide(ctx context.Context, in *GetRideRequest, opts ...grpc.CallOption) (*Ride, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ride)
+	err := c.cc.Invoke(ctx, RideService_GetRide_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) ListRides(ctx context.Context, in *ListRidesRequest, opts ...grpc.CallOption) (*ListRidesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRidesResponse)
+	err := c.cc.Invoke(ctx, RideService_ListRides_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) EditRide(ctx context.Context, in *EditRideRequest, opts ...grpc.CallOption) (*Ride, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ride)
+	err := c.cc.Invoke(ctx, RideService_EditRide_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) UpdateRideStatus(ctx context.Context, in *UpdateRideStatusRequest, opts ...grpc.CallOption) (*Ride, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ride)
+	err := c.cc.Invoke(ctx, RideService_UpdateRideStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) CancelRide(ctx context.Context, in *CancelRideRequest, opts ...grpc.CallOption) (*Ride, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ride)
+	err := c.cc.Invoke(ctx, RideService_CancelRide_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) GetRideHistory(ctx context.Context, in *GetRideHistoryRequest, opts ...grpc.CallOption) (*GetRideHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRideHistoryResponse)
+	err := c.cc.Invoke(ctx, RideService_GetRideHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) GetRideTransitions(ctx context.Context, in *GetRideTransitionsRequest, opts ...grpc.CallOption) (*GetRideTransitionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRideTransitionsResponse)
+	err := c.cc.Invoke(ctx, RideService_GetRideTransitions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) WatchRide(ctx context.Context, in *WatchRideRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RideEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RideService_ServiceDesc.Streams[0], RideService_WatchRide_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRideRequest, RideEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RideService_WatchRideClient = grpc.ServerStreamingClient[RideEvent]
+
+// RideServiceServer is the server API for RideService service.
+// All implementations must embed UnimplementedRideServiceServer
+// for forward compatibility.
+type RideServiceServer interface {
+	// CreateRide books a ride, or schedules it when scheduled_at is set.
+	CreateRide(context.Context, *CreateRideRequest) (*Ride, error)
+	GetRide(context.Context, *GetRideRequest) (*Ride, error)
+	ListRides(context.Context, *ListRidesRequest) (*ListRidesResponse, error)
+	// EditRide changes a scheduled ride before it is released for dispatch.
+	EditRide(context.Context, *EditRideRequest) (*Ride, error)
+	UpdateRideStatus(context.Context, *UpdateRideStatusRequest) (*Ride, error)
+	CancelRide(context.Context, *CancelRideRequest) (*Ride, error)
+	GetRideHistory(context.Context, *GetRideHistoryRequest) (*GetRideHistoryResponse, error)
+	GetRideTransitions(context.Context, *GetRideTransitionsRequest) (*GetRideTransitionsResponse, error)
+	// WatchRide streams the events of a ride as they happen. A client that
+	// was cut off resumes by passing the ID of the last event it received.
+	// The stream ends with UNAVAILABLE when the server shuts down, with
+	// PERMISSION_DENIED when a watching driver loses the ride and with
+	// RESOURCE_EXHAUSTED when the client falls too far behind.
+	WatchRide(*WatchRideRequest, grpc.ServerStreamingServer[RideEvent]) error
+	mustEmbedUnimplementedRideServiceServer()
+}
+
+// UnimplementedRideServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRideServiceServer struct{}
+
+func (UnimplementedRideServiceServer) CreateRide(context.Context, *CreateRideRequest) (*Ride, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRide not implemented")
+}
+func (UnimplementedRideServiceServer) GetRide(context.Context, *GetRideRequest) (*Ride, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRide not implemented")
+}
+func (UnimplementedRideServiceServer) ListRides(context.Context, *ListRidesRequest) (*ListRidesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRides not implemented")
+}
+func (UnimplementedRideServiceServer) EditRide(context.Context, *EditRideRequest) (*Ride, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditRide not implemented")
+}
+func (UnimplementedRideServiceServer) UpdateRideStatus(context.Context, *UpdateRideStatusRequest) (*Ride, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRideStatus not implemented")
+}
+func (UnimplementedRideServiceServer) CancelRide(context.Context, *CancelRideRequest) (*Ride, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelRide not implemented")
+}
+func (UnimplementedRideServiceServer) GetRideHistory(context.Context, *GetRideHistoryRequest) (*GetRideHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRideHistory not implemented")
+}
+func (UnimplementedRideServiceServer) GetRideTransitions(context.Context, *GetRideTransitionsRequest) (*GetRideTransitionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRideTransitions not implemented")
+}
+func (UnimplementedRideServiceServer) WatchRide(*WatchRideRequest, grpc.ServerStreamingServer[RideEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRide not implemented")
+}
+func (UnimplementedRideServiceServer) mustEmbedUnimplementedRideServiceServer() {}
+func (UnimplementedRideServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeRideServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RideServiceServer will
+// result in compilation errors.
+type UnsafeRideServiceServer interface {
+	mustEmbedUnimplementedRideServiceServer()
+}
+
+func RegisterRideServiceServer(s grpc.ServiceRegistrar, srv RideServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRideServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RideService_ServiceDesc, srv)
+}
+
+func _RideService_CreateRide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).CreateRide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_CreateRide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).CreateRide(ctx, req.(*CreateRideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_GetRide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).GetRide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_GetRide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).GetRide(ctx, req.(*GetRideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_ListRides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRidesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).ListRides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_ListRides_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).ListRides(ctx, req.(*ListRidesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_EditRide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditRideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).EditRide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_EditRide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).EditRide(ctx, req.(*EditRideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_UpdateRideStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRideStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).UpdateRideStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_UpdateRideStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).UpdateRideStatus(ctx, req.(*UpdateRideStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_CancelRide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).CancelRide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_CancelRide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).CancelRide(ctx, req.(*CancelRideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_GetRideHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRideHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).GetRideHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_GetRideHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).GetRideHistory(ctx, req.(*GetRideHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_GetRideTransitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRideTransitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).GetRideTransitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_GetRideTransitions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).GetRideTransitions(ctx, req.(*GetRideTransitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_WatchRide_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRideRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RideServiceServer).WatchRide(m, &grpc.GenericServerStream[WatchRideRequest, RideEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RideService_WatchRideServer = grpc.ServerStreamingServer[RideEvent]
+
+// RideService_ServiceDesc is the grpc.ServiceDesc for RideService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RideService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "taxi.v1.RideService",
+	HandlerType: (*RideServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRide",
+			Handler:    _RideService_CreateRide_Handler,
+		},
+		{
+			MethodName: "GetRide",
+			Handler:    _RideService_GetRide_Handler,
+		},
+		{
+			MethodName: "ListRides",
+			Handler:    _RideService_ListRides_Handler,
+		},
+		{
+			MethodName: "EditRide",
+			Handler:    _RideService_EditRide_Handler,
+		},
+		{
+			MethodName: "UpdateRideStatus",
+			Handler:    _RideService_UpdateRideStatus_Handler,
+		},
+		{
+			MethodName: "CancelRide",
+			Handler:    _RideService_CancelRide_Handler,
+		},
+		{
+			MethodName: "GetRideHistory",
+			Handler:    _RideService_GetRideHistory_Handler,
+		},
+		{
+			MethodName: "GetRideTransitions",
+			Handler:    _RideService_GetRideTransitions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRide",
+			Handler:       _RideService_WatchRide_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "taxi.proto",
+}
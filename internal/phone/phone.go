@@ -0,0 +1,115 @@
+// Package phone parses phone numbers as people write them into E.164 form,
+// e.g. "+4930123456", so that one number is always stored and compared the
+// same way.
+package phone
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	customErrors "taxiAPI/internal/errors"
+)
+
+// DefaultRegion is the region numbers without a country code are assumed
+// to be from unless configured otherwise.
+const DefaultRegion = "US"
+
+const (
+	minDigits = 8  // country code included
+	maxDigits = 15 // the most E.164 allows
+)
+
+// region holds the dialling rules of a country.
+type region struct {
+	countryCode string
+	// trunkPrefix is dialled before national numbers inside the country
+	// and dropped in international form; empty where numbers keep it.
+	trunkPrefix string
+	// exitCode is dialled inside the country before an international
+	// number.
+	exitCode string
+}
+
+// regions are the countries whose local formats Parse understands, by ISO
+// 3166-1 alpha-2 code.
+var regions = map[string]region{
+	"AT": {"43", "0", "00"},
+	"AU": {"61", "0", "0011"},
+	"BE": {"32", "0", "00"},
+	"BR": {"55", "0", "00"},
+	"CA": {"1", "1", "011"},
+	"CH": {"41", "0", "00"},
+	"DE": {"49", "0", "00"},
+	"ES": {"34", "", "00"},
+	"FR": {"33", "0", "00"},
+	"GB": {"44", "0", "00"},
+	"IE": {"353", "0", "00"},
+	"IL": {"972", "0", "00"},
+	"IN": {"91", "0", "00"},
+	"IT": {"39", "", "00"},
+	"JP": {"81", "0", "010"},
+	"MX": {"52", "", "00"},
+	"NL": {"31", "0", "00"},
+	"PL": {"48", "", "00"},
+	"PT": {"351", "", "00"},
+	"SE": {"46", "0", "00"},
+	"TR": {"90", "0", "00"},
+	"UA": {"380", "0", "00"},
+	"US": {"1", "1", "011"},
+}
+
+// Regions returns the codes of the regions Parse accepts, sorted.
+func Regions() []string {
+	codes := make([]string, 0, len(regions))
+	for code := range regions {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// ValidRegion reports whether Parse accepts code as its default region.
+func ValidRegion(code string) bool {
+	_, ok := regions[code]
+	return ok
+}
+
+// Parse returns number in E.164 form. Spaces, dashes, dots and parentheses
+// are ignored. Numbers starting with "+" or the region's exit code (such as
+// "00") are international; any other number is a national number of
+// defaultRegion, with its trunk prefix (such as "0") optional.
+func Parse(number, defaultRegion string) (string, error) {
+	r, ok := regions[defaultRegion]
+	if !ok {
+		return "", fmt.Errorf("phone: unknown region %q", defaultRegion)
+	}
+	number = strings.TrimSpace(number)
+	international := strings.HasPrefix(number, "+")
+	var digits strings.Builder
+	for _, c := range strings.TrimPrefix(number, "+") {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == ' ', c == '-', c == '.', c == '(', c == ')':
+		default:
+			return "", customErrors.ErrInvalidPhoneNumber
+		}
+	}
+
+	num := digits.String()
+	switch {
+	case international:
+	case strings.HasPrefix(num, r.exitCode):
+		num = strings.TrimPrefix(num, r.exitCode)
+	default:
+		if r.trunkPrefix != "" {
+			num = strings.TrimPrefix(num, r.trunkPrefix)
+		}
+		num = r.countryCode + num
+	}
+	if len(num) < minDigits || len(num) > maxDigits || num[0] == '0' {
+		return "", customErrors.ErrInvalidPhoneNumber
+	}
+	return "+" + num, nil
+}
@@ -0,0 +1,43 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+
+	customErrors "taxiAPI/internal/errors"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		number, region, want string
+	}{
+		{"+1 (415) 555-0100", "DE", "+14155550100"},
+		{"(415) 555-0100", "US", "+14155550100"},
+		{"1-415-555-0100", "US", "+14155550100"},
+		{"011 44 20 7946 0958", "US", "+442079460958"},
+		{"020 7946 0958", "GB", "+442079460958"},
+		{"0044 20 7946 0958", "DE", "+442079460958"},
+		{"030 123456", "DE", "+4930123456"},
+		{"052-123-4567", "IL", "+972521234567"},
+		{"06 1234 5678", "IT", "+390612345678"},
+		{"0011 1 415 555 0100", "AU", "+14155550100"},
+		{"+49 301 234", "US", "+49301234"},
+		{"+86 138 0013 8000 01", "US", "+861380013800001"},
+	} {
+		got, err := Parse(tc.number, tc.region)
+		if err != nil || got != tc.want {
+			t.Errorf("Parse(%q, %s) = %q, %v; want %q", tc.number, tc.region, got, err, tc.want)
+		}
+	}
+}
+
+func TestParseRejectsInvalidNumbers(t *testing.T) {
+	for _, number := range []string{"", "555", "+0 415 555 0100", "+1 415 555 0100 ext. 2", "+1234567890123456", "+4930123", "415-CALL-NOW"} {
+		if got, err := Parse(number, "US"); !errors.Is(err, customErrors.ErrInvalidPhoneNumber) {
+			t.Errorf("Parse(%q) = %q, %v; want ErrInvalidPhoneNumber", number, got, err)
+		}
+	}
+	if _, err := Parse("415 555 0100", "XX"); err == nil {
+		t.Error("unknown region accepted")
+	}
+}
@@ -0,0 +1,142 @@
+package pricing
+
+import (
+	"math"
+	"strings"
+	"taxiAPI/internal/entity"
+	"time"
+)
+
+// Config holds the tariff. Amounts are in minor currency units (agorot for
+// ILS) so fares never suffer from floating point rounding.
+type Config struct {
+	Currency    string
+	BaseFare    int64
+	PerKm       int64
+	PerMinute   int64
+	MinimumFare int64
+	// CarTypeMultipliers scales the fare for specific car types; the keys are
+	// matched case-insensitively and unknown car types use 1.
+	CarTypeMultipliers map[string]float64
+	// AverageSpeedKmh is used to estimate trip duration before the ride.
+	AverageSpeedKmh float64
+	Surge           SurgeConfig
+	// CancellationFee is charged when the passenger cancels more than
+	// CancellationGracePeriod after a driver accepted the ride.
+	CancellationFee         int64
+	CancellationGracePeriod time.Duration
+}
+
+// SurgeConfig controls dynamic pricing. Demand and supply are counted per
+// grid cell: rides requested within Window that are still pending against
+// available drivers currently in the cell.
+type SurgeConfig struct {
+	CellSizeDeg float64
+	Window      time.Duration
+	// TTL is how long a computed multiplier, and a quote that includes a
+	// surge, stays valid.
+	TTL time.Duration
+	// Step is added to the multiplier for every pending ride per available
+	// driver above one.
+	Step          float64
+	MaxMultiplier float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Currency:    "ILS",
+		BaseFare:    1200,
+		PerKm:       250,
+		PerMinute:   60,
+		MinimumFare: 2000,
+		CarTypeMultipliers: map[string]float64{
+			"van":     1.3,
+			"premium": 1.6,
+		},
+		AverageSpeedKmh:         30,
+		CancellationFee:         1000,
+		CancellationGracePeriod: 2 * time.Minute,
+		Surge: SurgeConfig{
+			CellSizeDeg:   0.02,
+			Window:        10 * time.Minute,
+			TTL:           2 * time.Minute,
+			Step:          0.5,
+			MaxMultiplier: 3,
+		},
+	}
+}
+
+type Engine struct {
+	config Config
+}
+
+func NewEngine(config Config) *Engine {
+	return &Engine{config: config}
+}
+
+func (e *Engine) Currency() string {
+	return e.config.Currency
+}
+
+func (e *Engine) Surge() SurgeConfig {
+	return e.config.Surge
+}
+
+// CancellationFee returns the fee for a passenger cancelling at cancelledAt
+// a ride that was accepted at acceptedAt.
+func (e *Engine) CancellationFee(acceptedAt, cancelledAt time.Time) int64 {
+	if cancelledAt.Sub(acceptedAt) <= e.config.CancellationGracePeriod {
+		return 0
+	}
+	return e.config.CancellationFee
+}
+
+// SurgeMultiplier turns the number of pending rides and available drivers
+// in a cell into a fare multiplier, rounded to one decimal. It is 1 while
+// drivers keep up with demand.
+func (e *Engine) SurgeMultiplier(pending, available int) float64 {
+	ratio := float64(pending) / math.Max(float64(available), 1)
+	if ratio <= 1 {
+		return 1
+	}
+	m := math.Min(1+(ratio-1)*e.config.Surge.Step, e.config.Surge.MaxMultiplier)
+	return math.Max(1, math.Round(m*10)/10)
+}
+
+// EstimateDuration guesses how long driving distanceKm takes.
+func (e *Engine) EstimateDuration(distanceKm float64) time.Duration {
+	if e.config.AverageSpeedKmh <= 0 {
+		return 0
+	}
+	return time.Duration(distanceKm / e.config.AverageSpeedKmh * float64(time.Hour)).Round(time.Second)
+}
+
+func (e *Engine) Multiplier(carType string) float64 {
+	if m, ok := e.config.CarTypeMultipliers[strings.ToLower(carType)]; ok && m > 0 {
+		return m
+	}
+	return 1
+}
+
+// Calculate prices a trip of the given distance and duration. surge is the
+// surge multiplier in effect; values below 1 count as no surge.
+func (e *Engine) Calculate(distanceKm float64, duration time.Duration, carType string, surge float64) entity.FareBreakdown {
+	fare := entity.FareBreakdown{
+		Currency:       e.config.Currency,
+		BaseFare:       e.config.BaseFare,
+		DistanceCharge: round(distanceKm * float64(e.config.PerKm)),
+		TimeCharge:     round(duration.Minutes() * float64(e.config.PerMinute)),
+		Multiplier:     e.Multiplier(carType),
+		Surge:          math.Max(surge, 1),
+	}
+	subtotal := fare.BaseFare + fare.DistanceCharge + fare.TimeCharge
+	fare.Total = round(float64(subtotal) * fare.Multiplier * fare.Surge)
+	if fare.Total < e.config.MinimumFare {
+		fare.Total = e.config.MinimumFare
+	}
+	return fare
+}
+
+func round(v float64) int64 {
+	return int64(math.Round(v))
+}
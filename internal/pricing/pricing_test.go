@@ -0,0 +1,83 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculate(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	for _, tc := range []struct {
+		name       string
+		distanceKm float64
+		duration   time.Duration
+		carType    string
+		surge      float64
+		want       int64
+	}{
+		// 1200 base + 10 km × 250 + 20 min × 60
+		{"standard", 10, 20 * time.Minute, "", 1, 4900},
+		{"car type is case-insensitive", 10, 20 * time.Minute, "Premium", 1, 7840},
+		{"unknown car type", 10, 20 * time.Minute, "limo", 1, 4900},
+		{"surge", 10, 20 * time.Minute, "", 1.5, 7350},
+		{"surge and car type", 10, 20 * time.Minute, "van", 2, 12740},
+		{"surge below 1 is ignored", 10, 20 * time.Minute, "", 0.5, 4900},
+		{"minimum fare", 0.5, time.Minute, "", 1, 2000},
+		{"rounded to the minor unit", 10.001, 20 * time.Minute, "", 1, 4900},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fare := e.Calculate(tc.distanceKm, tc.duration, tc.carType, tc.surge)
+			if fare.Total != tc.want {
+				t.Fatalf("Total = %d, want %d (%+v)", fare.Total, tc.want, fare)
+			}
+			if fare.Currency != "ILS" || fare.Surge < 1 {
+				t.Fatalf("fare = %+v, want ILS with a surge of at least 1", fare)
+			}
+		})
+	}
+}
+
+func TestSurgeMultiplier(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	for _, tc := range []struct {
+		pending, available int
+		want               float64
+	}{
+		{0, 0, 1},
+		{3, 5, 1},
+		{5, 5, 1},
+		{1, 0, 1},
+		{2, 0, 1.5},
+		{6, 2, 2},
+		{7, 3, 1.7},
+		{100, 1, 3},
+	} {
+		if got := e.SurgeMultiplier(tc.pending, tc.available); got != tc.want {
+			t.Errorf("SurgeMultiplier(%d pending, %d available) = %v, want %v", tc.pending, tc.available, got, tc.want)
+		}
+	}
+}
+
+func TestEstimateDuration(t *testing.T) {
+	if got := NewEngine(DefaultConfig()).EstimateDuration(15); got != 30*time.Minute {
+		t.Errorf("EstimateDuration(15 km) = %v, want 30m at 30 km/h", got)
+	}
+	if got := NewEngine(Config{}).EstimateDuration(15); got != 0 {
+		t.Errorf("EstimateDuration without an average speed = %v, want 0", got)
+	}
+}
+
+func TestCancellationFee(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	accepted := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for after, want := range map[time.Duration]int64{
+		0:                           0,
+		2 * time.Minute:             0,
+		2*time.Minute + time.Second: 1000,
+		time.Hour:                   1000,
+	} {
+		if got := e.CancellationFee(accepted, accepted.Add(after)); got != want {
+			t.Errorf("CancellationFee %v after acceptance = %d, want %d", after, got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+// The rules below decide what an authenticated caller may do. They are
+// shared by the REST and gRPC APIs; which roles may call an operation at all
+// is decided by each API's routing.
+
+// RequireSelf allows admins and the passenger or driver with the given ID.
+func RequireSelf(identity auth.Identity, role auth.Role, id int) error {
+	if identity.IsAdmin() || identity.Is(role, id) {
+		return nil
+	}
+	return customErrors.ErrForbidden
+}
+
+// CanSeeRide reports whether the caller may see the ride: admins, the
+// ride's passenger and its assigned driver.
+func CanSeeRide(identity auth.Identity, ride *entity.Ride) bool {
+	return identity.IsAdmin() || identity.Is(auth.RolePassenger, ride.PassengerID) || identity.Is(auth.RoleDriver, ride.DriverID)
+}
+
+// PassengerFor returns the passenger a ride or quote is requested for.
+// Passengers book for themselves; admins have to name the passenger.
+func PassengerFor(identity auth.Identity, requested int) (int, error) {
+	if identity.IsAdmin() {
+		return requested, nil
+	}
+	if requested != 0 && !identity.Is(auth.RolePassenger, requested) {
+		return 0, customErrors.ErrForbidden
+	}
+	return identity.ID, nil
+}
+
+// RestrictRideQuery limits passengers and drivers to their own rides.
+func RestrictRideQuery(identity auth.Identity, query *entity.RideQuery) error {
+	switch identity.Role {
+	case auth.RolePassenger:
+		if query.PassengerID != 0 && query.PassengerID != identity.ID {
+			return customErrors.ErrForbidden
+		}
+		query.PassengerID = identity.ID
+	case auth.RoleDriver:
+		if query.DriverID != 0 && query.DriverID != identity.ID {
+			return customErrors.ErrForbidden
+		}
+		query.DriverID = identity.ID
+	}
+	return nil
+}
+
+// StatusActor returns the actor a status change by the caller is recorded
+// as. Admins may name any actor. Otherwise only the assigned driver moves a
+// ride along, and cancelling goes through CancelRide so the reason codes
+// apply.
+func StatusActor(identity auth.Identity, ride *entity.Ride, status entity.Status, requested entity.Actor) (entity.Actor, error) {
+	if identity.IsAdmin() {
+		return requested, nil
+	}
+	if !identity.Is(auth.RoleDriver, ride.DriverID) || status == entity.StatusCancelled {
+		return "", customErrors.ErrForbidden
+	}
+	return entity.ActorDriver, nil
+}
+
+// CancelActor returns the actor a cancellation by the caller is recorded
+// as. Admins cancel on behalf of the requested actor, the system by
+// default; everyone else cancels as themselves.
+func CancelActor(identity auth.Identity, requested entity.Actor) entity.Actor {
+	if !identity.IsAdmin() {
+		return ActorOf(identity)
+	}
+	if requested == "" {
+		return entity.ActorSystem
+	}
+	return requested
+}
+
+// ActorOf maps a passenger or driver to the actor recorded in the ride
+// history.
+func ActorOf(identity auth.Identity) entity.Actor {
+	if identity.Role == auth.RoleDriver {
+		return entity.ActorDriver
+	}
+	return entity.ActorPassenger
+}
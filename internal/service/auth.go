@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"taxiAPI/internal/auth"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/phone"
+)
+
+// AuthService logs passengers, drivers and admins in and verifies their
+// tokens.
+type AuthService struct {
+	passengerStore    PassengerStore
+	driverStore       DriverStore
+	tokens            *auth.Tokens
+	adminPasswordHash string
+	phoneRegion       string
+}
+
+// NewAuthService creates the service. Admin login is disabled when
+// adminPasswordHash is empty. Phone numbers without a country code are read
+// as numbers of phoneRegion.
+func NewAuthService(passengerStore PassengerStore, driverStore DriverStore, tokens *auth.Tokens, adminPasswordHash, phoneRegion string) *AuthService {
+	return &AuthService{
+		passengerStore:    passengerStore,
+		driverStore:       driverStore,
+		tokens:            tokens,
+		adminPasswordHash: adminPasswordHash,
+		phoneRegion:       phoneRegion,
+	}
+}
+
+// Login checks the credentials and returns a new session. Admins log in
+// with the admin password alone.
+func (s *AuthService) Login(ctx context.Context, role auth.Role, phoneNumber, password string) (*auth.Session, error) {
+	if !role.IsValid() {
+		return nil, customErrors.ErrInvalidRole
+	}
+	if password == "" {
+		return nil, customErrors.ErrPasswordRequired
+	}
+
+	var (
+		id   int
+		hash string
+	)
+	// A number that does not parse matches no account, and fails like a
+	// wrong password.
+	phoneNumber, _ = phone.Parse(phoneNumber, s.phoneRegion)
+	switch role {
+	case auth.RoleAdmin:
+		hash = s.adminPasswordHash
+	case auth.RolePassenger:
+		if passenger, err := s.passengerStore.FindByPhoneNumber(ctx, phoneNumber); err == nil {
+			id, hash = passenger.PassengerID, passenger.PasswordHash
+		}
+	case auth.RoleDriver:
+		if driver, err := s.driverStore.FindByPhoneNumber(ctx, phoneNumber); err == nil {
+			id, hash = driver.DriverID, driver.PasswordHash
+		}
+	}
+	if !auth.CheckPassword(hash, password) {
+		return nil, customErrors.ErrInvalidCredentials
+	}
+	return s.tokens.Issue(auth.Identity{Role: role, ID: id})
+}
+
+// IssueSession hands a token to an account that was just registered.
+func (s *AuthService) IssueSession(identity auth.Identity) (*auth.Session, error) {
+	return s.tokens.Issue(identity)
+}
+
+// Authenticate resolves a token to the caller's identity. Tokens of deleted
+// passengers and drivers are rejected.
+func (s *AuthService) Authenticate(ctx context.Context, token string) (auth.Identity, error) {
+	identity, err := s.tokens.Parse(token)
+	if err != nil {
+		return auth.Identity{}, err
+	}
+	switch identity.Role {
+	case auth.RolePassenger:
+		_, err = s.passengerStore.GetPassengerByID(ctx, identity.ID)
+	case auth.RoleDriver:
+		_, err = s.driverStore.GetDriverByID(ctx, identity.ID)
+	}
+	if err != nil {
+		return auth.Identity{}, customErrors.ErrInvalidToken
+	}
+	return identity, nil
+}
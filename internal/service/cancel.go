@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"time"
+)
+
+// CancelRide cancels the ride on behalf of actor. A driver cancelling before
+// pickup only gives the ride up: it goes back to pending and is dispatched
+// again. A passenger cancelling more than the grace period after a driver
+// accepted the ride is charged a cancellation fee.
+func (s *RideService) CancelRide(ctx context.Context, rideID int, actor entity.Actor, reason entity.CancelReason, note string) (*entity.Ride, error) {
+	if rideID == 0 {
+		return nil, customErrors.ErrRideIDRequired
+	}
+	if !actor.IsValid() {
+		return nil, customErrors.ErrInvalidActor
+	}
+	if !reason.ValidFor(actor) {
+		return nil, customErrors.ErrInvalidCancelReason
+	}
+	historyReason := string(reason)
+	if note != "" {
+		historyReason += ": " + note
+	}
+
+	var change entity.StatusChange
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		ride, err := s.store.FindRideByID(ctx, rideID)
+		if err != nil {
+			return err
+		}
+		if actor == entity.ActorDriver {
+			change, err = s.requeueRide(ctx, ride, historyReason)
+			return err
+		}
+		if !ride.Status.CanTransitionTo(entity.StatusCancelled) {
+			return &customErrors.TransitionError{From: string(ride.Status), To: string(entity.StatusCancelled)}
+		}
+
+		now := time.Now().UTC()
+		change = entity.StatusChange{From: ride.Status, To: entity.StatusCancelled, Actor: actor, Reason: historyReason, At: now}
+		ride.Status = entity.StatusCancelled
+		ride.CancelledAt = &now
+		ride.CancelledBy = actor
+		ride.CancelReason = reason
+		if actor == entity.ActorPassenger && ride.AcceptedAt != nil {
+			s.pricing.chargeCancellation(ride, *ride.AcceptedAt, now)
+		}
+		if err := s.store.UpdateRide(ctx, ride); err != nil {
+			return err
+		}
+		if err := s.store.AppendRideHistory(ctx, rideID, change); err != nil {
+			return err
+		}
+		if ride.DriverID != 0 {
+			return s.releaseDriver(ctx, ride.DriverID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.events.Publish(statusEvent(rideID, change))
+	if actor == entity.ActorDriver && s.dispatcher != nil {
+		s.dispatcher.Dispatch(rideID)
+	}
+	return s.GetRide(ctx, rideID)
+}
+
+// requeueRide takes the ride away from the driver who gave it up and puts it
+// back to pending. It returns the status change it recorded.
+func (s *RideService) requeueRide(ctx context.Context, ride *entity.Ride, reason string) (entity.StatusChange, error) {
+	change := entity.StatusChange{
+		From:   ride.Status,
+		To:     entity.StatusPending,
+		Actor:  entity.ActorDriver,
+		Reason: reason,
+		At:     time.Now().UTC(),
+	}
+	if ride.DriverID == 0 {
+		return change, customErrors.ErrRideNotAssigned
+	}
+	if err := s.store.UnassignDriverFromRide(ctx, ride.RideID, ride.DriverID); err != nil {
+		return change, err
+	}
+	if err := s.store.AppendRideHistory(ctx, ride.RideID, change); err != nil {
+		return change, err
+	}
+	return change, s.releaseDriver(ctx, ride.DriverID)
+}
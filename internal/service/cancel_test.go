@@ -0,0 +1,149 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/pricing"
+)
+
+// recordingDispatcher remembers the rides it was asked to dispatch.
+type recordingDispatcher struct {
+	mutex sync.Mutex
+	rides []int
+}
+
+func (d *recordingDispatcher) Dispatch(rideID int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.rides = append(d.rides, rideID)
+}
+
+func TestCancellationFee(t *testing.T) {
+	config := pricing.DefaultConfig()
+	grace := config.CancellationGracePeriod
+
+	for _, tc := range []struct {
+		name string
+		// acceptedAgo is how long before the cancellation a driver accepted
+		// the ride; 0 leaves it pending.
+		acceptedAgo time.Duration
+		actor       entity.Actor
+		reason      entity.CancelReason
+		fee         int64
+	}{
+		{"pending", 0, entity.ActorPassenger, entity.CancelChangedPlans, 0},
+		{"within the grace period", grace / 2, entity.ActorPassenger, entity.CancelChangedPlans, 0},
+		{"after the grace period", grace + time.Minute, entity.ActorPassenger, entity.CancelChangedPlans, config.CancellationFee},
+		{"by the system", grace + time.Minute, entity.ActorSystem, entity.CancelOther, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rt := newRideTest(t)
+			ride, driver := rt.createRide(t), rt.addDriver(t)
+			if tc.acceptedAgo > 0 {
+				acceptedAt := time.Now().UTC().Add(-tc.acceptedAgo)
+				if err := rt.rideStore.AssignDriverToRide(rt.ctx, ride.RideID, driver.DriverID, acceptedAt); err != nil {
+					t.Fatalf("AssignDriverToRide: %v", err)
+				}
+			}
+
+			cancelled, err := rt.rides.CancelRide(rt.ctx, ride.RideID, tc.actor, tc.reason, "")
+			if err != nil {
+				t.Fatalf("CancelRide: %v", err)
+			}
+			if cancelled.Status != entity.StatusCancelled || cancelled.CancelledBy != tc.actor || cancelled.CancelReason != tc.reason {
+				t.Fatalf("ride = %s by %s (%s), want cancelled by %s (%s)", cancelled.Status, cancelled.CancelledBy, cancelled.CancelReason, tc.actor, tc.reason)
+			}
+			if cancelled.CancellationFee != tc.fee {
+				t.Fatalf("fee = %d, want %d", cancelled.CancellationFee, tc.fee)
+			}
+			if tc.fee > 0 && cancelled.Currency != config.Currency {
+				t.Fatalf("currency = %q, want %q", cancelled.Currency, config.Currency)
+			}
+		})
+	}
+}
+
+func TestCancelReasonValidFor(t *testing.T) {
+	for _, tc := range []struct {
+		reason entity.CancelReason
+		actor  entity.Actor
+		want   bool
+	}{
+		{entity.CancelChangedPlans, entity.ActorPassenger, true},
+		{entity.CancelDriverLate, entity.ActorPassenger, true},
+		{entity.CancelDriverLate, entity.ActorDriver, false},
+		{entity.CancelPassengerUnreachable, entity.ActorDriver, true},
+		{entity.CancelPassengerUnreachable, entity.ActorPassenger, false},
+		{entity.CancelNoDriverFound, entity.ActorSystem, true},
+		{entity.CancelNoDriverFound, entity.ActorPassenger, false},
+		{entity.CancelOther, entity.ActorPassenger, true},
+		{entity.CancelOther, entity.ActorDriver, true},
+		{entity.CancelOther, entity.ActorSystem, true},
+		{"", entity.ActorPassenger, false},
+		{"bored", entity.ActorDriver, false},
+		{entity.CancelOther, "", false},
+	} {
+		if got := tc.reason.ValidFor(tc.actor); got != tc.want {
+			t.Errorf("%q.ValidFor(%q) = %v, want %v", tc.reason, tc.actor, got, tc.want)
+		}
+	}
+
+	rt := newRideTest(t)
+	ride := rt.createRide(t)
+	if _, err := rt.rides.CancelRide(rt.ctx, ride.RideID, entity.ActorPassenger, entity.CancelVehicleProblem, ""); !errors.Is(err, customErrors.ErrInvalidCancelReason) {
+		t.Fatalf("passenger cancelling with a driver's reason: got %v, want ErrInvalidCancelReason", err)
+	}
+}
+
+func TestDriverCancelRequeuesRide(t *testing.T) {
+	rt := newRideTest(t)
+	dispatcher := &recordingDispatcher{}
+	rt.rides.SetDispatcher(dispatcher)
+	ride, driver := rt.createRide(t), rt.addDriver(t)
+
+	if _, err := rt.rides.CancelRide(rt.ctx, ride.RideID, entity.ActorDriver, entity.CancelVehicleProblem, ""); !errors.Is(err, customErrors.ErrRideNotAssigned) {
+		t.Fatalf("driver cancelling an unassigned ride: got %v, want ErrRideNotAssigned", err)
+	}
+	if err := rt.rides.AssignDriverToRide(rt.ctx, ride.RideID, driver.DriverID); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+	dispatcher.rides = nil
+
+	requeued, err := rt.rides.CancelRide(rt.ctx, ride.RideID, entity.ActorDriver, entity.CancelVehicleProblem, "flat tyre")
+	if err != nil {
+		t.Fatalf("CancelRide: %v", err)
+	}
+	if requeued.Status != entity.StatusPending || requeued.DriverID != 0 || requeued.AcceptedAt != nil || requeued.CancellationFee != 0 {
+		t.Fatalf("ride = %+v, want pending without a driver or fee", requeued)
+	}
+	if !rt.driver(t, driver.DriverID).IsAvailable {
+		t.Fatal("driver who gave the ride up is not available again")
+	}
+	if len(dispatcher.rides) != 1 || dispatcher.rides[0] != ride.RideID {
+		t.Fatalf("dispatched %v, want ride %d once", dispatcher.rides, ride.RideID)
+	}
+	history, err := rt.rides.GetRideHistory(rt.ctx, ride.RideID)
+	if err != nil {
+		t.Fatalf("GetRideHistory: %v", err)
+	}
+	last := history[len(history)-1]
+	if last.From != entity.StatusAccepted || last.To != entity.StatusPending || last.Actor != entity.ActorDriver || last.Reason != "vehicle_problem: flat tyre" {
+		t.Fatalf("last change = %+v, want accepted -> pending by the driver", last)
+	}
+
+	if err := rt.rides.AssignDriverToRide(rt.ctx, ride.RideID, driver.DriverID); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+	if err := rt.rides.UpdateRideStatus(rt.ctx, ride.RideID, entity.StatusInProgress, entity.ActorDriver, ""); err != nil {
+		t.Fatalf("UpdateRideStatus: %v", err)
+	}
+	var transitionErr *customErrors.TransitionError
+	if _, err := rt.rides.CancelRide(rt.ctx, ride.RideID, entity.ActorDriver, entity.CancelOther, ""); !errors.As(err, &transitionErr) {
+		t.Fatalf("driver cancelling after pickup: got %v, want a TransitionError", err)
+	}
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"taxiAPI/internal/entity"
+	"time"
+)
+
+type DispatchConfig struct {
+	// SearchRadiusKm limits how far from the pickup point drivers are searched.
+	SearchRadiusKm float64
+	// MaxOffers caps how many drivers a single ride is offered to before it
+	// is left pending for manual assignment.
+	MaxOffers int
+	// RatingWeightKm is how many km farther away a driver may be for every
+	// rating star they are ahead of another driver. 0 ranks by distance only.
+	RatingWeightKm float64
+}
+
+func DefaultDispatchConfig() DispatchConfig {
+	return DispatchConfig{
+		SearchRadiusKm: DefaultNearbyRadiusKm,
+		MaxOffers:      5,
+		RatingWeightKm: 0.5,
+	}
+}
+
+// Dispatcher finds a driver for every new ride. Candidates are offered the
+// ride one at a time, best first (see dispatchScore); a decline or an
+// unanswered offer moves on to the next candidate.
+type Dispatcher struct {
+	rides         *RideService
+	offers        *OfferService
+	driverStore   DriverStore
+	locationStore LocationStore
+	config        DispatchConfig
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher(rides *RideService, offers *OfferService, driverStore DriverStore, locationStore LocationStore, config DispatchConfig) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		rides:         rides,
+		offers:        offers,
+		driverStore:   driverStore,
+		locationStore: locationStore,
+		config:        config,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Dispatch starts looking for a driver for the ride in the background.
+func (d *Dispatcher) Dispatch(rideID int) {
+	if d.ctx.Err() != nil {
+		return
+	}
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.dispatch(d.ctx, rideID)
+	}()
+}
+
+// Stop abandons all dispatch rounds in progress and waits for them to exit.
+func (d *Dispatcher) Stop() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, rideID int) {
+	tried := d.formerDrivers(ctx, rideID)
+	for attempt := 0; attempt < d.config.MaxOffers; attempt++ {
+		ride, err := d.rides.store.FindRideByID(ctx, rideID)
+		if err != nil || ride.Status != entity.StatusPending || ride.DriverID != 0 {
+			return
+		}
+		candidates, err := d.candidates(ctx, ride, tried)
+		if err != nil {
+			slog.Error("dispatch failed", "ride_id", rideID, "err", err)
+			return
+		}
+		if len(candidates) == 0 {
+			return
+		}
+		best := candidates[0]
+		tried[best.Driver.DriverID] = true
+		slog.Debug("offering ride", "ride_id", rideID, "driver_id", best.Driver.DriverID, "distance_km", best.DistanceKm)
+
+		if d.offer(ctx, ride, best) {
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// formerDrivers returns the drivers who accepted the ride before, so that
+// a ride dispatched again after its driver gave it up is not offered back
+// to them.
+func (d *Dispatcher) formerDrivers(ctx context.Context, rideID int) map[int]bool {
+	drivers := make(map[int]bool)
+	offers, err := d.offers.store.ListOffersByRide(ctx, rideID)
+	if err != nil {
+		return drivers
+	}
+	for _, offer := range offers {
+		if offer.Status == entity.OfferAccepted {
+			drivers[offer.DriverID] = true
+		}
+	}
+	return drivers
+}
+
+// candidates returns the drivers the ride may be offered to, best first.
+// Drivers that are offline, on an active ride, already considering another
+// offer, driving the wrong car type or already tried are skipped.
+func (d *Dispatcher) candidates(ctx context.Context, ride *entity.Ride, tried map[int]bool) ([]*entity.NearbyDriver, error) {
+	if !ride.Origin.HasCoordinates() {
+		return nil, nil
+	}
+	locations, err := d.locationStore.FindNearby(ctx, ride.Origin, d.config.SearchRadiusKm)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-locationMaxAge)
+	candidates := make([]*entity.NearbyDriver, 0, len(locations))
+	for _, loc := range locations {
+		if tried[loc.DriverID] || loc.Timestamp.Before(cutoff) {
+			continue
+		}
+		if pending, err := d.offers.driverHasPendingOffer(ctx, loc.DriverID); err != nil || pending {
+			continue
+		}
+		driver, err := d.driverStore.GetDriverByID(ctx, loc.DriverID)
+		if err != nil || !driver.IsAvailable {
+			continue
+		}
+		if ride.CarType != "" && driver.CarType != ride.CarType {
+			continue
+		}
+		if active, err := d.rides.store.FindActiveRideByDriver(ctx, driver.DriverID); err == nil && active != nil {
+			continue
+		}
+		candidates = append(candidates, &entity.NearbyDriver{
+			Driver:     driver,
+			Location:   loc,
+			DistanceKm: ride.Origin.DistanceKm(loc.Point()),
+		})
+	}
+	slices.SortStableFunc(candidates, func(a, b *entity.NearbyDriver) int {
+		return compareScore(d.dispatchScore(a), d.dispatchScore(b))
+	})
+	return candidates, nil
+}
+
+// unratedDriverRating stands in for the rating of drivers nobody rated yet,
+// so new drivers are neither favoured nor buried.
+const unratedDriverRating = 4.5
+
+// dispatchScore ranks a candidate by distance, with every missing rating
+// star counting as RatingWeightKm of extra distance; lower is better.
+func (d *Dispatcher) dispatchScore(c *entity.NearbyDriver) float64 {
+	rating := c.Driver.Rating
+	if c.Driver.RatingCount == 0 {
+		rating = unratedDriverRating
+	}
+	return c.DistanceKm + (entity.MaxRatingScore-rating)*d.config.RatingWeightKm
+}
+
+func compareScore(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// offer proposes the ride to one candidate and blocks until the driver
+// answers, the offer expires or ctx is cancelled. It reports whether the
+// driver accepted and was assigned.
+func (d *Dispatcher) offer(ctx context.Context, ride *entity.Ride, candidate *entity.NearbyDriver) bool {
+	done := make(chan entity.OfferStatus, 1)
+	offer, err := d.offers.createOffer(ctx, ride, candidate.Driver.DriverID, candidate.DistanceKm, done)
+	if err != nil {
+		return false
+	}
+
+	timer := time.NewTimer(time.Until(offer.ExpiresAt))
+	defer timer.Stop()
+
+	select {
+	case status := <-done:
+		return status == entity.OfferAccepted
+	case <-timer.C:
+		if d.offers.expire(context.Background(), offer.OfferID) {
+			return false
+		}
+	case <-ctx.Done():
+		if d.offers.close(context.Background(), offer.OfferID, entity.OfferCancelled) {
+			return false
+		}
+	}
+	// The driver answered while the offer was timing out; their answer wins.
+	return <-done == entity.OfferAccepted
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"taxiAPI/internal/entity"
+)
+
+// fixedLocations is a location store whose nearby search always returns
+// the same drivers, so that tests pick distances instead of relying on the
+// spatial index.
+type fixedLocations struct {
+	LocationStore
+	nearby []*entity.DriverLocation
+}
+
+func (l *fixedLocations) FindNearby(context.Context, entity.Location, float64) ([]*entity.DriverLocation, error) {
+	nearby := make([]*entity.DriverLocation, 0, len(l.nearby))
+	for _, loc := range l.nearby {
+		copied := *loc
+		nearby = append(nearby, &copied)
+	}
+	return nearby, nil
+}
+
+// at places driver kmNorth of the pickup point used by createRide.
+func (l *fixedLocations) at(driver *entity.Driver, kmNorth float64, timestamp time.Time) {
+	l.nearby = append(l.nearby, &entity.DriverLocation{
+		DriverID:  driver.DriverID,
+		Latitude:  52.52 + kmNorth/111.2,
+		Longitude: 13.405,
+		Timestamp: timestamp,
+	})
+}
+
+func (rt *rideTest) newDispatcher(locations *fixedLocations, offerTimeout time.Duration) (*Dispatcher, *OfferService) {
+	offers := NewOfferService(rt.offerStore, rt.rides, offerTimeout)
+	config := DefaultDispatchConfig()
+	config.RatingWeightKm = 0
+	return NewDispatcher(rt.rides, offers, rt.drivers, locations, config), offers
+}
+
+func TestDispatcherCandidates(t *testing.T) {
+	rt := newRideTest(t)
+	locations := &fixedLocations{}
+	d, offers := rt.newDispatcher(locations, DefaultOfferTimeout)
+	ride, other, another := rt.createRide(t), rt.createRide(t), rt.createRide(t)
+	now := time.Now()
+
+	far, near := rt.addDriver(t), rt.addDriver(t)
+	locations.at(far, 2, now)
+	locations.at(near, 0.5, now)
+
+	busy := rt.addDriver(t)
+	locations.at(busy, 0.1, now)
+	if err := rt.rides.AssignDriverToRide(rt.ctx, other.RideID, busy.DriverID); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+	offered := rt.addDriver(t)
+	locations.at(offered, 0.1, now)
+	if _, err := offers.OfferRide(rt.ctx, another.RideID, offered.DriverID); err != nil {
+		t.Fatalf("OfferRide: %v", err)
+	}
+	offline := rt.addDriver(t)
+	locations.at(offline, 0.1, now)
+	if err := rt.drivers.UpdateDriverAvailability(rt.ctx, offline.DriverID, false, false); err != nil {
+		t.Fatalf("UpdateDriverAvailability: %v", err)
+	}
+	stale := rt.addDriver(t)
+	locations.at(stale, 0.1, now.Add(-2*locationMaxAge))
+	tried := rt.addDriver(t)
+	locations.at(tried, 0.1, now)
+
+	candidates, err := d.candidates(rt.ctx, rt.ride(t, ride.RideID), map[int]bool{tried.DriverID: true})
+	if err != nil {
+		t.Fatalf("candidates: %v", err)
+	}
+	var got []int
+	for _, c := range candidates {
+		got = append(got, c.Driver.DriverID)
+	}
+	if len(got) != 2 || got[0] != near.DriverID || got[1] != far.DriverID {
+		t.Fatalf("candidates = %v, want the near driver %d then the far driver %d", got, near.DriverID, far.DriverID)
+	}
+}
+
+func TestDispatcherSkipsFormerDrivers(t *testing.T) {
+	rt := newRideTest(t)
+	locations := &fixedLocations{}
+	d, offers := rt.newDispatcher(locations, DefaultOfferTimeout)
+	ride, former, other := rt.createRide(t), rt.addDriver(t), rt.addDriver(t)
+	locations.at(former, 0.5, time.Now())
+	locations.at(other, 2, time.Now())
+
+	offer, err := offers.OfferRide(rt.ctx, ride.RideID, former.DriverID)
+	if err != nil {
+		t.Fatalf("OfferRide: %v", err)
+	}
+	if _, err := offers.AcceptOffer(rt.ctx, former.DriverID, offer.OfferID); err != nil {
+		t.Fatalf("AcceptOffer: %v", err)
+	}
+	if _, err := rt.rides.CancelRide(rt.ctx, ride.RideID, entity.ActorDriver, entity.CancelVehicleProblem, ""); err != nil {
+		t.Fatalf("CancelRide: %v", err)
+	}
+
+	candidates, err := d.candidates(rt.ctx, rt.ride(t, ride.RideID), d.formerDrivers(rt.ctx, ride.RideID))
+	if err != nil {
+		t.Fatalf("candidates: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].Driver.DriverID != other.DriverID {
+		t.Fatalf("candidates = %v, want only driver %d", candidates, other.DriverID)
+	}
+}
+
+// pendingOffer waits for the driver to be offered a ride.
+func pendingOffer(t *testing.T, rt *rideTest, offers *OfferService, driverID int) *entity.Offer {
+	t.Helper()
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		pending, err := offers.GetDriverOffers(rt.ctx, driverID, entity.OfferPending)
+		if err != nil {
+			t.Fatalf("GetDriverOffers: %v", err)
+		}
+		if len(pending) > 0 {
+			return pending[0]
+		}
+	}
+	t.Fatalf("driver %d was never offered a ride", driverID)
+	return nil
+}
+
+func TestDispatcherMovesOnAfterDecline(t *testing.T) {
+	rt := newRideTest(t)
+	locations := &fixedLocations{}
+	d, offers := rt.newDispatcher(locations, DefaultOfferTimeout)
+	defer d.Stop()
+	ride, near, far := rt.createRide(t), rt.addDriver(t), rt.addDriver(t)
+	locations.at(near, 0.5, time.Now())
+	locations.at(far, 2, time.Now())
+
+	d.Dispatch(ride.RideID)
+	first := pendingOffer(t, rt, offers, near.DriverID)
+	if first.RideID != ride.RideID {
+		t.Fatalf("near driver offered ride %d, want %d", first.RideID, ride.RideID)
+	}
+	if _, err := offers.DeclineOffer(rt.ctx, near.DriverID, first.OfferID); err != nil {
+		t.Fatalf("DeclineOffer: %v", err)
+	}
+	second := pendingOffer(t, rt, offers, far.DriverID)
+	if _, err := offers.AcceptOffer(rt.ctx, far.DriverID, second.OfferID); err != nil {
+		t.Fatalf("AcceptOffer: %v", err)
+	}
+	d.Stop()
+
+	if got := rt.ride(t, ride.RideID); got.DriverID != far.DriverID || got.Status != entity.StatusAccepted {
+		t.Fatalf("ride = driver %d, %s; want driver %d, accepted", got.DriverID, got.Status, far.DriverID)
+	}
+	all, err := offers.GetRideOffers(rt.ctx, ride.RideID)
+	if err != nil {
+		t.Fatalf("GetRideOffers: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("ride was offered %d times, want 2", len(all))
+	}
+}
@@ -2,50 +2,96 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"taxiAPI/internal/auth"
 	"taxiAPI/internal/entity"
 	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/validation"
+	"time"
 )
 
 type DriverStore interface {
+	// RegisterDriver returns ErrPhoneNumberExists, and stores nothing, if
+	// another driver has the same phone number.
 	RegisterDriver(ctx context.Context, d *entity.Driver) (*entity.Driver, error)
 	GetDriverByID(ctx context.Context, id int) (*entity.Driver, error)
-	GetAllDrivers(ctx context.Context) ([]*entity.Driver, error)
+	ListDrivers(ctx context.Context, query entity.DriverQuery) ([]*entity.Driver, int, error)
 	DeleteDriver(ctx context.Context, id int) error
-	FindByPhoneNumber(ctx context.Context, phone int) (*entity.Driver, error)
+	FindByPhoneNumber(ctx context.Context, phone string) (*entity.Driver, error)
+	UpdateDriverAvailability(ctx context.Context, id int, online, available bool) error
+	AddDriverRating(ctx context.Context, id int, score int) error
 }
 
+type LocationStore interface {
+	UpdateLocation(ctx context.Context, loc *entity.DriverLocation) (*entity.DriverLocation, error)
+	GetLocation(ctx context.Context, driverID int) (*entity.DriverLocation, error)
+	DeleteLocation(ctx context.Context, driverID int) error
+	FindNearby(ctx context.Context, center entity.Location, radiusKm float64) ([]*entity.DriverLocation, error)
+}
+
+const (
+	DefaultNearbyRadiusKm = 5.0
+	MaxNearbyRadiusKm     = 50.0
+	// locationMaxAge is how long a position report counts as live; drivers
+	// that stopped reporting are left out of nearby searches.
+	locationMaxAge = 2 * time.Minute
+	// maxClockSkew is how far ahead of the server's clock a driver's
+	// position report may be timestamped.
+	maxClockSkew = 30 * time.Second
+)
+
 type DriverService struct {
-	store DriverStore
+	store         DriverStore
+	rideStore     RideStore
+	locationStore LocationStore
+	tx            Transactor
+	events        *EventBus
+	phoneRegion   string
 }
 
-func NewDriverService(store DriverStore) *DriverService {
+// NewDriverService creates the service. Phone numbers without a country
+// code are read as numbers of phoneRegion.
+func NewDriverService(store DriverStore, rideStore RideStore, locationStore LocationStore, tx Transactor, phoneRegion string) *DriverService {
 	return &DriverService{
-		store: store,
+		store:         store,
+		rideStore:     rideStore,
+		locationStore: locationStore,
+		tx:            tx,
+		phoneRegion:   phoneRegion,
 	}
 }
-func (s *DriverService) RegisterDriver(ctx context.Context, d *entity.Driver) (*entity.Driver, error) {
+
+// SetEventBus makes the service publish the position of drivers on a ride
+// to that ride's subscribers.
+func (s *DriverService) SetEventBus(bus *EventBus) {
+	s.events = bus
+}
+
+func (s *DriverService) RegisterDriver(ctx context.Context, d *entity.Driver, password string) (*entity.Driver, error) {
 	if d == nil {
 		return nil, customErrors.ErrDriverDataRequired
 	}
-	if d.FirstName == "" {
-		return nil, customErrors.ErrFirstName
-	}
-	if d.LastName == "" {
-		return nil, customErrors.ErrLastName
-	}
-	if d.CarType == "" {
-		return nil, customErrors.ErrCarTypeRequired
-	}
-	if d.LicensePlate == 0 {
-		return nil, customErrors.ErrLicensePlateRequired
-	}
-	if d.PhoneNumber == 0 {
-		return nil, customErrors.ErrPhoneNumber
+	d.FirstName, d.LastName = strings.TrimSpace(d.FirstName), strings.TrimSpace(d.LastName)
+	d.CarType = strings.TrimSpace(d.CarType)
+	v := validation.FromContext(ctx)
+	v.Name("first_name", d.FirstName, customErrors.ErrFirstName)
+	v.Name("last_name", d.LastName, customErrors.ErrLastName)
+	d.PhoneNumber = v.PhoneNumber("phone_number", d.PhoneNumber, s.phoneRegion)
+	v.Check(d.CarType != "", "car_type", customErrors.ErrCarTypeRequired)
+	v.LicensePlate("license_plate", d.LicensePlate)
+	v.Password("password", password)
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
-	existing, _ := s.store.FindByPhoneNumber(ctx, d.PhoneNumber)
-	if existing != nil {
-		return nil, customErrors.ErrPhoneNumberExists
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
 	}
+	d.PasswordHash = hash
+	d.IsOnline = true
+	d.IsAvailable = true
+	d.Rating, d.RatingCount = 0, 0
 	registeredDriver, err := s.store.RegisterDriver(ctx, d)
 	if err != nil {
 		return nil, err
@@ -64,17 +110,111 @@ func (s *DriverService) GetDriverByID(ctx context.Context, id int) (*entity.Driv
 	return driver, nil
 }
 
-func (s *DriverService) GetAllDrivers(ctx context.Context) ([]*entity.Driver, error) {
-	drivers, err := s.store.GetAllDrivers(ctx)
-	if err != nil {
-		return nil, err
-	}
-	return drivers, nil
+func (s *DriverService) ListDrivers(ctx context.Context, query entity.DriverQuery) ([]*entity.Driver, int, error) {
+	query.Page = normalizePage(query.Page)
+	return s.store.ListDrivers(ctx, query)
 }
 
 func (s *DriverService) DeleteDriver(ctx context.Context, id int) error {
 	if id == 0 {
 		return customErrors.ErrDriverNotFound
 	}
-	return s.store.DeleteDriver(ctx, id)
+	if err := s.store.DeleteDriver(ctx, id); err != nil {
+		return err
+	}
+	if err := s.locationStore.DeleteLocation(ctx, id); err != nil && !errors.Is(err, customErrors.ErrLocationNotFound) {
+		return err
+	}
+	return nil
+}
+
+// SetOnline toggles whether the driver wants to receive rides. A driver who
+// goes online while still on a ride only becomes available once it ends.
+func (s *DriverService) SetOnline(ctx context.Context, id int, online bool) (*entity.Driver, error) {
+	if id == 0 {
+		return nil, customErrors.ErrDriverNotFound
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.store.GetDriverByID(ctx, id); err != nil {
+			return err
+		}
+		available := online
+		if active, err := s.rideStore.FindActiveRideByDriver(ctx, id); err == nil && active != nil {
+			available = false
+		}
+		return s.store.UpdateDriverAvailability(ctx, id, online, available)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.store.GetDriverByID(ctx, id)
+}
+
+func (s *DriverService) UpdateLocation(ctx context.Context, loc *entity.DriverLocation) (*entity.DriverLocation, error) {
+	if err := validateLocation("", loc.Point()); err != nil {
+		return nil, err
+	}
+	if loc.Heading < 0 || loc.Heading >= 360 {
+		return nil, customErrors.ErrInvalidHeading
+	}
+	if _, err := s.GetDriverByID(ctx, loc.DriverID); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if loc.Timestamp.IsZero() {
+		loc.Timestamp = now
+	}
+	// A report from the future would outrank every real one after it.
+	if loc.Timestamp.After(now.Add(maxClockSkew)) {
+		return nil, customErrors.ErrTimestampInFuture
+	}
+	stored, err := s.locationStore.UpdateLocation(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	// Stale reports are ignored by the store and not worth publishing.
+	if s.events != nil && stored.Timestamp.Equal(loc.Timestamp) {
+		if ride, err := s.rideStore.FindActiveRideByDriver(ctx, loc.DriverID); err == nil && ride != nil {
+			position := *stored
+			s.events.Publish(entity.RideEvent{RideID: ride.RideID, Type: entity.EventDriverLocation, At: stored.Timestamp, Location: &position})
+		}
+	}
+	return stored, nil
+}
+
+func (s *DriverService) FindNearbyDrivers(ctx context.Context, center entity.Location, radiusKm float64) ([]*entity.NearbyDriver, error) {
+	if err := validateLocation("", center); err != nil {
+		return nil, err
+	}
+	if radiusKm == 0 {
+		radiusKm = DefaultNearbyRadiusKm
+	}
+	if radiusKm < 0 || radiusKm > MaxNearbyRadiusKm {
+		return nil, customErrors.ErrInvalidRadius
+	}
+	locations, err := s.locationStore.FindNearby(ctx, center, radiusKm)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-locationMaxAge)
+	nearby := make([]*entity.NearbyDriver, 0, len(locations))
+	for _, loc := range locations {
+		if loc.Timestamp.Before(cutoff) {
+			continue
+		}
+		driver, err := s.store.GetDriverByID(ctx, loc.DriverID)
+		if err != nil || !driver.IsAvailable {
+			continue
+		}
+		// Whoever searches is not the driver's passenger yet.
+		public := *driver
+		public.PhoneNumber = ""
+		nearby = append(nearby, &entity.NearbyDriver{
+			Driver:     &public,
+			Location:   loc,
+			DistanceKm: center.DistanceKm(loc.Point()),
+		})
+	}
+	return nearby, nil
 }
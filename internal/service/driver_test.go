@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/storage"
+)
+
+func TestUpdateLocationRejectsFutureTimestamps(t *testing.T) {
+	rt := newRideTest(t)
+	drivers := NewDriverService(rt.drivers, rt.rideStore, rt.locations, storage.NewTransactor(), "US")
+	driver := rt.addDriver(t)
+	now := time.Now().UTC()
+
+	report := func(timestamp time.Time) error {
+		_, err := drivers.UpdateLocation(rt.ctx, &entity.DriverLocation{DriverID: driver.DriverID, Latitude: 52.52, Longitude: 13.405, Timestamp: timestamp})
+		return err
+	}
+	if err := report(now.Add(time.Hour)); !errors.Is(err, customErrors.ErrTimestampInFuture) {
+		t.Fatalf("report an hour ahead: got %v, want ErrTimestampInFuture", err)
+	}
+	if err := report(now.Add(maxClockSkew / 2)); err != nil {
+		t.Fatalf("report within the allowed skew: %v", err)
+	}
+	// A later real report still replaces the slightly early one.
+	if err := report(now.Add(maxClockSkew)); err != nil {
+		t.Fatalf("later report: %v", err)
+	}
+	loc, err := rt.locations.GetLocation(rt.ctx, driver.DriverID)
+	if err != nil {
+		t.Fatalf("GetLocation: %v", err)
+	}
+	if !loc.Timestamp.Equal(now.Add(maxClockSkew)) {
+		t.Fatalf("stored timestamp = %v, want %v", loc.Timestamp, now.Add(maxClockSkew))
+	}
+}
+
+func TestFindNearbyDriversAcrossAntimeridianAndPole(t *testing.T) {
+	rt := newRideTest(t)
+	drivers := NewDriverService(rt.drivers, rt.rideStore, rt.locations, storage.NewTransactor(), "US")
+	for _, tc := range []struct {
+		name           string
+		center, driver entity.Location
+	}{
+		{"antimeridian", entity.Location{Latitude: -17.7, Longitude: 179.99}, entity.Location{Latitude: -17.7, Longitude: -179.99}},
+		{"pole", entity.Location{Latitude: 89.99, Longitude: 0}, entity.Location{Latitude: 89.99, Longitude: 180}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			driver := rt.addDriver(t)
+			if _, err := drivers.UpdateLocation(rt.ctx, &entity.DriverLocation{DriverID: driver.DriverID, Latitude: tc.driver.Latitude, Longitude: tc.driver.Longitude}); err != nil {
+				t.Fatalf("UpdateLocation: %v", err)
+			}
+			nearby, err := drivers.FindNearbyDrivers(rt.ctx, tc.center, 5)
+			if err != nil {
+				t.Fatalf("FindNearbyDrivers: %v", err)
+			}
+			if len(nearby) != 1 || nearby[0].Driver.DriverID != driver.DriverID {
+				t.Fatalf("nearby = %v, want driver %d %.1f km away", nearby, driver.DriverID, tc.center.DistanceKm(tc.driver))
+			}
+			if nearby[0].Driver.PhoneNumber != "" || rt.driver(t, driver.DriverID).PhoneNumber == "" {
+				t.Fatalf("nearby driver %+v, want the phone number left out of the result only", nearby[0].Driver)
+			}
+		})
+	}
+}
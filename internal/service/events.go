@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"time"
+)
+
+const (
+	// DefaultEventBacklog is how many recent events are kept so subscribers
+	// that reconnect can catch up on what they missed.
+	DefaultEventBacklog = 1024
+	// subscriberBuffer is how many events a subscriber may fall behind
+	// before it is dropped.
+	subscriberBuffer = 64
+)
+
+// EventBus fans ride events out to the subscribers of each ride. Events are
+// only published after the change they describe has been committed.
+type EventBus struct {
+	mutex       sync.Mutex
+	lastID      uint64
+	backlog     []entity.RideEvent
+	maxBacklog  int
+	subscribers map[int]map[*Subscription]struct{}
+	listeners   []func(entity.RideEvent)
+	closed      bool
+}
+
+func NewEventBus(backlog int) *EventBus {
+	return &EventBus{
+		// IDs continue from the clock so a client reconnecting after a
+		// restart does not skip events whose IDs were reused.
+		lastID:      uint64(time.Now().UnixMicro()),
+		maxBacklog:  backlog,
+		subscribers: make(map[int]map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the events of one ride until it is closed. C is
+// closed when the subscriber falls too far behind; it should reconnect with
+// the ID of the last event it handled. C is also closed when the subscriber
+// may no longer see the ride (see Revoked).
+type Subscription struct {
+	// Missed holds the backlogged events published after the ID passed to
+	// Subscribe, oldest first.
+	Missed []entity.RideEvent
+	C      <-chan entity.RideEvent
+
+	bus     *EventBus
+	viewer  auth.Identity
+	rideID  int
+	events  chan entity.RideEvent
+	closed  bool
+	revoked bool
+}
+
+// Subscribe starts listening to the ride on behalf of viewer, who should
+// have been checked with CanSeeRide. A driver's subscription is revoked
+// when the ride goes to another driver or back to pending. When lastEventID
+// is not 0 the backlogged events after it are returned in Missed. After
+// Close the subscription's channel is closed right away.
+func (b *EventBus) Subscribe(viewer auth.Identity, rideID int, lastEventID uint64) *Subscription {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	events := make(chan entity.RideEvent, subscriberBuffer)
+	sub := &Subscription{C: events, bus: b, viewer: viewer, rideID: rideID, events: events}
+	if b.closed {
+		sub.closed = true
+		close(events)
+		return sub
+	}
+	if lastEventID != 0 {
+		for _, event := range b.backlog {
+			if event.RideID == rideID && event.ID > lastEventID {
+				sub.Missed = append(sub.Missed, event)
+			}
+		}
+	}
+	if b.subscribers[rideID] == nil {
+		b.subscribers[rideID] = make(map[*Subscription]struct{})
+	}
+	b.subscribers[rideID][sub] = struct{}{}
+	return sub
+}
+
+// Close stops the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.bus.mutex.Lock()
+	defer s.bus.mutex.Unlock()
+	s.bus.drop(s)
+}
+
+// Revoked reports whether C was closed because the ride changed hands and
+// the subscriber may no longer see it.
+func (s *Subscription) Revoked() bool {
+	s.bus.mutex.Lock()
+	defer s.bus.mutex.Unlock()
+	return s.revoked
+}
+
+// allowed reports whether the subscriber may still see the ride once event
+// has happened. Only drivers lose access, when the ride is assigned to
+// someone else or given back to pending.
+func (s *Subscription) allowed(event entity.RideEvent) bool {
+	if s.viewer.Role != auth.RoleDriver {
+		return true
+	}
+	switch event.Type {
+	case entity.EventRideAssigned:
+		return event.DriverID == s.viewer.ID
+	case entity.EventRideStatusChanged:
+		return event.Change == nil || event.Change.To != entity.StatusPending
+	}
+	return true
+}
+
+// BusClosed reports whether C was closed because the bus shut down rather
+// than because the subscriber fell behind.
+func (s *Subscription) BusClosed() bool {
+	s.bus.mutex.Lock()
+	defer s.bus.mutex.Unlock()
+	return s.bus.closed
+}
+
+// Close ends every subscription so that open event streams finish, e.g.
+// when the server shuts down. Events published afterwards still reach the
+// listeners.
+func (b *EventBus) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.closed = true
+	for _, subs := range b.subscribers {
+		for sub := range subs {
+			b.drop(sub)
+		}
+	}
+}
+
+// Listen registers fn to be called with every event of every ride. fn runs
+// on the publisher's goroutine and must not block.
+func (b *EventBus) Listen(fn func(entity.RideEvent)) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.listeners = append(b.listeners, fn)
+}
+
+// Publish assigns the event an ID and delivers it to the ride's
+// subscribers and to every listener. Publishing on a nil bus does nothing.
+func (b *EventBus) Publish(event entity.RideEvent) {
+	if b == nil {
+		return
+	}
+	event, listeners := b.publish(event)
+	for _, fn := range listeners {
+		fn(event)
+	}
+}
+
+func (b *EventBus) publish(event entity.RideEvent) (entity.RideEvent, []func(entity.RideEvent)) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	if event.At.IsZero() {
+		event.At = time.Now().UTC()
+	}
+	b.backlog = append(b.backlog, event)
+	if len(b.backlog) > b.maxBacklog {
+		b.backlog = append(b.backlog[:0], b.backlog[len(b.backlog)-b.maxBacklog:]...)
+	}
+
+	for sub := range b.subscribers[event.RideID] {
+		if !sub.allowed(event) {
+			sub.revoked = true
+			b.drop(sub)
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// Never block publishers on a slow client.
+			b.drop(sub)
+		}
+	}
+	return event, b.listeners
+}
+
+// drop unregisters the subscription and closes its channel. The caller
+// holds the mutex.
+func (b *EventBus) drop(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.events)
+	delete(b.subscribers[sub.rideID], sub)
+	if len(b.subscribers[sub.rideID]) == 0 {
+		delete(b.subscribers, sub.rideID)
+	}
+}
+
+// SubscribeRide subscribes viewer to the ride's events if they may see it
+// (see CanSeeRide). The ride is checked again once the subscription is in
+// place, so a change of driver in between cannot slip past; later changes
+// revoke the subscription. The service needs an event bus (SetEventBus).
+func (s *RideService) SubscribeRide(ctx context.Context, viewer auth.Identity, rideID int, lastEventID uint64) (*Subscription, error) {
+	if err := s.authorizeViewer(ctx, viewer, rideID); err != nil {
+		return nil, err
+	}
+	sub := s.events.Subscribe(viewer, rideID, lastEventID)
+	if err := s.authorizeViewer(ctx, viewer, rideID); err != nil {
+		sub.Close()
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *RideService) authorizeViewer(ctx context.Context, viewer auth.Identity, rideID int) error {
+	ride, err := s.GetRide(ctx, rideID)
+	if err != nil {
+		return err
+	}
+	if !CanSeeRide(viewer, ride) {
+		return customErrors.ErrForbidden
+	}
+	return nil
+}
+
+// statusEvent describes a committed status change of the ride.
+func statusEvent(rideID int, change entity.StatusChange) entity.RideEvent {
+	return entity.RideEvent{RideID: rideID, Type: entity.EventRideStatusChanged, At: change.At, Change: &change}
+}
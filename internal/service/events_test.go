@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+var adminViewer = auth.Identity{Role: auth.RoleAdmin}
+
+func publishN(bus *EventBus, rideID, n int) {
+	for range n {
+		bus.Publish(entity.RideEvent{RideID: rideID, Type: entity.EventRideUpdated})
+	}
+}
+
+func eventIDs(events []entity.RideEvent) []uint64 {
+	ids := make([]uint64, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	return ids
+}
+
+func TestEventBusResumesAfterLastEventID(t *testing.T) {
+	bus := NewEventBus(DefaultEventBacklog)
+	first := bus.Subscribe(adminViewer, 1, 0)
+	defer first.Close()
+	publishN(bus, 1, 2)
+	publishN(bus, 2, 1)
+	publishN(bus, 1, 1)
+
+	var received []entity.RideEvent
+	for range 3 {
+		received = append(received, <-first.C)
+	}
+	if len(first.Missed) != 0 {
+		t.Fatalf("Missed = %v without a last event ID", first.Missed)
+	}
+
+	resumed := bus.Subscribe(adminViewer, 1, received[0].ID)
+	defer resumed.Close()
+	if got, want := eventIDs(resumed.Missed), eventIDs(received[1:]); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("Missed = %v, want %v", got, want)
+	}
+	if resumed.Missed[0].ID <= received[0].ID {
+		t.Fatal("event IDs do not increase")
+	}
+}
+
+func TestEventBusEvictsOldestBacklog(t *testing.T) {
+	bus := NewEventBus(3)
+	sub := bus.Subscribe(adminViewer, 1, 0)
+	defer sub.Close()
+	publishN(bus, 1, 5)
+	var received []entity.RideEvent
+	for range 5 {
+		received = append(received, <-sub.C)
+	}
+
+	resumed := bus.Subscribe(adminViewer, 1, received[0].ID)
+	defer resumed.Close()
+	if got := eventIDs(resumed.Missed); len(got) != 3 || got[0] != received[2].ID || got[2] != received[4].ID {
+		t.Fatalf("Missed = %v, want the last 3 of %v", got, eventIDs(received))
+	}
+}
+
+func TestEventBusDropsSlowSubscriber(t *testing.T) {
+	bus := NewEventBus(DefaultEventBacklog)
+	slow := bus.Subscribe(adminViewer, 1, 0)
+	fast := bus.Subscribe(adminViewer, 1, 0)
+	defer fast.Close()
+
+	for range subscriberBuffer + 1 {
+		publishN(bus, 1, 1)
+		<-fast.C
+	}
+	n := 0
+	for range slow.C {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Fatalf("slow subscriber got %d events before being dropped, want %d", n, subscriberBuffer)
+	}
+	if slow.BusClosed() || slow.Revoked() {
+		t.Fatal("a dropped subscriber was reported as shut down or revoked")
+	}
+
+	publishN(bus, 1, 1)
+	if _, ok := <-fast.C; !ok {
+		t.Fatal("the subscriber that kept up was dropped too")
+	}
+	bus.Close()
+	if _, ok := <-fast.C; ok || !fast.BusClosed() {
+		t.Fatal("Close did not end the subscription")
+	}
+}
+
+func TestSubscribeRideRefusesOutsiders(t *testing.T) {
+	rt := newRideTest(t)
+	rt.rides.SetEventBus(NewEventBus(DefaultEventBacklog))
+	ride, assigned, other := rt.createRide(t), rt.addDriver(t), rt.addDriver(t)
+	if err := rt.rides.AssignDriverToRide(rt.ctx, ride.RideID, assigned.DriverID); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		viewer auth.Identity
+		err    error
+	}{
+		{"passenger", auth.Identity{Role: auth.RolePassenger, ID: rt.passenger.PassengerID}, nil},
+		{"assigned driver", auth.Identity{Role: auth.RoleDriver, ID: assigned.DriverID}, nil},
+		{"adminViewer", adminViewer, nil},
+		{"another passenger", auth.Identity{Role: auth.RolePassenger, ID: rt.passenger.PassengerID + 1}, customErrors.ErrForbidden},
+		{"another driver", auth.Identity{Role: auth.RoleDriver, ID: other.DriverID}, customErrors.ErrForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sub, err := rt.rides.SubscribeRide(rt.ctx, tc.viewer, ride.RideID, 0)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("SubscribeRide = %v, want %v", err, tc.err)
+			}
+			if sub != nil {
+				sub.Close()
+			}
+		})
+	}
+	if _, err := rt.rides.SubscribeRide(rt.ctx, adminViewer, ride.RideID+1, 0); !errors.Is(err, customErrors.ErrRideNotFound) {
+		t.Fatalf("subscribing to a missing ride: got %v, want ErrRideNotFound", err)
+	}
+}
+
+func TestSubscriptionRevokedWhenDriverChanges(t *testing.T) {
+	rt := newRideTest(t)
+	rt.rides.SetEventBus(NewEventBus(DefaultEventBacklog))
+	ride, first, second := rt.createRide(t), rt.addDriver(t), rt.addDriver(t)
+	if err := rt.rides.AssignDriverToRide(rt.ctx, ride.RideID, first.DriverID); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+	subscribe := func(viewer auth.Identity) *Subscription {
+		t.Helper()
+		sub, err := rt.rides.SubscribeRide(rt.ctx, viewer, ride.RideID, 0)
+		if err != nil {
+			t.Fatalf("SubscribeRide: %v", err)
+		}
+		t.Cleanup(sub.Close)
+		return sub
+	}
+	passenger := subscribe(auth.Identity{Role: auth.RolePassenger, ID: rt.passenger.PassengerID})
+	firstDriver := subscribe(auth.Identity{Role: auth.RoleDriver, ID: first.DriverID})
+
+	if _, err := rt.rides.CancelRide(rt.ctx, ride.RideID, entity.ActorDriver, entity.CancelVehicleProblem, ""); err != nil {
+		t.Fatalf("CancelRide: %v", err)
+	}
+	if _, ok := <-firstDriver.C; ok || !firstDriver.Revoked() {
+		t.Fatal("the driver who gave the ride up still receives its events")
+	}
+	if event := <-passenger.C; event.Change == nil || event.Change.To != entity.StatusPending {
+		t.Fatalf("passenger got %+v, want the change back to pending", event)
+	}
+
+	if err := rt.rides.AssignDriverToRide(rt.ctx, ride.RideID, second.DriverID); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+	if event := <-passenger.C; event.Type != entity.EventRideAssigned || event.DriverID != second.DriverID {
+		t.Fatalf("passenger got %+v, want the new assignment", event)
+	}
+	secondDriver := subscribe(auth.Identity{Role: auth.RoleDriver, ID: second.DriverID})
+	if err := rt.rides.UpdateRideStatus(rt.ctx, ride.RideID, entity.StatusInProgress, entity.ActorDriver, ""); err != nil {
+		t.Fatalf("UpdateRideStatus: %v", err)
+	}
+	if event, ok := <-secondDriver.C; !ok || event.Change.To != entity.StatusInProgress {
+		t.Fatalf("assigned driver got %+v, %v; want the status change", event, ok)
+	}
+	if passenger.Revoked() || secondDriver.Revoked() {
+		t.Fatal("a subscriber who may still see the ride was revoked")
+	}
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+// validateLocation checks coordinate ranges; prefix names the request field
+// holding the location, or is empty when lat/lng are top-level fields.
+func validateLocation(prefix string, loc entity.Location) error {
+	if !loc.ValidLatitude() {
+		return &customErrors.FieldError{Field: fieldPath(prefix, "lat"), Err: customErrors.ErrInvalidLatitude}
+	}
+	if !loc.ValidLongitude() {
+		return &customErrors.FieldError{Field: fieldPath(prefix, "lng"), Err: customErrors.ErrInvalidLongitude}
+	}
+	return nil
+}
+
+func fieldPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"time"
+)
+
+type OfferStore interface {
+	// SaveOffer returns ErrOfferAlreadyPending, and stores nothing, if the
+	// ride or the driver already has a pending offer that has not expired
+	// by the new offer's CreatedAt.
+	SaveOffer(ctx context.Context, offer *entity.Offer) error
+	FindOfferByID(ctx context.Context, id int) (*entity.Offer, error)
+	UpdateOfferStatus(ctx context.Context, offerID int, from, to entity.OfferStatus, at time.Time) error
+	ListOffersByRide(ctx context.Context, rideID int) ([]*entity.Offer, error)
+	ListOffersByDriver(ctx context.Context, driverID int, status entity.OfferStatus) ([]*entity.Offer, error)
+}
+
+const DefaultOfferTimeout = 15 * time.Second
+
+// OfferService proposes pending rides to drivers. A ride is only assigned
+// once the driver accepts the offer before it expires.
+type OfferService struct {
+	store   OfferStore
+	rides   *RideService
+	timeout time.Duration
+
+	mutex   sync.Mutex
+	waiters map[int]chan<- entity.OfferStatus
+}
+
+func NewOfferService(store OfferStore, rides *RideService, timeout time.Duration) *OfferService {
+	return &OfferService{
+		store:   store,
+		rides:   rides,
+		timeout: timeout,
+		waiters: make(map[int]chan<- entity.OfferStatus),
+	}
+}
+
+// OfferRide proposes the ride to a driver picked by hand.
+func (s *OfferService) OfferRide(ctx context.Context, rideID, driverID int) (*entity.Offer, error) {
+	if rideID == 0 {
+		return nil, customErrors.ErrRideIDRequired
+	}
+	if driverID == 0 {
+		return nil, customErrors.ErrDriverIDRequired
+	}
+	ride, err := s.rides.store.FindRideByID(ctx, rideID)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := s.rides.driverStore.GetDriverByID(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if active, err := s.rides.store.FindActiveRideByDriver(ctx, driverID); err == nil && active != nil {
+		return nil, customErrors.ErrDriverAlreadyOnActiveRide
+	}
+	if !driver.IsAvailable {
+		return nil, customErrors.ErrDriverUnavailable
+	}
+	return s.createOffer(ctx, ride, driverID, 0, nil)
+}
+
+// createOffer saves a new pending offer. When done is not nil it receives the
+// offer's final status once the offer is accepted, declined or expired.
+func (s *OfferService) createOffer(ctx context.Context, ride *entity.Ride, driverID int, distanceKm float64, done chan<- entity.OfferStatus) (*entity.Offer, error) {
+	if ride.DriverID != 0 {
+		if ride.DriverID == driverID {
+			return nil, customErrors.ErrDriverAlreadyAssignedToRide
+		}
+		return nil, customErrors.ErrRideAlreadyAssigned
+	}
+	if ride.Status != entity.StatusPending {
+		return nil, customErrors.ErrCannotAssignDriverToNonPendingRide
+	}
+	if active, err := s.rides.store.FindActiveRideByDriver(ctx, driverID); err == nil && active != nil {
+		return nil, customErrors.ErrDriverAlreadyOnActiveRide
+	}
+
+	now := time.Now().UTC()
+	offer := &entity.Offer{
+		RideID:     ride.RideID,
+		DriverID:   driverID,
+		DistanceKm: distanceKm,
+		Status:     entity.OfferPending,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(s.timeout),
+	}
+	// Holding the mutex until the waiter is registered keeps an answer that
+	// arrives right after the save from being lost.
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.store.SaveOffer(ctx, offer); err != nil {
+		return nil, err
+	}
+	if done != nil {
+		s.waiters[offer.OfferID] = done
+	}
+	return offer, nil
+}
+
+func (s *OfferService) driverHasPendingOffer(ctx context.Context, driverID int) (bool, error) {
+	offers, err := s.store.ListOffersByDriver(ctx, driverID, entity.OfferPending)
+	if err != nil {
+		return false, err
+	}
+	return anyPending(offers), nil
+}
+
+func anyPending(offers []*entity.Offer) bool {
+	now := time.Now()
+	for _, offer := range offers {
+		if offer.Status == entity.OfferPending && !offer.IsExpired(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptOffer assigns the offered ride to the driver. The assignment goes
+// through RideService.AssignDriverToRide, so a driver who is already on an
+// active ride is still rejected.
+func (s *OfferService) AcceptOffer(ctx context.Context, driverID, offerID int) (*entity.Offer, error) {
+	offer, err := s.respond(ctx, driverID, offerID, entity.OfferAccepted)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rides.AssignDriverToRide(ctx, offer.RideID, driverID); err != nil {
+		s.notify(offerID, entity.OfferCancelled)
+		if cancelErr := s.store.UpdateOfferStatus(ctx, offerID, entity.OfferAccepted, entity.OfferCancelled, time.Now().UTC()); cancelErr != nil {
+			return nil, cancelErr
+		}
+		return nil, err
+	}
+	s.notify(offerID, entity.OfferAccepted)
+	return s.store.FindOfferByID(ctx, offerID)
+}
+
+func (s *OfferService) DeclineOffer(ctx context.Context, driverID, offerID int) (*entity.Offer, error) {
+	if _, err := s.respond(ctx, driverID, offerID, entity.OfferDeclined); err != nil {
+		return nil, err
+	}
+	s.notify(offerID, entity.OfferDeclined)
+	return s.store.FindOfferByID(ctx, offerID)
+}
+
+// respond records the driver's answer to a pending offer. Answers that come
+// in after the offer expired are rejected and the offer is marked expired.
+func (s *OfferService) respond(ctx context.Context, driverID, offerID int, status entity.OfferStatus) (*entity.Offer, error) {
+	if driverID == 0 {
+		return nil, customErrors.ErrDriverIDRequired
+	}
+	if offerID == 0 {
+		return nil, customErrors.ErrOfferIDRequired
+	}
+	offer, err := s.store.FindOfferByID(ctx, offerID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.DriverID != driverID {
+		return nil, customErrors.ErrOfferNotFound
+	}
+	now := time.Now().UTC()
+	if offer.IsExpired(now) {
+		if s.expire(ctx, offerID) {
+			return nil, customErrors.ErrOfferExpired
+		}
+		return nil, customErrors.ErrOfferNotPending
+	}
+	if err := s.store.UpdateOfferStatus(ctx, offerID, entity.OfferPending, status, now); err != nil {
+		return nil, err
+	}
+	offer.Status = status
+	offer.RespondedAt = &now
+	return offer, nil
+}
+
+// expire marks a still pending offer as expired and reports whether it did.
+func (s *OfferService) expire(ctx context.Context, offerID int) bool {
+	return s.close(ctx, offerID, entity.OfferExpired)
+}
+
+// close moves a still pending offer to status and reports whether it did; it
+// fails when the driver has answered in the meantime.
+func (s *OfferService) close(ctx context.Context, offerID int, status entity.OfferStatus) bool {
+	err := s.store.UpdateOfferStatus(ctx, offerID, entity.OfferPending, status, time.Now().UTC())
+	if err != nil {
+		return false
+	}
+	s.notify(offerID, status)
+	return true
+}
+
+func (s *OfferService) notify(offerID int, status entity.OfferStatus) {
+	s.mutex.Lock()
+	done, ok := s.waiters[offerID]
+	delete(s.waiters, offerID)
+	s.mutex.Unlock()
+	if ok {
+		done <- status
+	}
+}
+
+// GetRideOffers returns every offer made for the ride, oldest first.
+func (s *OfferService) GetRideOffers(ctx context.Context, rideID int) ([]*entity.Offer, error) {
+	if rideID == 0 {
+		return nil, customErrors.ErrRideIDRequired
+	}
+	if _, err := s.rides.store.FindRideByID(ctx, rideID); err != nil {
+		return nil, err
+	}
+	offers, err := s.store.ListOffersByRide(ctx, rideID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withExpiry(offers), nil
+}
+
+func (s *OfferService) GetDriverOffers(ctx context.Context, driverID int, status entity.OfferStatus) ([]*entity.Offer, error) {
+	if driverID == 0 {
+		return nil, customErrors.ErrDriverIDRequired
+	}
+	if _, err := s.rides.driverStore.GetDriverByID(ctx, driverID); err != nil {
+		return nil, err
+	}
+	offers, err := s.store.ListOffersByDriver(ctx, driverID, "")
+	if err != nil {
+		return nil, err
+	}
+	offers = s.withExpiry(offers)
+	if status == "" {
+		return offers, nil
+	}
+	filtered := make([]*entity.Offer, 0, len(offers))
+	for _, offer := range offers {
+		if offer.Status == status {
+			filtered = append(filtered, offer)
+		}
+	}
+	return filtered, nil
+}
+
+// withExpiry reports pending offers past their deadline as expired even if no
+// one has marked them yet.
+func (s *OfferService) withExpiry(offers []*entity.Offer) []*entity.Offer {
+	now := time.Now()
+	for _, offer := range offers {
+		if offer.IsExpired(now) {
+			offer.Status = entity.OfferExpired
+		}
+	}
+	return offers
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+func TestAcceptOffer(t *testing.T) {
+	rt := newRideTest(t)
+	offers := NewOfferService(rt.offerStore, rt.rides, DefaultOfferTimeout)
+	ride, driver := rt.createRide(t), rt.addDriver(t)
+
+	offer, err := offers.OfferRide(rt.ctx, ride.RideID, driver.DriverID)
+	if err != nil {
+		t.Fatalf("OfferRide: %v", err)
+	}
+	if _, err := offers.AcceptOffer(rt.ctx, driver.DriverID+1, offer.OfferID); !errors.Is(err, customErrors.ErrOfferNotFound) {
+		t.Fatalf("accepting another driver's offer: got %v, want ErrOfferNotFound", err)
+	}
+	accepted, err := offers.AcceptOffer(rt.ctx, driver.DriverID, offer.OfferID)
+	if err != nil {
+		t.Fatalf("AcceptOffer: %v", err)
+	}
+	if accepted.Status != entity.OfferAccepted || accepted.RespondedAt == nil {
+		t.Fatalf("offer = %+v, want accepted", accepted)
+	}
+	if got := rt.ride(t, ride.RideID); got.Status != entity.StatusAccepted || got.DriverID != driver.DriverID {
+		t.Fatalf("ride = %s with driver %d, want accepted with driver %d", got.Status, got.DriverID, driver.DriverID)
+	}
+	if rt.driver(t, driver.DriverID).IsAvailable {
+		t.Fatal("driver is still available after accepting")
+	}
+	if _, err := offers.DeclineOffer(rt.ctx, driver.DriverID, offer.OfferID); !errors.Is(err, customErrors.ErrOfferNotPending) {
+		t.Fatalf("declining an accepted offer: got %v, want ErrOfferNotPending", err)
+	}
+}
+
+func TestDeclineOffer(t *testing.T) {
+	rt := newRideTest(t)
+	offers := NewOfferService(rt.offerStore, rt.rides, DefaultOfferTimeout)
+	ride, first, second := rt.createRide(t), rt.addDriver(t), rt.addDriver(t)
+
+	offer, err := offers.OfferRide(rt.ctx, ride.RideID, first.DriverID)
+	if err != nil {
+		t.Fatalf("OfferRide: %v", err)
+	}
+	declined, err := offers.DeclineOffer(rt.ctx, first.DriverID, offer.OfferID)
+	if err != nil {
+		t.Fatalf("DeclineOffer: %v", err)
+	}
+	if declined.Status != entity.OfferDeclined {
+		t.Fatalf("offer status = %s, want declined", declined.Status)
+	}
+	if got := rt.ride(t, ride.RideID); got.Status != entity.StatusPending || got.DriverID != 0 {
+		t.Fatalf("ride = %s with driver %d, want pending without a driver", got.Status, got.DriverID)
+	}
+	if _, err := offers.AcceptOffer(rt.ctx, first.DriverID, offer.OfferID); !errors.Is(err, customErrors.ErrOfferNotPending) {
+		t.Fatalf("accepting a declined offer: got %v, want ErrOfferNotPending", err)
+	}
+	if _, err := offers.OfferRide(rt.ctx, ride.RideID, second.DriverID); err != nil {
+		t.Fatalf("offering the ride again after a decline: %v", err)
+	}
+}
+
+func TestOfferExpires(t *testing.T) {
+	rt := newRideTest(t)
+	offers := NewOfferService(rt.offerStore, rt.rides, 20*time.Millisecond)
+	ride, driver := rt.createRide(t), rt.addDriver(t)
+
+	offer, err := offers.OfferRide(rt.ctx, ride.RideID, driver.DriverID)
+	if err != nil {
+		t.Fatalf("OfferRide: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	listed, err := offers.GetDriverOffers(rt.ctx, driver.DriverID, entity.OfferExpired)
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("expired offers = %v, %v; want the unanswered offer", listed, err)
+	}
+	if _, err := offers.AcceptOffer(rt.ctx, driver.DriverID, offer.OfferID); !errors.Is(err, customErrors.ErrOfferExpired) {
+		t.Fatalf("accepting after the deadline: got %v, want ErrOfferExpired", err)
+	}
+	if _, err := offers.AcceptOffer(rt.ctx, driver.DriverID, offer.OfferID); !errors.Is(err, customErrors.ErrOfferNotPending) {
+		t.Fatalf("accepting an expired offer again: got %v, want ErrOfferNotPending", err)
+	}
+	if got := rt.ride(t, ride.RideID); got.Status != entity.StatusPending || got.DriverID != 0 {
+		t.Fatalf("ride = %s with driver %d, want pending without a driver", got.Status, got.DriverID)
+	}
+	if _, err := offers.OfferRide(rt.ctx, ride.RideID, driver.DriverID); err != nil {
+		t.Fatalf("offering the ride again after expiry: %v", err)
+	}
+}
+
+func TestOfferWhilePending(t *testing.T) {
+	rt := newRideTest(t)
+	offers := NewOfferService(rt.offerStore, rt.rides, DefaultOfferTimeout)
+	ride, other := rt.createRide(t), rt.createRide(t)
+	drivers := make([]*entity.Driver, 8)
+	for i := range drivers {
+		drivers[i] = rt.addDriver(t)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		offered []*entity.Offer
+	)
+	for _, driver := range drivers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			offer, err := offers.OfferRide(rt.ctx, ride.RideID, driver.DriverID)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				offered = append(offered, offer)
+			case !errors.Is(err, customErrors.ErrOfferAlreadyPending):
+				t.Errorf("OfferRide: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(offered) != 1 {
+		t.Fatalf("ride offered to %d drivers at once, want 1", len(offered))
+	}
+	if _, err := offers.OfferRide(rt.ctx, other.RideID, offered[0].DriverID); !errors.Is(err, customErrors.ErrOfferAlreadyPending) {
+		t.Fatalf("second ride to a driver with a pending offer: got %v, want ErrOfferAlreadyPending", err)
+	}
+}
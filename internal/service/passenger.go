@@ -2,45 +2,57 @@ package service
 
 import (
 	"context"
+	"strings"
+	"taxiAPI/internal/auth"
 	"taxiAPI/internal/entity"
 	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/validation"
 )
 
 type PassengerStore interface {
+	// RegisterPassenger returns ErrPhoneNumberExists, and stores nothing,
+	// if another passenger has the same phone number.
 	RegisterPassenger(ctx context.Context, p *entity.Passenger) (*entity.Passenger, error)
 	GetPassengerByID(ctx context.Context, id int) (*entity.Passenger, error)
-	GetAllPassengers(ctx context.Context) ([]*entity.Passenger, error)
+	ListPassengers(ctx context.Context, query entity.PassengerQuery) ([]*entity.Passenger, int, error)
 	DeletePassenger(ctx context.Context, id int) error
-	FindByPhoneNumber(ctx context.Context, phone int) (*entity.Passenger, error)
+	FindByPhoneNumber(ctx context.Context, phone string) (*entity.Passenger, error)
+	AddPassengerRating(ctx context.Context, id int, score int) error
 }
 
 type PassengerService struct {
-	store PassengerStore
+	store       PassengerStore
+	phoneRegion string
 }
 
-func NewPassengerService(store PassengerStore) *PassengerService {
+// NewPassengerService creates the service. Phone numbers without a country
+// code are read as numbers of phoneRegion.
+func NewPassengerService(store PassengerStore, phoneRegion string) *PassengerService {
 	return &PassengerService{
-		store: store,
+		store:       store,
+		phoneRegion: phoneRegion,
 	}
 }
 
-func (s *PassengerService) RegisterPassenger(ctx context.Context, p *entity.Passenger) (*entity.Passenger, error) {
+func (s *PassengerService) RegisterPassenger(ctx context.Context, p *entity.Passenger, password string) (*entity.Passenger, error) {
 	if p == nil {
 		return nil, customErrors.ErrPassengerDataRequired
 	}
-	if p.FirstName == "" {
-		return nil, customErrors.ErrFirstName
-	}
-	if p.LastName == "" {
-		return nil, customErrors.ErrLastName
-	}
-	if p.PhoneNumber == 0 {
-		return nil, customErrors.ErrPhoneNumber
+	p.FirstName, p.LastName = strings.TrimSpace(p.FirstName), strings.TrimSpace(p.LastName)
+	v := validation.FromContext(ctx)
+	v.Name("first_name", p.FirstName, customErrors.ErrFirstName)
+	v.Name("last_name", p.LastName, customErrors.ErrLastName)
+	p.PhoneNumber = v.PhoneNumber("phone_number", p.PhoneNumber, s.phoneRegion)
+	v.Password("password", password)
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
-	existing, _ := s.store.FindByPhoneNumber(ctx, p.PhoneNumber)
-	if existing != nil {
-		return nil, customErrors.ErrPhoneNumberExists
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
 	}
+	p.PasswordHash = hash
+	p.Rating, p.RatingCount = 0, 0
 	registeredPassenger, err := s.store.RegisterPassenger(ctx, p)
 	if err != nil {
 		return nil, err
@@ -59,12 +71,9 @@ func (s *PassengerService) GetPassengerByID(ctx context.Context, id int) (*entit
 	return passenger, nil
 }
 
-func (s *PassengerService) GetAllPassengers(ctx context.Context) ([]*entity.Passenger, error) {
-	passengers, err := s.store.GetAllPassengers(ctx)
-	if err != nil {
-		return nil, err
-	}
-	return passengers, nil
+func (s *PassengerService) ListPassengers(ctx context.Context, query entity.PassengerQuery) ([]*entity.Passenger, int, error) {
+	query.Page = normalizePage(query.Page)
+	return s.store.ListPassengers(ctx, query)
 }
 
 func (s *PassengerService) DeletePassenger(ctx context.Context, id int) error {
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/storage"
+)
+
+// TestRegisterConcurrentKeepsPhoneNumbersUnique registers one number,
+// written in different ways, many times at once. Exactly one registration
+// per account type may win.
+func TestRegisterConcurrentKeepsPhoneNumbersUnique(t *testing.T) {
+	const attempts = 8
+	numbers := []string{"+14155550100", "(415) 555-0100", "1 415 555 0100", "011 1 415 555 0100"}
+	ctx := context.Background()
+	driverStore := storage.NewDriver()
+	passengers := NewPassengerService(storage.NewPassenger(), "US")
+	drivers := NewDriverService(driverStore, storage.NewRide(), storage.NewLocation(), storage.NewTransactor(), "US")
+
+	register := map[string]func(i int) error{
+		"passenger": func(i int) error {
+			_, err := passengers.RegisterPassenger(ctx, &entity.Passenger{
+				FirstName: "John", LastName: "Doe", PhoneNumber: numbers[i%len(numbers)],
+			}, "secret123")
+			return err
+		},
+		"driver": func(i int) error {
+			_, err := drivers.RegisterDriver(ctx, &entity.Driver{
+				FirstName: "Alex", LastName: "Smith", PhoneNumber: numbers[i%len(numbers)], CarType: "Prius", LicensePlate: 1,
+			}, "secret123")
+			return err
+		},
+	}
+	for role, fn := range register {
+		t.Run(role, func(t *testing.T) {
+			var (
+				wg        sync.WaitGroup
+				mu        sync.Mutex
+				succeeded int
+			)
+			for i := range attempts {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					err := fn(i)
+					mu.Lock()
+					defer mu.Unlock()
+					switch {
+					case err == nil:
+						succeeded++
+					case !errors.Is(err, customErrors.ErrPhoneNumberExists):
+						t.Errorf("register: got %v, want nil or ErrPhoneNumberExists", err)
+					}
+				}()
+			}
+			wg.Wait()
+			if succeeded != 1 {
+				t.Fatalf("%d registrations succeeded, want 1", succeeded)
+			}
+		})
+	}
+
+	// Deleting an account frees its number.
+	if err := driverStore.DeleteDriver(ctx, 1); err != nil {
+		t.Fatalf("DeleteDriver: %v", err)
+	}
+	if err := register["driver"](0); err != nil {
+		t.Fatalf("register after delete: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/pricing"
+	"taxiAPI/internal/validation"
+	"time"
+)
+
+type QuoteStore interface {
+	SaveQuote(ctx context.Context, quote *entity.Quote) error
+	FindQuoteByID(ctx context.Context, id int) (*entity.Quote, error)
+	// MarkQuoteUsed atomically marks the quote used, or returns
+	// ErrQuoteUsed if it already was.
+	MarkQuoteUsed(ctx context.Context, id int, usedAt time.Time) error
+}
+
+// DefaultQuoteTTL is how long a passenger has to book at the quoted fare.
+const DefaultQuoteTTL = 5 * time.Minute
+
+type PricingService struct {
+	engine *pricing.Engine
+	store  QuoteStore
+	surge  *SurgeService
+	ttl    time.Duration
+}
+
+func NewPricingService(engine *pricing.Engine, store QuoteStore, surge *SurgeService, ttl time.Duration) *PricingService {
+	return &PricingService{
+		engine: engine,
+		store:  store,
+		surge:  surge,
+		ttl:    ttl,
+	}
+}
+
+// Estimate prices a trip before it is booked and saves the quote so the
+// ride can be booked at that fare.
+func (s *PricingService) Estimate(ctx context.Context, quote *entity.Quote) (*entity.Quote, error) {
+	if quote == nil {
+		return nil, customErrors.ErrRideDataRequired
+	}
+	v := validation.FromContext(ctx)
+	v.Trip(quote.Origin, quote.Destination)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	if !quote.Origin.HasCoordinates() || !quote.Destination.HasCoordinates() {
+		return nil, customErrors.ErrCoordinatesRequired
+	}
+
+	surge, err := s.price(ctx, quote)
+	if err != nil {
+		return nil, err
+	}
+	quote.CreatedAt = time.Now().UTC()
+	quote.ExpiresAt = quote.CreatedAt.Add(s.ttl)
+	// A surged fare is only honoured for as long as the surge itself.
+	if surge.Multiplier > 1 && surge.ExpiresAt.Before(quote.ExpiresAt) {
+		quote.ExpiresAt = surge.ExpiresAt
+	}
+	if err := s.store.SaveQuote(ctx, quote); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// price fills in the quote's distance, duration and fare using the surge in
+// effect at the pickup point, and returns that surge.
+func (s *PricingService) price(ctx context.Context, quote *entity.Quote) (*entity.Surge, error) {
+	surge, err := s.surge.GetSurge(ctx, quote.Origin)
+	if err != nil {
+		return nil, err
+	}
+	s.priceAt(quote, surge.Multiplier)
+	return surge, nil
+}
+
+// priceAt fills in the quote's distance, duration and fare at the given
+// surge multiplier.
+func (s *PricingService) priceAt(quote *entity.Quote, multiplier float64) {
+	quote.DistanceKm = quote.Origin.DistanceKm(quote.Destination)
+	duration := s.engine.EstimateDuration(quote.DistanceKm)
+	quote.DurationMinutes = duration.Minutes()
+	quote.Fare = s.engine.Calculate(quote.DistanceKm, duration, quote.CarType, multiplier)
+}
+
+// lockIn sets the ride's quoted fare. A ride booked with a quote ID gets the
+// fare of that quote, which must be the passenger's own and unused (see
+// useQuote); otherwise the trip is priced now. Scheduled rides are
+// priced without surge, since the surge of today says nothing about demand
+// at pickup time. Rides without coordinates cannot be priced up front and
+// only get a final fare.
+func (s *PricingService) lockIn(ctx context.Context, ride *entity.Ride, quoteID int) error {
+	if quoteID == 0 {
+		if !ride.Origin.HasCoordinates() || !ride.Destination.HasCoordinates() {
+			return nil
+		}
+		quote := &entity.Quote{Origin: ride.Origin, Destination: ride.Destination, CarType: ride.CarType}
+		if ride.ScheduledAt != nil {
+			s.priceAt(quote, 1)
+		} else if _, err := s.price(ctx, quote); err != nil {
+			return err
+		}
+		setQuotedFare(ride, quote.Fare)
+		return nil
+	}
+
+	quote, err := s.store.FindQuoteByID(ctx, quoteID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if now.After(quote.ExpiresAt) {
+		return customErrors.ErrQuoteExpired
+	}
+	// A quote made without a passenger, by an admin, only shows the fare.
+	if quote.PassengerID != ride.PassengerID ||
+		quote.Origin != ride.Origin || quote.Destination != ride.Destination || quote.CarType != ride.CarType {
+		return customErrors.ErrQuoteMismatch
+	}
+	if quote.UsedAt != nil {
+		return customErrors.ErrQuoteUsed
+	}
+	setQuotedFare(ride, quote.Fare)
+	return nil
+}
+
+// checkQuoteUnused fails with ErrQuoteUsed if a ride was booked with the
+// quote since lockIn. It is the check before the first write of the
+// transaction that books the ride and then calls useQuote.
+func (s *PricingService) checkQuoteUnused(ctx context.Context, quoteID int) error {
+	if quoteID == 0 {
+		return nil
+	}
+	quote, err := s.store.FindQuoteByID(ctx, quoteID)
+	if err != nil {
+		return err
+	}
+	if quote.UsedAt != nil {
+		return customErrors.ErrQuoteUsed
+	}
+	return nil
+}
+
+// useQuote records that a ride was booked with the quote, so that it books
+// no other. It belongs in the transaction that saves the ride.
+func (s *PricingService) useQuote(ctx context.Context, quoteID int, usedAt time.Time) error {
+	if quoteID == 0 {
+		return nil
+	}
+	return s.store.MarkQuoteUsed(ctx, quoteID, usedAt)
+}
+
+func setQuotedFare(ride *entity.Ride, fare entity.FareBreakdown) {
+	ride.Currency = fare.Currency
+	ride.QuotedFare = fare.Total
+	ride.SurgeMultiplier = fare.Surge
+}
+
+// finalFare prices a completed ride from its distance and the time between
+// pickup and drop-off, at the surge the passenger was quoted.
+func (s *PricingService) finalFare(ride *entity.Ride, completedAt time.Time) entity.FareBreakdown {
+	var duration time.Duration
+	if ride.StartedAt != nil {
+		duration = completedAt.Sub(*ride.StartedAt)
+	}
+	return s.engine.Calculate(ride.DistanceKm, duration, ride.CarType, ride.SurgeMultiplier)
+}
+
+// chargeCancellation sets the fee for a passenger cancelling at cancelledAt
+// the ride a driver accepted at acceptedAt.
+func (s *PricingService) chargeCancellation(ride *entity.Ride, acceptedAt, cancelledAt time.Time) {
+	ride.CancellationFee = s.engine.CancellationFee(acceptedAt, cancelledAt)
+	if ride.CancellationFee > 0 && ride.Currency == "" {
+		ride.Currency = s.engine.Currency()
+	}
+}
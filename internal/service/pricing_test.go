@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/pricing"
+	"taxiAPI/internal/storage"
+)
+
+// berlinTrip is the trip createRide books.
+func berlinTrip(passengerID int) *entity.Quote {
+	return &entity.Quote{
+		PassengerID: passengerID,
+		Origin:      entity.Location{Latitude: 52.52, Longitude: 13.405},
+		Destination: entity.Location{Latitude: 52.50, Longitude: 13.45},
+	}
+}
+
+// newPricing returns a PricingService with a fresh surge cache and the given
+// quote TTL, and points the ride service at it.
+func (rt *rideTest) newPricing(ttl time.Duration) *PricingService {
+	engine := pricing.NewEngine(pricing.DefaultConfig())
+	surge := NewSurgeService(engine, rt.rideStore, rt.drivers, rt.locations)
+	prices := NewPricingService(engine, storage.NewQuote(), surge, ttl)
+	rt.rides.pricing = prices
+	return prices
+}
+
+func (rt *rideTest) bookWithQuote(passengerID, quoteID int) (*entity.Ride, error) {
+	trip := berlinTrip(passengerID)
+	return rt.rides.CreateRide(rt.ctx, &entity.Ride{PassengerID: passengerID, Origin: trip.Origin, Destination: trip.Destination}, quoteID)
+}
+
+func TestEstimate(t *testing.T) {
+	rt := newRideTest(t)
+	prices := rt.newPricing(DefaultQuoteTTL)
+
+	quote, err := prices.Estimate(rt.ctx, berlinTrip(rt.passenger.PassengerID))
+	if err != nil {
+		t.Fatalf("Estimate: %v", err)
+	}
+	if quote.QuoteID == 0 || quote.Fare.Total == 0 || quote.Fare.Surge != 1 {
+		t.Fatalf("quote = %+v, want a saved quote without surge", quote)
+	}
+	if ttl := quote.ExpiresAt.Sub(quote.CreatedAt); ttl != DefaultQuoteTTL {
+		t.Fatalf("quote valid for %v, want %v", ttl, DefaultQuoteTTL)
+	}
+	if _, err := prices.Estimate(rt.ctx, &entity.Quote{Origin: entity.Location{Address: "A"}, Destination: entity.Location{Address: "B"}}); !errors.Is(err, customErrors.ErrCoordinatesRequired) {
+		t.Fatalf("Estimate without coordinates: got %v, want ErrCoordinatesRequired", err)
+	}
+}
+
+func TestEstimateWithSurgeExpiresWithTheSurge(t *testing.T) {
+	rt := newRideTest(t)
+	for i := 0; i < 4; i++ {
+		rt.createRide(t)
+	}
+	// A fresh surge cache sees the four pending rides and no drivers.
+	prices := rt.newPricing(DefaultQuoteTTL)
+
+	quote, err := prices.Estimate(rt.ctx, berlinTrip(rt.passenger.PassengerID))
+	if err != nil {
+		t.Fatalf("Estimate: %v", err)
+	}
+	surge, err := prices.surge.GetSurge(rt.ctx, quote.Origin)
+	if err != nil {
+		t.Fatalf("GetSurge: %v", err)
+	}
+	if surge.Multiplier != 2.5 || quote.Fare.Surge != surge.Multiplier {
+		t.Fatalf("quote surge = %v, cell surge = %+v; want both 2.5", quote.Fare.Surge, surge)
+	}
+	if !quote.ExpiresAt.Equal(surge.ExpiresAt) {
+		t.Fatalf("quote expires at %v, want with the surge at %v", quote.ExpiresAt, surge.ExpiresAt)
+	}
+
+	ride, err := rt.bookWithQuote(rt.passenger.PassengerID, quote.QuoteID)
+	if err != nil {
+		t.Fatalf("CreateRide: %v", err)
+	}
+	if ride.QuotedFare != quote.Fare.Total || ride.SurgeMultiplier != 2.5 {
+		t.Fatalf("ride fare = %d at × %v, want the quoted %d at × 2.5", ride.QuotedFare, ride.SurgeMultiplier, quote.Fare.Total)
+	}
+}
+
+func TestQuoteBooksOneRideForItsPassenger(t *testing.T) {
+	rt := newRideTest(t)
+	prices := rt.newPricing(DefaultQuoteTTL)
+	other, err := rt.rides.passengerStore.RegisterPassenger(rt.ctx, &entity.Passenger{FirstName: "Jane", LastName: "Roe", PhoneNumber: "+14155550199"})
+	if err != nil {
+		t.Fatalf("RegisterPassenger: %v", err)
+	}
+
+	quote, err := prices.Estimate(rt.ctx, berlinTrip(rt.passenger.PassengerID))
+	if err != nil {
+		t.Fatalf("Estimate: %v", err)
+	}
+	if _, err := rt.bookWithQuote(other.PassengerID, quote.QuoteID); !errors.Is(err, customErrors.ErrQuoteMismatch) {
+		t.Fatalf("booking with another passenger's quote: got %v, want ErrQuoteMismatch", err)
+	}
+	if _, err := rt.bookWithQuote(rt.passenger.PassengerID, quote.QuoteID); err != nil {
+		t.Fatalf("CreateRide: %v", err)
+	}
+	if _, err := rt.bookWithQuote(rt.passenger.PassengerID, quote.QuoteID); !errors.Is(err, customErrors.ErrQuoteUsed) {
+		t.Fatalf("booking twice with one quote: got %v, want ErrQuoteUsed", err)
+	}
+
+	unbound, err := prices.Estimate(rt.ctx, berlinTrip(0))
+	if err != nil {
+		t.Fatalf("Estimate: %v", err)
+	}
+	if _, err := rt.bookWithQuote(rt.passenger.PassengerID, unbound.QuoteID); !errors.Is(err, customErrors.ErrQuoteMismatch) {
+		t.Fatalf("booking with a quote made for no passenger: got %v, want ErrQuoteMismatch", err)
+	}
+}
+
+func TestQuoteUsedOnceUnderConcurrency(t *testing.T) {
+	rt := newRideTest(t)
+	prices := rt.newPricing(DefaultQuoteTTL)
+	quote, err := prices.Estimate(rt.ctx, berlinTrip(rt.passenger.PassengerID))
+	if err != nil {
+		t.Fatalf("Estimate: %v", err)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		start  = make(chan struct{})
+		booked atomic.Int64
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := rt.bookWithQuote(rt.passenger.PassengerID, quote.QuoteID)
+			switch {
+			case err == nil:
+				booked.Add(1)
+			case !errors.Is(err, customErrors.ErrQuoteUsed):
+				t.Errorf("CreateRide: unexpected error %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if booked.Load() != 1 {
+		t.Fatalf("%d rides booked with one quote, want 1", booked.Load())
+	}
+}
+
+func TestQuoteExpires(t *testing.T) {
+	rt := newRideTest(t)
+	prices := rt.newPricing(10 * time.Millisecond)
+	quote, err := prices.Estimate(rt.ctx, berlinTrip(rt.passenger.PassengerID))
+	if err != nil {
+		t.Fatalf("Estimate: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, err := rt.bookWithQuote(rt.passenger.PassengerID, quote.QuoteID); !errors.Is(err, customErrors.ErrQuoteExpired) {
+		t.Fatalf("booking with an expired quote: got %v, want ErrQuoteExpired", err)
+	}
+}
+
+func TestQuoteUnusedWhenBookingFails(t *testing.T) {
+	rt := newRideTest(t)
+	prices := rt.newPricing(DefaultQuoteTTL)
+	quote, err := prices.Estimate(rt.ctx, berlinTrip(rt.passenger.PassengerID))
+	if err != nil {
+		t.Fatalf("Estimate: %v", err)
+	}
+
+	failing := NewRideService(failingHistory{rt.rideStore}, rt.rides.passengerStore, rt.drivers, storage.NewTransactor(), prices)
+	trip := berlinTrip(rt.passenger.PassengerID)
+	if _, err := failing.CreateRide(rt.ctx, &entity.Ride{PassengerID: rt.passenger.PassengerID, Origin: trip.Origin, Destination: trip.Destination}, quote.QuoteID); err == nil {
+		t.Fatal("CreateRide succeeded although the history could not be written")
+	}
+	if _, err := rt.bookWithQuote(rt.passenger.PassengerID, quote.QuoteID); err != nil {
+		t.Fatalf("booking again after a failed booking: %v", err)
+	}
+}
+
+func TestQuoteUsedByEditingAScheduledRide(t *testing.T) {
+	rt := newRideTest(t)
+	prices := rt.newPricing(DefaultQuoteTTL)
+	scheduled := rt.scheduleRide(t, time.Now().Add(time.Hour))
+	quote, err := prices.Estimate(rt.ctx, berlinTrip(rt.passenger.PassengerID))
+	if err != nil {
+		t.Fatalf("Estimate: %v", err)
+	}
+
+	edited, err := rt.rides.EditScheduledRide(rt.ctx, scheduled.RideID, entity.RideEdit{}, quote.QuoteID)
+	if err != nil {
+		t.Fatalf("EditScheduledRide: %v", err)
+	}
+	if edited.QuotedFare != quote.Fare.Total {
+		t.Fatalf("fare = %d, want the quoted %d", edited.QuotedFare, quote.Fare.Total)
+	}
+	if _, err := rt.bookWithQuote(rt.passenger.PassengerID, quote.QuoteID); !errors.Is(err, customErrors.ErrQuoteUsed) {
+		t.Fatalf("booking with a quote an edit used: got %v, want ErrQuoteUsed", err)
+	}
+}
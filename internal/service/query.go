@@ -0,0 +1,16 @@
+package service
+
+import "taxiAPI/internal/entity"
+
+func normalizePage(page entity.Page) entity.Page {
+	if page.Limit <= 0 {
+		page.Limit = entity.DefaultPageLimit
+	}
+	if page.Limit > entity.MaxPageLimit {
+		page.Limit = entity.MaxPageLimit
+	}
+	if page.Offset < 0 {
+		page.Offset = 0
+	}
+	return page
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"time"
+)
+
+type RatingStore interface {
+	SaveRating(ctx context.Context, rating *entity.Rating) error
+	ListRatingsByRide(ctx context.Context, rideID int) ([]*entity.Rating, error)
+}
+
+type RatingService struct {
+	store RatingStore
+	rides *RideService
+}
+
+func NewRatingService(store RatingStore, rides *RideService) *RatingService {
+	return &RatingService{
+		store: store,
+		rides: rides,
+	}
+}
+
+// RateRide records one side's rating of a completed ride and folds it into
+// the other side's average. Each side can rate a ride once.
+func (s *RatingService) RateRide(ctx context.Context, rating *entity.Rating) (*entity.Rating, error) {
+	if rating == nil {
+		return nil, customErrors.ErrInvalidPayload
+	}
+	if rating.RideID == 0 {
+		return nil, customErrors.ErrRideIDRequired
+	}
+	if rating.Actor != entity.ActorPassenger && rating.Actor != entity.ActorDriver {
+		return nil, customErrors.ErrInvalidRater
+	}
+	if rating.Score < entity.MinRatingScore || rating.Score > entity.MaxRatingScore {
+		return nil, customErrors.ErrInvalidScore
+	}
+	rating.Comment = strings.TrimSpace(rating.Comment)
+	rating.CreatedAt = time.Now().UTC()
+
+	err := s.rides.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		ride, err := s.rides.store.FindRideByID(ctx, rating.RideID)
+		if err != nil {
+			return err
+		}
+		if ride.Status != entity.StatusCompleted {
+			return customErrors.ErrRideNotCompleted
+		}
+		ratings, err := s.store.ListRatingsByRide(ctx, rating.RideID)
+		if err != nil {
+			return err
+		}
+		for _, existing := range ratings {
+			if existing.Actor == rating.Actor {
+				return customErrors.ErrAlreadyRated
+			}
+		}
+		// The average is updated before the rating is saved: the memory
+		// stores cannot roll back, and a rating saved but never counted
+		// could not be retried. The rating stays on the ride even if the
+		// rated account was deleted in the meantime.
+		if err := s.addRating(ctx, ride, rating); err != nil {
+			return err
+		}
+		return s.store.SaveRating(ctx, rating)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+// addRating folds the rating into the average of the side being rated.
+func (s *RatingService) addRating(ctx context.Context, ride *entity.Ride, rating *entity.Rating) error {
+	if rating.Actor == entity.ActorPassenger {
+		err := s.rides.driverStore.AddDriverRating(ctx, ride.DriverID, rating.Score)
+		if errors.Is(err, customErrors.ErrDriverNotFound) {
+			return nil
+		}
+		return err
+	}
+	err := s.rides.passengerStore.AddPassengerRating(ctx, ride.PassengerID, rating.Score)
+	if errors.Is(err, customErrors.ErrPassengerNotFound) {
+		return nil
+	}
+	return err
+}
+
+// GetRideRatings returns the ratings given for the ride, oldest first.
+func (s *RatingService) GetRideRatings(ctx context.Context, rideID int) ([]*entity.Rating, error) {
+	if rideID == 0 {
+		return nil, customErrors.ErrRideIDRequired
+	}
+	if _, err := s.rides.store.FindRideByID(ctx, rideID); err != nil {
+		return nil, err
+	}
+	return s.store.ListRatingsByRide(ctx, rideID)
+}
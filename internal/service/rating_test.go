@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/storage"
+)
+
+// completeRide takes a new ride through to completed with driver.
+func (rt *rideTest) completeRide(t *testing.T, driver *entity.Driver) *entity.Ride {
+	t.Helper()
+	ride := rt.createRide(t)
+	if err := rt.rides.AssignDriverToRide(rt.ctx, ride.RideID, driver.DriverID); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+	for _, status := range []entity.Status{entity.StatusInProgress, entity.StatusCompleted} {
+		if err := rt.rides.UpdateRideStatus(rt.ctx, ride.RideID, status, entity.ActorDriver, ""); err != nil {
+			t.Fatalf("UpdateRideStatus(%s): %v", status, err)
+		}
+	}
+	return ride
+}
+
+func TestRateRide(t *testing.T) {
+	rt := newRideTest(t)
+	ratings := NewRatingService(storage.NewRating(), rt.rides)
+	driver := rt.addDriver(t)
+	pending := rt.createRide(t)
+	ride := rt.completeRide(t, driver)
+
+	for _, tc := range []struct {
+		name   string
+		rating entity.Rating
+		want   error
+	}{
+		{"uncompleted ride", entity.Rating{RideID: pending.RideID, Actor: entity.ActorPassenger, Score: 5}, customErrors.ErrRideNotCompleted},
+		{"score too low", entity.Rating{RideID: ride.RideID, Actor: entity.ActorPassenger, Score: entity.MinRatingScore - 1}, customErrors.ErrInvalidScore},
+		{"score too high", entity.Rating{RideID: ride.RideID, Actor: entity.ActorPassenger, Score: entity.MaxRatingScore + 1}, customErrors.ErrInvalidScore},
+		{"system", entity.Rating{RideID: ride.RideID, Actor: entity.ActorSystem, Score: 5}, customErrors.ErrInvalidRater},
+		{"passenger", entity.Rating{RideID: ride.RideID, Actor: entity.ActorPassenger, Score: 4}, nil},
+		{"passenger again", entity.Rating{RideID: ride.RideID, Actor: entity.ActorPassenger, Score: 1}, customErrors.ErrAlreadyRated},
+		{"driver", entity.Rating{RideID: ride.RideID, Actor: entity.ActorDriver, Score: 2}, nil},
+		{"driver again", entity.Rating{RideID: ride.RideID, Actor: entity.ActorDriver, Score: 5}, customErrors.ErrAlreadyRated},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rating := tc.rating
+			if _, err := ratings.RateRide(rt.ctx, &rating); !errors.Is(err, tc.want) || (err == nil) != (tc.want == nil) {
+				t.Fatalf("RateRide = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	// Only the first rating from each side counts.
+	if got := rt.driver(t, driver.DriverID); got.Rating != 4 || got.RatingCount != 1 {
+		t.Errorf("driver rating = %v over %d, want 4 over 1", got.Rating, got.RatingCount)
+	}
+	passenger, err := rt.rides.passengerStore.GetPassengerByID(rt.ctx, rt.passenger.PassengerID)
+	if err != nil {
+		t.Fatalf("GetPassengerByID: %v", err)
+	}
+	if passenger.Rating != 2 || passenger.RatingCount != 1 {
+		t.Errorf("passenger rating = %v over %d, want 2 over 1", passenger.Rating, passenger.RatingCount)
+	}
+	if got, err := ratings.GetRideRatings(rt.ctx, ride.RideID); err != nil || len(got) != 2 {
+		t.Errorf("GetRideRatings = %d ratings, %v; want 2", len(got), err)
+	}
+}
+
+func TestRatingChangesDispatchScore(t *testing.T) {
+	rt := newRideTest(t)
+	ratings := NewRatingService(storage.NewRating(), rt.rides)
+	dispatcher := &Dispatcher{config: DefaultDispatchConfig()}
+	good, bad, unrated := rt.addDriver(t), rt.addDriver(t), rt.addDriver(t)
+
+	for driver, score := range map[*entity.Driver]int{good: 5, bad: 1} {
+		ride := rt.completeRide(t, driver)
+		if _, err := ratings.RateRide(rt.ctx, &entity.Rating{RideID: ride.RideID, Actor: entity.ActorPassenger, Score: score}); err != nil {
+			t.Fatalf("RateRide: %v", err)
+		}
+	}
+
+	score := func(driver *entity.Driver) float64 {
+		return dispatcher.dispatchScore(&entity.NearbyDriver{Driver: rt.driver(t, driver.DriverID), DistanceKm: 1})
+	}
+	if !(score(good) < score(unrated) && score(unrated) < score(bad)) {
+		t.Fatalf("scores at the same distance: 5 stars %v, unrated %v, 1 star %v; want them in that order",
+			score(good), score(unrated), score(bad))
+	}
+	// A 5-star driver is worth RatingWeightKm per star over a 1-star one.
+	farther := dispatcher.dispatchScore(&entity.NearbyDriver{Driver: rt.driver(t, good.DriverID), DistanceKm: 1 + 3*dispatcher.config.RatingWeightKm})
+	if farther >= score(bad) {
+		t.Errorf("a 5-star driver %v km farther away scores %v, want below the 1-star driver's %v",
+			3*dispatcher.config.RatingWeightKm, farther, score(bad))
+	}
+}
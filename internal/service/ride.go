@@ -2,63 +2,128 @@ package service
 
 import (
 	"context"
+	"errors"
 	"taxiAPI/internal/entity"
 	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/validation"
+	"time"
 )
 
 type RideStore interface {
 	SaveRide(ctx context.Context, ride *entity.Ride) error
 	FindRideByID(ctx context.Context, id int) (*entity.Ride, error)
-	UpdateRideStatus(ctx context.Context, rideID int, status entity.Status) error
-	AssignDriverToRide(ctx context.Context, rideID int, driverID int) error
-	GetAllRides(ctx context.Context) ([]*entity.Ride, error)
+	UpdateRide(ctx context.Context, ride *entity.Ride) error
+	AssignDriverToRide(ctx context.Context, rideID int, driverID int, acceptedAt time.Time) error
+	UnassignDriverFromRide(ctx context.Context, rideID int, driverID int) error
+	AppendRideHistory(ctx context.Context, rideID int, change entity.StatusChange) error
+	ListRideHistory(ctx context.Context, rideID int) ([]entity.StatusChange, error)
+	ListRides(ctx context.Context, query entity.RideQuery) ([]*entity.Ride, int, error)
 	FindActiveRideByDriver(ctx context.Context, driverID int) (*entity.Ride, error)
+	ListDueScheduledRides(ctx context.Context, dueBy time.Time) ([]*entity.Ride, error)
+}
+
+// RideDispatcher is told about every new ride so it can look for a driver.
+type RideDispatcher interface {
+	Dispatch(rideID int)
 }
 
 type RideService struct {
 	store          RideStore
 	passengerStore PassengerStore
 	driverStore    DriverStore
-	nextID         int
+	tx             Transactor
+	pricing        *PricingService
+	dispatcher     RideDispatcher
+	events         *EventBus
 }
 
-func NewRideService(store RideStore, passengerStore PassengerStore, driverStore DriverStore) *RideService {
+func NewRideService(store RideStore, passengerStore PassengerStore, driverStore DriverStore, tx Transactor, pricing *PricingService) *RideService {
 	return &RideService{
 		store:          store,
 		passengerStore: passengerStore,
 		driverStore:    driverStore,
-		nextID:         1,
+		tx:             tx,
+		pricing:        pricing,
 	}
 }
-func (s *RideService) CreateRide(ctx context.Context, passengerID int, origin, destination string) (*entity.Ride, error) {
-	if passengerID == 0 {
+func (s *RideService) SetDispatcher(dispatcher RideDispatcher) {
+	s.dispatcher = dispatcher
+}
+
+// SetEventBus makes the service publish ride events on bus.
+func (s *RideService) SetEventBus(bus *EventBus) {
+	s.events = bus
+}
+
+// CreateRide books a ride. When quoteID is not 0 the ride is booked at the
+// fare of that quote. A ride with a pickup time is scheduled instead of
+// dispatched; the Scheduler releases it ahead of pickup.
+func (s *RideService) CreateRide(ctx context.Context, ride *entity.Ride, quoteID int) (*entity.Ride, error) {
+	if ride == nil {
+		return nil, customErrors.ErrRideDataRequired
+	}
+	if ride.PassengerID == 0 {
 		return nil, customErrors.ErrPassengerIDRequired
 	}
-	if origin == "" {
-		return nil, customErrors.ErrOriginRequired
+
+	now := time.Now().UTC()
+	status, reason := entity.StatusPending, "ride requested"
+	v := validation.FromContext(ctx)
+	v.Trip(ride.Origin, ride.Destination)
+	if ride.ScheduledAt != nil {
+		scheduledAt, err := validateScheduledAt(*ride.ScheduledAt, now)
+		if v.Check(err == nil, "scheduled_at", err) {
+			ride.ScheduledAt = &scheduledAt
+			status, reason = entity.StatusScheduled, "ride scheduled"
+		}
 	}
-	if destination == "" {
-		return nil, customErrors.ErrDestinationRequired
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 
-	passenger, err := s.passengerStore.GetPassengerByID(ctx,passengerID)
+	passenger, err := s.passengerStore.GetPassengerByID(ctx, ride.PassengerID)
 	if err != nil {
 		return nil, err
 	}
 
-	ride := &entity.Ride{
-		RideID:      s.nextID,
-		PassengerID: passengerID,
-		Passenger:   passenger,
-		Origin:      origin,
-		Destination: destination,
-		Status:      entity.StatusPending,
+	ride.Passenger = passenger
+	ride.DriverID = 0
+	ride.Status = status
+	ride.CreatedAt = now
+	ride.DistanceKm = tripDistance(ride)
+	ride.AcceptedAt, ride.StartedAt, ride.CompletedAt, ride.CancelledAt = nil, nil, nil, nil
+	ride.FinalFare, ride.SurgeMultiplier, ride.CancellationFee = 0, 0, 0
+	ride.CancelledBy, ride.CancelReason = "", ""
+	if err := s.pricing.lockIn(ctx, ride, quoteID); err != nil {
+		return nil, err
 	}
-	s.nextID++
 
-	if err := s.store.SaveRide(ctx, ride); err != nil {
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.pricing.checkQuoteUnused(ctx, quoteID); err != nil {
+			return err
+		}
+		if err := s.store.SaveRide(ctx, ride); err != nil {
+			return err
+		}
+		err := s.store.AppendRideHistory(ctx, ride.RideID, entity.StatusChange{
+			To:     status,
+			Actor:  entity.ActorPassenger,
+			Reason: reason,
+			At:     ride.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		return s.pricing.useQuote(ctx, quoteID, now)
+	})
+	if err != nil {
 		return nil, err
 	}
+	created := *ride
+	s.events.Publish(entity.RideEvent{RideID: ride.RideID, Type: entity.EventRideCreated, At: ride.CreatedAt, Ride: &created})
+	if status == entity.StatusPending && s.dispatcher != nil {
+		s.dispatcher.Dispatch(ride.RideID)
+	}
 
 	return ride, nil
 }
@@ -71,54 +136,147 @@ func (s *RideService) GetRide(ctx context.Context, rideID int) (*entity.Ride, er
 	if err != nil {
 		return nil, err
 	}
-	passenger, err := s.passengerStore.GetPassengerByID(ctx,ride.PassengerID)
+	passenger, err := s.passengerStore.GetPassengerByID(ctx, ride.PassengerID)
 	if err == nil {
 		ride.Passenger = passenger
 	}
-	driver, err := s.driverStore.GetDriverByID(ctx,ride.DriverID)
+	driver, err := s.driverStore.GetDriverByID(ctx, ride.DriverID)
 	if err == nil {
 		ride.Driver = driver
 	}
 	return ride, nil
 }
 
-func (s *RideService) GetAllRides(ctx context.Context) ([]*entity.Ride, error) {
-	rides, err := s.store.GetAllRides(ctx)
+func (s *RideService) ListRides(ctx context.Context, query entity.RideQuery) ([]*entity.Ride, int, error) {
+	if query.Status != "" && !query.Status.IsValid() {
+		return nil, 0, &customErrors.FieldError{Field: "status", Err: customErrors.ErrInvalidRideStatus}
+	}
+	query.Page = normalizePage(query.Page)
+	rides, total, err := s.store.ListRides(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	for _, ride := range rides {
 		if err := ctx.Err(); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-		if passenger, err := s.passengerStore.GetPassengerByID(ctx,ride.PassengerID); err == nil {
+		if passenger, err := s.passengerStore.GetPassengerByID(ctx, ride.PassengerID); err == nil {
 			ride.Passenger = passenger
 		}
-		if driver, err := s.driverStore.GetDriverByID(ctx,ride.DriverID); err == nil {
+		if driver, err := s.driverStore.GetDriverByID(ctx, ride.DriverID); err == nil {
 			ride.Driver = driver
 		}
 	}
 
-	return rides, nil
+	return rides, total, nil
 }
 
-func (s *RideService) UpdateRideStatus(ctx context.Context, rideID int, status entity.Status) error {
+// UpdateRideStatus moves the ride to status and records the change in its
+// history. An empty actor is recorded as the system. A ride that becomes
+// pending is handed to the dispatcher. Rides are accepted by assigning a
+// driver (AssignDriverToRide or an offer), not through this method: statuses
+// that need a driver fail with ErrRideNotAssigned on a ride without one.
+func (s *RideService) UpdateRideStatus(ctx context.Context, rideID int, status entity.Status, actor entity.Actor, reason string) error {
 	if rideID == 0 {
 		return customErrors.ErrRideIDRequired
 	}
-	ride, err := s.store.FindRideByID(ctx, rideID)
+	if !status.IsValid() {
+		return customErrors.ErrInvalidRideStatus
+	}
+	if actor == "" {
+		actor = entity.ActorSystem
+	}
+	if !actor.IsValid() {
+		return customErrors.ErrInvalidActor
+	}
+	var change entity.StatusChange
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		ride, err := s.store.FindRideByID(ctx, rideID)
+		if err != nil {
+			return err
+		}
+		if !ride.Status.CanTransitionTo(status) {
+			return &customErrors.TransitionError{From: string(ride.Status), To: string(status)}
+		}
+		if status.RequiresDriver() && ride.DriverID == 0 {
+			return customErrors.ErrRideNotAssigned
+		}
+		now := time.Now().UTC()
+		change = entity.StatusChange{From: ride.Status, To: status, Actor: actor, Reason: reason, At: now}
+		ride.Status = status
+		switch status {
+		case entity.StatusInProgress:
+			ride.StartedAt = &now
+		case entity.StatusCompleted:
+			fare := s.pricing.finalFare(ride, now)
+			ride.CompletedAt = &now
+			ride.Currency = fare.Currency
+			ride.FinalFare = fare.Total
+		case entity.StatusCancelled:
+			ride.CancelledAt = &now
+			ride.CancelledBy = actor
+		}
+		if err := s.store.UpdateRide(ctx, ride); err != nil {
+			return err
+		}
+		if err := s.store.AppendRideHistory(ctx, rideID, change); err != nil {
+			return err
+		}
+		if status.IsTerminal() && ride.DriverID != 0 {
+			return s.releaseDriver(ctx, ride.DriverID)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	if ride.Status == entity.StatusCompleted && status != entity.StatusCompleted {
-		return customErrors.ErrCannotChangeCompletedRide
+	s.events.Publish(statusEvent(rideID, change))
+	if status == entity.StatusPending && s.dispatcher != nil {
+		s.dispatcher.Dispatch(rideID)
 	}
-	if !status.IsValid() {
-		return customErrors.ErrInvalidRideStatus
+	return nil
+}
+
+// tripDistance is the straight-line length of the ride, or 0 when either
+// end has no coordinates.
+func tripDistance(ride *entity.Ride) float64 {
+	if ride.Origin.HasCoordinates() && ride.Destination.HasCoordinates() {
+		return ride.Origin.DistanceKm(ride.Destination)
 	}
-	ride.Status = status
-	return s.store.UpdateRideStatus(ctx, rideID, ride.Status)
+	return 0
+}
+
+// releaseDriver makes the driver of a finished ride available again, unless
+// they went offline during the ride.
+func (s *RideService) releaseDriver(ctx context.Context, driverID int) error {
+	driver, err := s.driverStore.GetDriverByID(ctx, driverID)
+	if errors.Is(err, customErrors.ErrDriverNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.driverStore.UpdateDriverAvailability(ctx, driverID, driver.IsOnline, driver.IsOnline)
+}
+
+// GetRideHistory returns every status change of the ride, oldest first.
+func (s *RideService) GetRideHistory(ctx context.Context, rideID int) ([]entity.StatusChange, error) {
+	if rideID == 0 {
+		return nil, customErrors.ErrRideIDRequired
+	}
+	return s.store.ListRideHistory(ctx, rideID)
+}
+
+func (s *RideService) GetAllowedTransitions(ctx context.Context, rideID int) (*entity.Ride, []entity.Status, error) {
+	if rideID == 0 {
+		return nil, nil, customErrors.ErrRideIDRequired
+	}
+	ride, err := s.store.FindRideByID(ctx, rideID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ride, ride.Status.NextStatuses(), nil
 }
 
 func (s *RideService) AssignDriverToRide(ctx context.Context, rideID, driverID int) error {
@@ -128,24 +286,63 @@ func (s *RideService) AssignDriverToRide(ctx context.Context, rideID, driverID i
 	if driverID == 0 {
 		return customErrors.ErrDriverIDRequired
 	}
-	ride, err := s.store.FindRideByID(ctx, rideID)
+	var change entity.StatusChange
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		ride, err := s.store.FindRideByID(ctx, rideID)
+		if err != nil {
+			return err
+		}
+		if ride.DriverID != 0 {
+			if ride.DriverID == driverID {
+				return customErrors.ErrDriverAlreadyAssignedToRide
+			}
+			return customErrors.ErrRideAlreadyAssigned
+		}
+		if ride.Status != entity.StatusPending {
+			return customErrors.ErrCannotAssignDriverToNonPendingRide
+		}
+		driver, err := s.driverStore.GetDriverByID(ctx, driverID)
+		if err != nil {
+			return err
+		}
+		existingRide, err := s.store.FindActiveRideByDriver(ctx, driverID)
+		if err == nil && existingRide != nil {
+			return customErrors.ErrDriverAlreadyOnActiveRide
+		}
+		if !driver.IsAvailable {
+			return customErrors.ErrDriverUnavailable
+		}
+		// The checks above give early, precise errors; the store repeats the
+		// ride and active-ride checks atomically with the write, which is what
+		// actually prevents double booking.
+		change = entity.StatusChange{
+			From:   entity.StatusPending,
+			To:     entity.StatusAccepted,
+			Actor:  entity.ActorDriver,
+			Reason: "driver assigned",
+			At:     time.Now().UTC(),
+		}
+		if err := s.store.AssignDriverToRide(ctx, rideID, driverID, change.At); err != nil {
+			return err
+		}
+		// The memory stores cannot roll back, so if a later write fails
+		// (say, the context is cancelled) the assignment is undone by hand
+		// rather than leaving the driver booked on a ride with no history.
+		undo := context.WithoutCancel(ctx)
+		if err := s.driverStore.UpdateDriverAvailability(ctx, driverID, driver.IsOnline, false); err != nil {
+			s.store.UnassignDriverFromRide(undo, rideID, driverID)
+			return err
+		}
+		if err := s.store.AppendRideHistory(ctx, rideID, change); err != nil {
+			s.driverStore.UpdateDriverAvailability(undo, driverID, driver.IsOnline, driver.IsAvailable)
+			s.store.UnassignDriverFromRide(undo, rideID, driverID)
+			return err
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	if ride.DriverID != 0 {
-		if ride.DriverID == driverID {
-			return customErrors.ErrDriverAlreadyAssignedToRide
-		}
-		return customErrors.ErrRideAlreadyAssigned
-	}
-	if ride.Status != entity.StatusPending {
-		return customErrors.ErrCannotAssignDriverToNonPendingRide
-	}
-	existingRide, err := s.store.FindActiveRideByDriver(ctx, driverID)
-	if err == nil && existingRide != nil {
-		return customErrors.ErrDriverAlreadyOnActiveRide
-	}
-	ride.DriverID = driverID
-	ride.Status = entity.StatusAccepted
-	return s.store.AssignDriverToRide(ctx, rideID, driverID)
+	s.events.Publish(entity.RideEvent{RideID: rideID, Type: entity.EventRideAssigned, At: change.At, Change: &change, DriverID: driverID})
+	return nil
 }
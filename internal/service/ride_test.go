@@ -0,0 +1,387 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/pricing"
+	"taxiAPI/internal/storage"
+)
+
+// noTransaction runs fn directly, leaving the store as the only guard.
+type noTransaction struct{}
+
+func (noTransaction) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestAssignDriverToRideConcurrentNeverDoubleBooks(t *testing.T) {
+	const (
+		numRides    = 40
+		numDrivers  = 15
+		numAttempts = 800
+	)
+
+	for name, tx := range map[string]Transactor{
+		"transaction":    storage.NewTransactor(),
+		"no transaction": noTransaction{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			rideStore, passengerStore, driverStore := storage.NewRide(), storage.NewPassenger(), storage.NewDriver()
+			engine := pricing.NewEngine(pricing.DefaultConfig())
+			surge := NewSurgeService(engine, rideStore, driverStore, storage.NewLocation())
+			prices := NewPricingService(engine, storage.NewQuote(), surge, DefaultQuoteTTL)
+			rides := NewRideService(rideStore, passengerStore, driverStore, tx, prices)
+			drivers := NewDriverService(driverStore, rideStore, storage.NewLocation(), tx, "US")
+
+			passenger, err := passengerStore.RegisterPassenger(ctx, &entity.Passenger{FirstName: "John", LastName: "Doe", PhoneNumber: "+14155550100"})
+			if err != nil {
+				t.Fatalf("RegisterPassenger: %v", err)
+			}
+			for i := 0; i < numDrivers; i++ {
+				driver := &entity.Driver{FirstName: "Alex", LastName: "Smith", PhoneNumber: fmt.Sprintf("+1415555%04d", 100+i), CarType: "Prius", LicensePlate: 1}
+				if _, err := drivers.RegisterDriver(ctx, driver, "secret-password"); err != nil {
+					t.Fatalf("RegisterDriver: %v", err)
+				}
+			}
+			for i := 0; i < numRides; i++ {
+				ride := &entity.Ride{PassengerID: passenger.PassengerID, Origin: entity.Location{Address: "A"}, Destination: entity.Location{Address: "B"}}
+				if _, err := rides.CreateRide(ctx, ride, 0); err != nil {
+					t.Fatalf("CreateRide: %v", err)
+				}
+			}
+
+			var (
+				wg        sync.WaitGroup
+				start     = make(chan struct{})
+				succeeded atomic.Int64
+			)
+			for i := 0; i < numAttempts; i++ {
+				rideID := 1 + i%numRides
+				driverID := 1 + (i*7+i/numRides)%numDrivers
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					err := rides.AssignDriverToRide(ctx, rideID, driverID)
+					switch {
+					case err == nil:
+						succeeded.Add(1)
+					case errors.Is(err, customErrors.ErrDriverAlreadyOnActiveRide),
+						errors.Is(err, customErrors.ErrDriverUnavailable),
+						errors.Is(err, customErrors.ErrRideAlreadyAssigned),
+						errors.Is(err, customErrors.ErrDriverAlreadyAssignedToRide),
+						errors.Is(err, customErrors.ErrCannotAssignDriverToNonPendingRide):
+					default:
+						t.Errorf("AssignDriverToRide(%d, %d): unexpected error %v", rideID, driverID, err)
+					}
+				}()
+			}
+			close(start)
+			wg.Wait()
+
+			all, _, err := rideStore.ListRides(ctx, entity.RideQuery{})
+			if err != nil {
+				t.Fatalf("ListRides: %v", err)
+			}
+			activeByDriver := make(map[int]int)
+			assigned := 0
+			for _, ride := range all {
+				if ride.DriverID == 0 {
+					if ride.Status != entity.StatusPending {
+						t.Errorf("ride %d has no driver but status %q", ride.RideID, ride.Status)
+					}
+					continue
+				}
+				assigned++
+				if ride.Status != entity.StatusAccepted {
+					t.Errorf("ride %d has a driver but status %q", ride.RideID, ride.Status)
+				}
+				activeByDriver[ride.DriverID]++
+			}
+			for driverID, n := range activeByDriver {
+				if n > 1 {
+					t.Errorf("driver %d is on %d active rides", driverID, n)
+				}
+				driver, err := driverStore.GetDriverByID(ctx, driverID)
+				if err != nil {
+					t.Fatalf("GetDriverByID: %v", err)
+				}
+				if driver.IsAvailable {
+					t.Errorf("driver %d is on a ride but still available", driverID)
+				}
+			}
+			if int(succeeded.Load()) != assigned {
+				t.Errorf("%d assignments reported success but %d rides are assigned", succeeded.Load(), assigned)
+			}
+			if assigned != numDrivers {
+				t.Errorf("expected every one of the %d drivers to end up on a ride, got %d", numDrivers, assigned)
+			}
+		})
+	}
+}
+
+func TestAssignDriverToRideTwoDriversAtOnce(t *testing.T) {
+	rt := newRideTest(t)
+	ride := rt.createRide(t)
+	first, second := rt.addDriver(t), rt.addDriver(t)
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make(map[int]error)
+	var mu sync.Mutex
+	for _, driver := range []*entity.Driver{first, second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			err := rt.rides.AssignDriverToRide(rt.ctx, ride.RideID, driver.DriverID)
+			mu.Lock()
+			errs[driver.DriverID] = err
+			mu.Unlock()
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	winner, loser := first, second
+	if errs[first.DriverID] != nil {
+		winner, loser = second, first
+	}
+	if errs[winner.DriverID] != nil || !errors.Is(errs[loser.DriverID], customErrors.ErrRideAlreadyAssigned) {
+		t.Fatalf("errors = %v, want exactly one success and one ErrRideAlreadyAssigned", errs)
+	}
+	if got := rt.ride(t, ride.RideID); got.DriverID != winner.DriverID || got.Status != entity.StatusAccepted {
+		t.Fatalf("ride = driver %d, %s; want driver %d, accepted", got.DriverID, got.Status, winner.DriverID)
+	}
+	if !rt.driver(t, loser.DriverID).IsAvailable {
+		t.Error("the driver who lost the race should still be available")
+	}
+	if rt.driver(t, winner.DriverID).IsAvailable {
+		t.Error("the assigned driver should no longer be available")
+	}
+}
+
+// failingHistory is a ride store whose history cannot be written.
+type failingHistory struct {
+	*storage.Ride
+}
+
+func (failingHistory) AppendRideHistory(context.Context, int, entity.StatusChange) error {
+	return errors.New("history unavailable")
+}
+
+func TestAssignDriverToRideUndoesOnError(t *testing.T) {
+	rt := newRideTest(t)
+	ride := rt.createRide(t)
+	driver := rt.addDriver(t)
+	rides := NewRideService(failingHistory{rt.rideStore}, storage.NewPassenger(), rt.drivers, storage.NewTransactor(), nil)
+
+	if err := rides.AssignDriverToRide(rt.ctx, ride.RideID, driver.DriverID); err == nil {
+		t.Fatal("AssignDriverToRide succeeded although the history could not be written")
+	}
+	if got := rt.ride(t, ride.RideID); got.DriverID != 0 || got.Status != entity.StatusPending || got.AcceptedAt != nil {
+		t.Fatalf("ride = driver %d, %s, accepted at %v; want it unassigned and pending", got.DriverID, got.Status, got.AcceptedAt)
+	}
+	if !rt.driver(t, driver.DriverID).IsAvailable {
+		t.Fatal("driver left unavailable by a failed assignment")
+	}
+	if err := rt.rides.AssignDriverToRide(rt.ctx, ride.RideID, driver.DriverID); err != nil {
+		t.Fatalf("retrying the assignment: %v", err)
+	}
+}
+
+// rideTest is a RideService on the memory stores with one passenger.
+type rideTest struct {
+	ctx        context.Context
+	rideStore  *storage.Ride
+	drivers    *storage.Driver
+	locations  *storage.Location
+	offerStore *storage.Offer
+	rides      *RideService
+	passenger  *entity.Passenger
+	numDrivers int
+}
+
+func newRideTest(t *testing.T) *rideTest {
+	t.Helper()
+	ctx := context.Background()
+	rideStore, passengerStore, driverStore, locationStore := storage.NewRide(), storage.NewPassenger(), storage.NewDriver(), storage.NewLocation()
+	engine := pricing.NewEngine(pricing.DefaultConfig())
+	surge := NewSurgeService(engine, rideStore, driverStore, locationStore)
+	prices := NewPricingService(engine, storage.NewQuote(), surge, DefaultQuoteTTL)
+	passenger, err := passengerStore.RegisterPassenger(ctx, &entity.Passenger{FirstName: "John", LastName: "Doe", PhoneNumber: "+14155550100"})
+	if err != nil {
+		t.Fatalf("RegisterPassenger: %v", err)
+	}
+	return &rideTest{
+		ctx:        ctx,
+		rideStore:  rideStore,
+		drivers:    driverStore,
+		locations:  locationStore,
+		offerStore: storage.NewOffer(),
+		rides:      NewRideService(rideStore, passengerStore, driverStore, storage.NewTransactor(), prices),
+		passenger:  passenger,
+	}
+}
+
+// addDriver registers an online, available driver straight in the store,
+// skipping password hashing.
+func (rt *rideTest) addDriver(t *testing.T) *entity.Driver {
+	t.Helper()
+	rt.numDrivers++
+	driver, err := rt.drivers.RegisterDriver(rt.ctx, &entity.Driver{
+		FirstName: "Alex", LastName: "Smith", PhoneNumber: fmt.Sprintf("+1415555%04d", 200+rt.numDrivers),
+		CarType: "Prius", LicensePlate: 1, IsOnline: true, IsAvailable: true,
+	})
+	if err != nil {
+		t.Fatalf("RegisterDriver: %v", err)
+	}
+	return driver
+}
+
+// createRide books a pending ride across Berlin for the passenger.
+func (rt *rideTest) createRide(t *testing.T) *entity.Ride {
+	t.Helper()
+	ride, err := rt.rides.CreateRide(rt.ctx, &entity.Ride{
+		PassengerID: rt.passenger.PassengerID,
+		Origin:      entity.Location{Latitude: 52.52, Longitude: 13.405},
+		Destination: entity.Location{Latitude: 52.50, Longitude: 13.45},
+	}, 0)
+	if err != nil {
+		t.Fatalf("CreateRide: %v", err)
+	}
+	return ride
+}
+
+// ride returns the ride as stored.
+func (rt *rideTest) ride(t *testing.T, rideID int) *entity.Ride {
+	t.Helper()
+	ride, err := rt.rideStore.FindRideByID(rt.ctx, rideID)
+	if err != nil {
+		t.Fatalf("FindRideByID: %v", err)
+	}
+	return ride
+}
+
+// driver returns the driver as stored.
+func (rt *rideTest) driver(t *testing.T, driverID int) *entity.Driver {
+	t.Helper()
+	driver, err := rt.drivers.GetDriverByID(rt.ctx, driverID)
+	if err != nil {
+		t.Fatalf("GetDriverByID: %v", err)
+	}
+	return driver
+}
+
+func TestUpdateRideStatusTransitions(t *testing.T) {
+	allowed := map[entity.Status][]entity.Status{
+		entity.StatusScheduled:      {entity.StatusPending, entity.StatusCancelled},
+		entity.StatusPending:        {entity.StatusCancelled},
+		entity.StatusAccepted:       {entity.StatusDriverArriving, entity.StatusInProgress, entity.StatusCancelled},
+		entity.StatusDriverArriving: {entity.StatusInProgress, entity.StatusNoShow, entity.StatusCancelled},
+		entity.StatusInProgress:     {entity.StatusCompleted},
+	}
+	statuses := []entity.Status{
+		entity.StatusScheduled, entity.StatusPending, entity.StatusAccepted, entity.StatusDriverArriving,
+		entity.StatusInProgress, entity.StatusCompleted, entity.StatusCancelled, entity.StatusNoShow,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			t.Run(fmt.Sprintf("%s to %s", from, to), func(t *testing.T) {
+				rt := newRideTest(t)
+				ride, driver := rt.createRide(t), rt.addDriver(t)
+				// Rides past pending got there with a driver.
+				if from != entity.StatusScheduled && from != entity.StatusPending {
+					if err := rt.rides.AssignDriverToRide(rt.ctx, ride.RideID, driver.DriverID); err != nil {
+						t.Fatalf("AssignDriverToRide: %v", err)
+					}
+				}
+				stored := rt.ride(t, ride.RideID)
+				stored.Status = from
+				if err := rt.rideStore.UpdateRide(rt.ctx, stored); err != nil {
+					t.Fatal(err)
+				}
+
+				err := rt.rides.UpdateRideStatus(rt.ctx, ride.RideID, to, entity.ActorSystem, "")
+				want := false
+				for _, next := range allowed[from] {
+					want = want || next == to
+				}
+				var transitionErr *customErrors.TransitionError
+				switch {
+				case want && err != nil:
+					t.Fatalf("UpdateRideStatus = %v, want success", err)
+				case from == entity.StatusPending && to == entity.StatusAccepted:
+					if !errors.Is(err, customErrors.ErrRideNotAssigned) {
+						t.Fatalf("accepting without a driver: got %v, want ErrRideNotAssigned", err)
+					}
+				case !want && !errors.As(err, &transitionErr):
+					t.Fatalf("UpdateRideStatus = %v, want a TransitionError", err)
+				}
+
+				wantStatus := to
+				if err != nil {
+					wantStatus = from
+				}
+				if got := rt.ride(t, ride.RideID); got.Status != wantStatus {
+					t.Fatalf("status = %s after %v, want %s", got.Status, err, wantStatus)
+				}
+				history, _ := rt.rides.GetRideHistory(rt.ctx, ride.RideID)
+				if last := history[len(history)-1]; (err == nil) != (last.From == from && last.To == to && last.Actor == entity.ActorSystem) {
+					t.Fatalf("last history entry %+v after %v", last, err)
+				}
+			})
+		}
+	}
+}
+
+func TestRideHistoryRecordsLifecycle(t *testing.T) {
+	rt := newRideTest(t)
+	ride, driver := rt.createRide(t), rt.addDriver(t)
+	if err := rt.rides.AssignDriverToRide(rt.ctx, ride.RideID, driver.DriverID); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+	if err := rt.rides.UpdateRideStatus(rt.ctx, ride.RideID, entity.StatusDriverArriving, entity.ActorDriver, "on my way"); err != nil {
+		t.Fatalf("UpdateRideStatus: %v", err)
+	}
+	// A rejected change leaves no entry.
+	if err := rt.rides.UpdateRideStatus(rt.ctx, ride.RideID, entity.StatusCompleted, entity.ActorDriver, ""); err == nil {
+		t.Fatal("driver_arriving -> completed accepted")
+	}
+	if _, err := rt.rides.CancelRide(rt.ctx, ride.RideID, entity.ActorPassenger, entity.CancelChangedPlans, "meeting moved"); err != nil {
+		t.Fatalf("CancelRide: %v", err)
+	}
+
+	history, err := rt.rides.GetRideHistory(rt.ctx, ride.RideID)
+	if err != nil {
+		t.Fatalf("GetRideHistory: %v", err)
+	}
+	want := []entity.StatusChange{
+		{To: entity.StatusPending, Actor: entity.ActorPassenger, Reason: "ride requested"},
+		{From: entity.StatusPending, To: entity.StatusAccepted, Actor: entity.ActorDriver, Reason: "driver assigned"},
+		{From: entity.StatusAccepted, To: entity.StatusDriverArriving, Actor: entity.ActorDriver, Reason: "on my way"},
+		{From: entity.StatusDriverArriving, To: entity.StatusCancelled, Actor: entity.ActorPassenger, Reason: "changed_plans: meeting moved"},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("history = %+v, want %d entries", history, len(want))
+	}
+	for i, change := range history {
+		if i > 0 && change.At.Before(history[i-1].At) {
+			t.Errorf("entry %d at %v is before entry %d at %v", i, change.At, i-1, history[i-1].At)
+		}
+		change.At = time.Time{}
+		if change != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, change, want[i])
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/validation"
+	"time"
+)
+
+// MaxScheduleAhead is how far in advance a ride can be booked.
+const MaxScheduleAhead = 30 * 24 * time.Hour
+
+type SchedulerConfig struct {
+	// LeadTime is how long before pickup a scheduled ride is released to
+	// dispatch.
+	LeadTime time.Duration
+	// PollInterval is how often the store is checked for rides to release.
+	PollInterval time.Duration
+	// ExpireAfter is how long past its pickup time a ride that was never
+	// released, e.g. because the server was down, is still dispatched.
+	// Older rides are cancelled instead.
+	ExpireAfter time.Duration
+}
+
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		LeadTime:     15 * time.Minute,
+		PollInterval: 15 * time.Second,
+		ExpireAfter:  30 * time.Minute,
+	}
+}
+
+// validateScheduledAt checks a requested pickup time and returns it in UTC.
+func validateScheduledAt(scheduledAt, now time.Time) (time.Time, error) {
+	if !scheduledAt.After(now) || scheduledAt.Sub(now) > MaxScheduleAhead {
+		return time.Time{}, customErrors.ErrInvalidScheduledAt
+	}
+	return scheduledAt.UTC(), nil
+}
+
+// EditScheduledRide changes the trip or pickup time of a ride that has not
+// been released for dispatch yet. The ride is priced again, at the fare of
+// the quote when quoteID is not 0.
+func (s *RideService) EditScheduledRide(ctx context.Context, rideID int, edit entity.RideEdit, quoteID int) (*entity.Ride, error) {
+	if rideID == 0 {
+		return nil, customErrors.ErrRideIDRequired
+	}
+	var updated entity.Ride
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		ride, err := s.store.FindRideByID(ctx, rideID)
+		if err != nil {
+			return err
+		}
+		if ride.Status != entity.StatusScheduled {
+			return customErrors.ErrRideNotScheduled
+		}
+		if edit.Origin != nil {
+			ride.Origin = *edit.Origin
+		}
+		if edit.Destination != nil {
+			ride.Destination = *edit.Destination
+		}
+		if edit.CarType != nil {
+			ride.CarType = *edit.CarType
+		}
+		v := validation.FromContext(ctx)
+		v.Trip(ride.Origin, ride.Destination)
+		if edit.ScheduledAt != nil {
+			scheduledAt, err := validateScheduledAt(*edit.ScheduledAt, time.Now())
+			if v.Check(err == nil, "scheduled_at", err) {
+				ride.ScheduledAt = &scheduledAt
+			}
+		}
+		if err := v.Err(); err != nil {
+			return err
+		}
+
+		ride.DistanceKm = tripDistance(ride)
+		ride.Currency, ride.QuotedFare, ride.SurgeMultiplier = "", 0, 0
+		if err := s.pricing.lockIn(ctx, ride, quoteID); err != nil {
+			return err
+		}
+		if err := s.store.UpdateRide(ctx, ride); err != nil {
+			return err
+		}
+		if err := s.pricing.useQuote(ctx, quoteID, time.Now().UTC()); err != nil {
+			return err
+		}
+		updated = *ride
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.events.Publish(entity.RideEvent{RideID: rideID, Type: entity.EventRideUpdated, Ride: &updated})
+	return s.GetRide(ctx, rideID)
+}
+
+// Scheduler releases scheduled rides to dispatch LeadTime before their
+// pickup time. It keeps no state of its own, so rides scheduled before a
+// restart are picked up again from the store.
+type Scheduler struct {
+	rides  *RideService
+	config SchedulerConfig
+	// now is the clock rides are released by; tests replace it.
+	now func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(rides *RideService, config SchedulerConfig) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		rides:  rides,
+		config: config,
+		now:    time.Now,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start releases the rides that are already due and then keeps checking
+// every PollInterval until Stop is called.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.config.PollInterval)
+		defer ticker.Stop()
+		for {
+			s.releaseDue(s.ctx, s.now())
+			select {
+			case <-ticker.C:
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the polling loop and waits for it to exit.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// releaseDue moves every scheduled ride whose pickup is less than LeadTime
+// away to pending, or cancels it when its pickup is long past.
+func (s *Scheduler) releaseDue(ctx context.Context, now time.Time) {
+	rides, err := s.rides.store.ListDueScheduledRides(ctx, now.Add(s.config.LeadTime))
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("scheduler: list due rides", "err", err)
+		}
+		return
+	}
+	for _, ride := range rides {
+		if ctx.Err() != nil {
+			return
+		}
+		if now.Sub(*ride.ScheduledAt) > s.config.ExpireAfter {
+			slog.Debug("scheduler: cancelling expired ride", "ride_id", ride.RideID, "scheduled_at", ride.ScheduledAt)
+			_, err = s.rides.CancelRide(ctx, ride.RideID, entity.ActorSystem, entity.CancelOther, "pickup time passed before the ride was released")
+		} else {
+			slog.Debug("scheduler: releasing ride", "ride_id", ride.RideID, "scheduled_at", ride.ScheduledAt)
+			err = s.rides.UpdateRideStatus(ctx, ride.RideID, entity.StatusPending, entity.ActorSystem, "released for dispatch")
+		}
+		// A ride cancelled in the meantime fails the transition check.
+		if err != nil && !errors.Is(err, customErrors.ErrInvalidStatusTransition) && ctx.Err() == nil {
+			slog.Error("scheduler: release ride", "ride_id", ride.RideID, "err", err)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+// scheduleRide books a ride for pickup at scheduledAt.
+func (rt *rideTest) scheduleRide(t *testing.T, scheduledAt time.Time) *entity.Ride {
+	t.Helper()
+	ride, err := rt.rides.CreateRide(rt.ctx, &entity.Ride{
+		PassengerID: rt.passenger.PassengerID,
+		Origin:      entity.Location{Latitude: 52.52, Longitude: 13.405},
+		Destination: entity.Location{Latitude: 52.50, Longitude: 13.45},
+		ScheduledAt: &scheduledAt,
+	}, 0)
+	if err != nil {
+		t.Fatalf("CreateRide: %v", err)
+	}
+	if ride.Status != entity.StatusScheduled {
+		t.Fatalf("status = %s, want scheduled", ride.Status)
+	}
+	return ride
+}
+
+func TestValidateScheduledAt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+	for _, tc := range []struct {
+		name        string
+		scheduledAt time.Time
+		ok          bool
+	}{
+		{"in the past", now.Add(-time.Minute), false},
+		{"now", now, false},
+		{"in a minute", now.Add(time.Minute), true},
+		{"at the limit", now.Add(MaxScheduleAhead), true},
+		{"past the limit", now.Add(MaxScheduleAhead + time.Second), false},
+		{"other time zone", now.Add(time.Hour).In(tokyo), true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := validateScheduledAt(tc.scheduledAt, now)
+			if !tc.ok {
+				if !errors.Is(err, customErrors.ErrInvalidScheduledAt) {
+					t.Fatalf("validateScheduledAt = %v, %v; want ErrInvalidScheduledAt", got, err)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tc.scheduledAt) || got.Location() != time.UTC {
+				t.Fatalf("validateScheduledAt = %v, %v; want %v in UTC", got, err, tc.scheduledAt)
+			}
+		})
+	}
+}
+
+func TestSchedulerReleasesDueRides(t *testing.T) {
+	rt := newRideTest(t)
+	dispatcher := &recordingDispatcher{}
+	rt.rides.SetDispatcher(dispatcher)
+	config := DefaultSchedulerConfig()
+	scheduler := NewScheduler(rt.rides, config)
+
+	start := time.Now().UTC()
+	soon := rt.scheduleRide(t, start.Add(time.Hour))
+	later := rt.scheduleRide(t, start.Add(3*time.Hour))
+	missed := rt.scheduleRide(t, start.Add(2*time.Hour))
+
+	// Not due yet: the ride is more than LeadTime away.
+	scheduler.releaseDue(rt.ctx, start.Add(time.Hour-config.LeadTime-time.Minute))
+	if got := rt.ride(t, soon.RideID); got.Status != entity.StatusScheduled {
+		t.Fatalf("ride released %s early", got.Status)
+	}
+
+	scheduler.releaseDue(rt.ctx, start.Add(time.Hour-config.LeadTime))
+	if got := rt.ride(t, soon.RideID); got.Status != entity.StatusPending {
+		t.Fatalf("due ride is %s, want pending", got.Status)
+	}
+	if len(dispatcher.rides) != 1 || dispatcher.rides[0] != soon.RideID {
+		t.Fatalf("dispatched %v, want ride %d", dispatcher.rides, soon.RideID)
+	}
+
+	// The server was down past the missed ride's pickup time; the later
+	// ride is still within ExpireAfter of its own.
+	scheduler.releaseDue(rt.ctx, start.Add(3*time.Hour+config.ExpireAfter))
+	got := rt.ride(t, missed.RideID)
+	if got.Status != entity.StatusCancelled || got.CancelledBy != entity.ActorSystem || got.CancelReason != entity.CancelOther {
+		t.Fatalf("missed ride is %s by %q (%s), want cancelled by the system", got.Status, got.CancelledBy, got.CancelReason)
+	}
+	if got := rt.ride(t, later.RideID); got.Status != entity.StatusPending {
+		t.Fatalf("late but not expired ride is %s, want pending", got.Status)
+	}
+	if len(dispatcher.rides) != 2 {
+		t.Fatalf("dispatched %v, want the two released rides", dispatcher.rides)
+	}
+
+	// Releasing again finds nothing left to do.
+	scheduler.releaseDue(rt.ctx, start.Add(4*time.Hour))
+	if len(dispatcher.rides) != 2 {
+		t.Fatalf("dispatched %v after a second pass", dispatcher.rides)
+	}
+}
+
+func TestSchedulerStartUsesClock(t *testing.T) {
+	rt := newRideTest(t)
+	config := DefaultSchedulerConfig()
+	config.PollInterval = time.Millisecond
+	scheduler := NewScheduler(rt.rides, config)
+
+	ride := rt.scheduleRide(t, time.Now().Add(24*time.Hour))
+	var now atomic.Pointer[time.Time]
+	frozen := time.Now()
+	now.Store(&frozen)
+	scheduler.now = func() time.Time { return *now.Load() }
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	time.Sleep(10 * time.Millisecond)
+	if got := rt.ride(t, ride.RideID); got.Status != entity.StatusScheduled {
+		t.Fatalf("ride is %s a day before pickup", got.Status)
+	}
+	due := ride.ScheduledAt.Add(-config.LeadTime)
+	now.Store(&due)
+	deadline := time.Now().Add(time.Second)
+	for rt.ride(t, ride.RideID).Status != entity.StatusPending {
+		if time.Now().After(deadline) {
+			t.Fatal("the scheduler did not release the ride once its clock reached LeadTime before pickup")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestEditScheduledRide(t *testing.T) {
+	rt := newRideTest(t)
+	scheduled := rt.scheduleRide(t, time.Now().Add(time.Hour))
+
+	pickup := time.Now().Add(2 * time.Hour)
+	destination := entity.Location{Latitude: 52.40, Longitude: 13.06}
+	edited, err := rt.rides.EditScheduledRide(rt.ctx, scheduled.RideID, entity.RideEdit{ScheduledAt: &pickup, Destination: &destination}, 0)
+	if err != nil {
+		t.Fatalf("EditScheduledRide: %v", err)
+	}
+	if !edited.ScheduledAt.Equal(pickup) || edited.Destination != destination || edited.DistanceKm <= scheduled.DistanceKm {
+		t.Fatalf("edited ride = %+v", edited)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		edit   entity.RideEdit
+		fields []string
+	}{
+		{"too far ahead", entity.RideEdit{ScheduledAt: ptr(time.Now().Add(MaxScheduleAhead + time.Hour))}, []string{"scheduled_at"}},
+		{"in the past", entity.RideEdit{ScheduledAt: ptr(time.Now().Add(-time.Minute))}, []string{"scheduled_at"}},
+		{"same place", entity.RideEdit{Destination: &edited.Origin, ScheduledAt: ptr(time.Now())}, []string{"destination", "scheduled_at"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := rt.rides.EditScheduledRide(rt.ctx, scheduled.RideID, tc.edit, 0)
+			var validationErr *customErrors.ValidationError
+			if !errors.As(err, &validationErr) || len(validationErr.Errors) != len(tc.fields) {
+				t.Fatalf("EditScheduledRide = %v, want errors for %v", err, tc.fields)
+			}
+			for i, field := range tc.fields {
+				if validationErr.Errors[i].Field != field {
+					t.Errorf("errors[%d] = %v, want %s", i, validationErr.Errors[i], field)
+				}
+			}
+			if got := rt.ride(t, scheduled.RideID); !got.ScheduledAt.Equal(pickup) || got.Destination != destination {
+				t.Fatalf("a rejected edit changed the ride: %+v", got)
+			}
+		})
+	}
+
+	pending := rt.createRide(t)
+	if _, err := rt.rides.EditScheduledRide(rt.ctx, pending.RideID, entity.RideEdit{ScheduledAt: &pickup}, 0); !errors.Is(err, customErrors.ErrRideNotScheduled) {
+		t.Fatalf("editing a pending ride: got %v, want ErrRideNotScheduled", err)
+	}
+	scheduler := NewScheduler(rt.rides, DefaultSchedulerConfig())
+	scheduler.releaseDue(rt.ctx, pickup)
+	if _, err := rt.rides.EditScheduledRide(rt.ctx, scheduled.RideID, entity.RideEdit{ScheduledAt: &pickup}, 0); !errors.Is(err, customErrors.ErrRideNotScheduled) {
+		t.Fatalf("editing a released ride: got %v, want ErrRideNotScheduled", err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"taxiAPI/internal/entity"
+	"taxiAPI/internal/geo"
+	"taxiAPI/internal/pricing"
+	"time"
+)
+
+// SurgeService computes surge multipliers per grid cell. A multiplier is
+// cached until it expires so everyone asking for the same cell within that
+// time sees, and is quoted, the same surge.
+type SurgeService struct {
+	engine        *pricing.Engine
+	rideStore     RideStore
+	driverStore   DriverStore
+	locationStore LocationStore
+
+	mutex sync.Mutex
+	cells map[geo.Cell]*entity.Surge
+}
+
+func NewSurgeService(engine *pricing.Engine, rideStore RideStore, driverStore DriverStore, locationStore LocationStore) *SurgeService {
+	return &SurgeService{
+		engine:        engine,
+		rideStore:     rideStore,
+		driverStore:   driverStore,
+		locationStore: locationStore,
+		cells:         make(map[geo.Cell]*entity.Surge),
+	}
+}
+
+// GetSurge returns the surge in effect at loc.
+func (s *SurgeService) GetSurge(ctx context.Context, loc entity.Location) (*entity.Surge, error) {
+	if err := validateLocation("", loc); err != nil {
+		return nil, err
+	}
+	config := s.engine.Surge()
+	cell := geo.CellAt(loc, config.CellSizeDeg)
+	now := time.Now().UTC()
+
+	s.mutex.Lock()
+	cached, ok := s.cells[cell]
+	s.mutex.Unlock()
+	if ok && now.Before(cached.ExpiresAt) {
+		copied := *cached
+		return &copied, nil
+	}
+
+	pending, err := s.pendingRides(ctx, cell, now.Add(-config.Window))
+	if err != nil {
+		return nil, err
+	}
+	available, err := s.availableDrivers(ctx, cell, now.Add(-locationMaxAge))
+	if err != nil {
+		return nil, err
+	}
+	surge := &entity.Surge{
+		Multiplier:       s.engine.SurgeMultiplier(pending, available),
+		PendingRides:     pending,
+		AvailableDrivers: available,
+		ComputedAt:       now,
+		ExpiresAt:        now.Add(config.TTL),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// Another request may have computed the cell in the meantime; keep the
+	// first result so the multiplier does not change under a quote.
+	if cached, ok := s.cells[cell]; ok && now.Before(cached.ExpiresAt) {
+		surge = cached
+	} else {
+		s.cells[cell] = surge
+	}
+	for c, cached := range s.cells {
+		if !now.Before(cached.ExpiresAt) {
+			delete(s.cells, c)
+		}
+	}
+	copied := *surge
+	return &copied, nil
+}
+
+// pendingRides counts the rides requested from the cell since the start of
+// the window that still wait for a driver.
+func (s *SurgeService) pendingRides(ctx context.Context, cell geo.Cell, since time.Time) (int, error) {
+	rides, _, err := s.rideStore.ListRides(ctx, entity.RideQuery{Status: entity.StatusPending, CreatedFrom: since})
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, ride := range rides {
+		if ride.Origin.HasCoordinates() && geo.CellAt(ride.Origin, s.engine.Surge().CellSizeDeg) == cell {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// availableDrivers counts the available drivers last seen in the cell.
+func (s *SurgeService) availableDrivers(ctx context.Context, cell geo.Cell, cutoff time.Time) (int, error) {
+	size := s.engine.Surge().CellSizeDeg
+	locations, err := s.locationStore.FindNearby(ctx, cell.Center(size), geo.CellRadiusKm(size))
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, loc := range locations {
+		if loc.Timestamp.Before(cutoff) || geo.CellAt(loc.Point(), size) != cell {
+			continue
+		}
+		if driver, err := s.driverStore.GetDriverByID(ctx, loc.DriverID); err == nil && driver.IsAvailable {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,9 @@
+package service
+
+import "context"
+
+// Transactor runs fn so that every store call made with the context passed to
+// fn commits or fails together, and is isolated from other transactions.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
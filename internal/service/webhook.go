@@ -0,0 +1,319 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/webhook"
+	"time"
+)
+
+type WebhookStore interface {
+	SaveWebhook(ctx context.Context, webhook *entity.Webhook) error
+	FindWebhookByID(ctx context.Context, id int) (*entity.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]*entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int) error
+	SaveDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID int) ([]*entity.WebhookDelivery, error)
+}
+
+type WebhookConfig struct {
+	// MaxAttempts is how often an event is sent before it is given up.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry; it doubles with
+	// every further retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Timeout bounds a single delivery request.
+	Timeout time.Duration
+	// Workers is how many deliveries are made at once.
+	Workers int
+	// QueueSize is how many deliveries may wait for a worker; deliveries
+	// beyond that are dropped and logged.
+	QueueSize int
+}
+
+func DefaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		MaxAttempts:    6,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Timeout:        10 * time.Second,
+		Workers:        8,
+		QueueSize:      1024,
+	}
+}
+
+// delivery is one attempt, queued for a worker, to send an event to a
+// webhook.
+type delivery struct {
+	webhook *entity.Webhook
+	event   entity.RideEvent
+	body    []byte
+	attempt int
+}
+
+// WebhookService manages webhook subscriptions and delivers ride events to
+// them. Every delivery is signed with the webhook's secret (see
+// webhook.Sign) and failed deliveries are retried with exponential backoff.
+// Pending retries are kept in memory and lost on restart.
+//
+// The subscriptions are cached from the first event on and reloaded after
+// a webhook is created or deleted through the service, so webhooks changed
+// in the store behind its back are not seen until then.
+type WebhookService struct {
+	store  WebhookStore
+	tx     Transactor
+	client *http.Client
+	config WebhookConfig
+	queue  chan delivery
+
+	mutex    sync.Mutex
+	webhooks []*entity.Webhook // nil until loaded
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWebhookService starts config.Workers delivery workers; call Stop to
+// end them.
+func NewWebhookService(store WebhookStore, tx Transactor, client *http.Client, config WebhookConfig) *WebhookService {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &WebhookService{
+		store:  store,
+		tx:     tx,
+		client: client,
+		config: config,
+		queue:  make(chan delivery, config.QueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for i := 0; i < config.Workers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.work()
+		}()
+	}
+	return s
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, w *entity.Webhook) (*entity.Webhook, error) {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, customErrors.ErrInvalidWebhookURL
+	}
+	if len(w.Events) == 0 {
+		return nil, customErrors.ErrWebhookEventsRequired
+	}
+	for _, event := range w.Events {
+		if !event.IsValid() {
+			return nil, customErrors.ErrInvalidEventType
+		}
+	}
+	if w.Secret == "" {
+		return nil, customErrors.ErrWebhookSecretRequired
+	}
+	w.CreatedAt = time.Now().UTC()
+	if err := s.store.SaveWebhook(ctx, w); err != nil {
+		return nil, err
+	}
+	s.forgetWebhooks()
+	return w, nil
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, id int) (*entity.Webhook, error) {
+	return s.store.FindWebhookByID(ctx, id)
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	return s.store.ListWebhooks(ctx)
+}
+
+// DeleteWebhook removes the webhook and its delivery log; retries still
+// pending for it are dropped.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id int) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.store.DeleteWebhook(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+	s.forgetWebhooks()
+	return nil
+}
+
+// GetDeliveries returns every delivery attempt made to the webhook, oldest
+// first.
+func (s *WebhookService) GetDeliveries(ctx context.Context, webhookID int) ([]*entity.WebhookDelivery, error) {
+	return s.store.ListDeliveries(ctx, webhookID)
+}
+
+// Notify queues the event for every webhook subscribed to its type. It
+// returns immediately; pass it to EventBus.Listen.
+func (s *WebhookService) Notify(event entity.RideEvent) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	webhooks, err := s.subscribed()
+	if err != nil {
+		slog.Error("webhooks not notified", "event_id", event.ID, "err", err)
+		return
+	}
+	var body []byte
+	for _, w := range webhooks {
+		if !w.Wants(event.Type) {
+			continue
+		}
+		if body == nil {
+			if body, err = json.Marshal(event); err != nil {
+				return
+			}
+		}
+		s.enqueue(delivery{webhook: w, event: event, body: body, attempt: 1})
+	}
+}
+
+// subscribed returns the webhooks, loading them from the store unless they
+// are cached.
+func (s *WebhookService) subscribed() ([]*entity.Webhook, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.webhooks == nil {
+		webhooks, err := s.store.ListWebhooks(s.ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.webhooks = append(make([]*entity.Webhook, 0, len(webhooks)), webhooks...)
+	}
+	return s.webhooks, nil
+}
+
+// forgetWebhooks drops the cached webhooks after they changed.
+func (s *WebhookService) forgetWebhooks() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.webhooks = nil
+}
+
+// enqueue hands d to a worker, or drops it if the queue is full.
+func (s *WebhookService) enqueue(d delivery) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	select {
+	case s.queue <- d:
+	default:
+		slog.Warn("webhook delivery dropped, queue full", "webhook_id", d.webhook.WebhookID, "event_id", d.event.ID, "attempt", d.attempt)
+	}
+}
+
+// Stop abandons pending retries and waits for deliveries in flight.
+func (s *WebhookService) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *WebhookService) work() {
+	for {
+		select {
+		case d := <-s.queue:
+			s.deliver(s.ctx, d)
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// deliver makes one attempt to send the event and logs it, unless the
+// webhook has been deleted. A failed attempt is queued again after a backoff
+// until the attempts run out, the webhook is deleted or ctx is cancelled.
+func (s *WebhookService) deliver(ctx context.Context, d delivery) {
+	w, event := d.webhook, d.event
+	if _, err := s.store.FindWebhookByID(ctx, w.WebhookID); err != nil {
+		// Deleted while the delivery waited in the queue or for its retry.
+		return
+	}
+	logged := &entity.WebhookDelivery{
+		WebhookID:   w.WebhookID,
+		EventID:     event.ID,
+		EventType:   event.Type,
+		RideID:      event.RideID,
+		Attempt:     d.attempt,
+		AttemptedAt: time.Now().UTC(),
+	}
+	var err error
+	logged.StatusCode, logged.Succeeded, err = s.send(ctx, w, event, d.attempt, d.body)
+	if err != nil {
+		logged.Error = err.Error()
+	}
+
+	var backoff time.Duration
+	if !logged.Succeeded && d.attempt < s.config.MaxAttempts && ctx.Err() == nil {
+		backoff = s.backoff(d.attempt)
+		next := time.Now().UTC().Add(backoff)
+		logged.NextAttemptAt = &next
+	}
+	// The log outlives shutdown, so it is not written with ctx.
+	if err := s.store.SaveDelivery(context.Background(), logged); err != nil {
+		// Most likely the webhook was deleted.
+		return
+	}
+	if logged.NextAttemptAt == nil {
+		if !logged.Succeeded && ctx.Err() == nil {
+			slog.Warn("webhook delivery given up", "webhook_id", w.WebhookID, "event_id", event.ID, "attempts", d.attempt, "err", logged.Error)
+		}
+		return
+	}
+	// The retry waits on a timer rather than a worker.
+	d.attempt++
+	time.AfterFunc(backoff, func() { s.enqueue(d) })
+}
+
+// send makes one delivery attempt. Any 2xx response counts as success.
+func (s *WebhookService) send(ctx context.Context, w *entity.Webhook, event entity.RideEvent, attempt int, body []byte) (int, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "taxiAPI-webhooks")
+	req.Header.Set("X-Taxi-Event", string(event.Type))
+	req.Header.Set("X-Taxi-Event-ID", strconv.FormatUint(event.ID, 10))
+	req.Header.Set("X-Taxi-Delivery-Attempt", strconv.Itoa(attempt))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(w.Secret, time.Now(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+	// Drain a little so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, false, fmt.Errorf("receiver responded %s", resp.Status)
+	}
+	return resp.StatusCode, true, nil
+}
+
+// backoff is the wait after the given failed attempt.
+func (s *WebhookService) backoff(attempt int) time.Duration {
+	wait := s.config.InitialBackoff
+	for i := 1; i < attempt && wait < s.config.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, s.config.MaxBackoff)
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/storage"
+	"taxiAPI/internal/webhook"
+)
+
+// receiver is a local webhook endpoint that fails the first failures
+// requests and records every request it verified.
+type receiver struct {
+	t        *testing.T
+	secret   string
+	failures int
+
+	mutex    sync.Mutex
+	received []*http.Request
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rc.t.Errorf("read body: %v", err)
+	}
+	header := r.Header.Get(webhook.SignatureHeader)
+	if err := webhook.Verify(rc.secret, header, body, webhook.DefaultTolerance, time.Now()); err != nil {
+		rc.t.Errorf("Verify: %v", err)
+	}
+	if err := webhook.Verify("wrong secret", header, body, webhook.DefaultTolerance, time.Now()); !errors.Is(err, customErrors.ErrInvalidSignature) {
+		rc.t.Errorf("Verify with the wrong secret: got %v, want ErrInvalidSignature", err)
+	}
+
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.received = append(rc.received, r)
+	if len(rc.received) <= rc.failures {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newWebhookTest(t *testing.T, rc http.Handler, maxAttempts int) (*EventBus, *WebhookService, *entity.Webhook) {
+	t.Helper()
+	return newWebhookTestWith(t, rc, storage.NewWebhook(), WebhookConfig{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: 5 * time.Millisecond,
+		MaxBackoff:     20 * time.Millisecond,
+		Timeout:        time.Second,
+		Workers:        4,
+		QueueSize:      16,
+	})
+}
+
+func newWebhookTestWith(t *testing.T, rc http.Handler, store WebhookStore, config WebhookConfig) (*EventBus, *WebhookService, *entity.Webhook) {
+	t.Helper()
+	server := httptest.NewServer(rc)
+	t.Cleanup(server.Close)
+
+	webhooks := NewWebhookService(store, storage.NewTransactor(), server.Client(), config)
+	t.Cleanup(webhooks.Stop)
+	bus := NewEventBus(DefaultEventBacklog)
+	bus.Listen(webhooks.Notify)
+
+	hook, err := webhooks.CreateWebhook(context.Background(), &entity.Webhook{
+		URL:    server.URL,
+		Events: []entity.RideEventType{entity.EventRideStatusChanged},
+		Secret: "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	return bus, webhooks, hook
+}
+
+// waitForDeliveries polls the delivery log until it holds n attempts.
+func waitForDeliveries(t *testing.T, webhooks *WebhookService, webhookID, n int) []*entity.WebhookDelivery {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		deliveries, err := webhooks.GetDeliveries(context.Background(), webhookID)
+		if err != nil {
+			t.Fatalf("GetDeliveries: %v", err)
+		}
+		if len(deliveries) >= n {
+			return deliveries
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d deliveries, want %d", len(deliveries), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWebhookRetriesUntilDelivered(t *testing.T) {
+	rc := &receiver{t: t, secret: "s3cret", failures: 2}
+	bus, webhooks, hook := newWebhookTest(t, rc, 5)
+
+	bus.Publish(entity.RideEvent{RideID: 1, Type: entity.EventRideCreated})
+	bus.Publish(statusEvent(1, entity.StatusChange{From: entity.StatusPending, To: entity.StatusCancelled, Actor: entity.ActorPassenger}))
+
+	deliveries := waitForDeliveries(t, webhooks, hook.WebhookID, 3)
+	if len(deliveries) != 3 {
+		t.Fatalf("got %d deliveries, want 3: %+v", len(deliveries), deliveries)
+	}
+	for i, delivery := range deliveries {
+		if delivery.Attempt != i+1 || delivery.EventType != entity.EventRideStatusChanged || delivery.EventID != deliveries[0].EventID {
+			t.Errorf("delivery %d: unexpected %+v", i, delivery)
+		}
+		last := i == len(deliveries)-1
+		if delivery.Succeeded != last || (delivery.NextAttemptAt == nil) != last {
+			t.Errorf("delivery %d: succeeded %v, next attempt %v", i, delivery.Succeeded, delivery.NextAttemptAt)
+		}
+	}
+	if deliveries[0].StatusCode != http.StatusInternalServerError || deliveries[2].StatusCode != http.StatusNoContent {
+		t.Errorf("unexpected status codes %d, %d", deliveries[0].StatusCode, deliveries[2].StatusCode)
+	}
+
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	for _, r := range rc.received {
+		if r.Header.Get("X-Taxi-Event") != string(entity.EventRideStatusChanged) {
+			t.Errorf("received unsubscribed event %q", r.Header.Get("X-Taxi-Event"))
+		}
+	}
+}
+
+func TestWebhookGivesUpAfterMaxAttempts(t *testing.T) {
+	rc := &receiver{t: t, secret: "s3cret", failures: 100}
+	bus, webhooks, hook := newWebhookTest(t, rc, 3)
+
+	bus.Publish(statusEvent(1, entity.StatusChange{From: entity.StatusPending, To: entity.StatusCancelled, Actor: entity.ActorSystem}))
+
+	deliveries := waitForDeliveries(t, webhooks, hook.WebhookID, 3)
+	last := deliveries[len(deliveries)-1]
+	if last.Attempt != 3 || last.Succeeded || last.NextAttemptAt != nil || last.Error == "" {
+		t.Fatalf("last attempt should have been given up: %+v", last)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := waitForDeliveries(t, webhooks, hook.WebhookID, 3); len(got) != 3 {
+		t.Fatalf("retried after the last attempt: %d deliveries", len(got))
+	}
+}
+
+func TestWebhookBackoffDoubles(t *testing.T) {
+	webhooks := NewWebhookService(storage.NewWebhook(), storage.NewTransactor(), http.DefaultClient, WebhookConfig{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+	})
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := webhooks.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+// countingWebhooks counts how often the webhooks are listed.
+type countingWebhooks struct {
+	*storage.Webhook
+	lists atomic.Int64
+}
+
+func (c *countingWebhooks) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	c.lists.Add(1)
+	return c.Webhook.ListWebhooks(ctx)
+}
+
+func TestWebhookListCachedUntilChanged(t *testing.T) {
+	rc := &receiver{t: t, secret: "s3cret"}
+	store := &countingWebhooks{Webhook: storage.NewWebhook()}
+	bus, webhooks, hook := newWebhookTestWith(t, rc, store, WebhookConfig{MaxAttempts: 1, Timeout: time.Second, Workers: 1, QueueSize: 16})
+	event := statusEvent(1, entity.StatusChange{From: entity.StatusPending, To: entity.StatusCancelled, Actor: entity.ActorSystem})
+
+	for i := 0; i < 5; i++ {
+		bus.Publish(event)
+	}
+	waitForDeliveries(t, webhooks, hook.WebhookID, 5)
+	if n := store.lists.Load(); n != 1 {
+		t.Fatalf("webhooks listed %d times for 5 events, want once", n)
+	}
+
+	second, err := webhooks.CreateWebhook(context.Background(), &entity.Webhook{URL: hook.URL, Events: hook.Events, Secret: "s3cret"})
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	bus.Publish(event)
+	waitForDeliveries(t, webhooks, second.WebhookID, 1)
+	if n := store.lists.Load(); n != 2 {
+		t.Fatalf("webhooks listed %d times, want them reloaded once after a new one", n)
+	}
+
+	if err := webhooks.DeleteWebhook(context.Background(), hook.WebhookID); err != nil {
+		t.Fatalf("DeleteWebhook: %v", err)
+	}
+	bus.Publish(event)
+	waitForDeliveries(t, webhooks, second.WebhookID, 2)
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	if len(rc.received) != 8 {
+		t.Fatalf("receiver got %d requests, want 8: the deleted webhook must not be notified", len(rc.received))
+	}
+}
+
+// blockingReceiver accepts every request, but only once released.
+type blockingReceiver struct {
+	release  chan struct{}
+	received atomic.Int64
+}
+
+func (b *blockingReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	<-b.release
+	b.received.Add(1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestWebhookQueueIsBounded(t *testing.T) {
+	rc := &blockingReceiver{release: make(chan struct{})}
+	bus, webhooks, hook := newWebhookTestWith(t, rc, storage.NewWebhook(), WebhookConfig{MaxAttempts: 1, Timeout: 5 * time.Second, Workers: 1, QueueSize: 2})
+
+	bus.Publish(statusEvent(1, entity.StatusChange{From: entity.StatusPending, To: entity.StatusCancelled, Actor: entity.ActorSystem}))
+	// Let the worker pick up the first delivery before the queue fills.
+	for deadline := time.Now().Add(time.Second); len(webhooks.queue) > 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	for i := 2; i <= 10; i++ {
+		bus.Publish(statusEvent(i, entity.StatusChange{From: entity.StatusPending, To: entity.StatusCancelled, Actor: entity.ActorSystem}))
+	}
+	close(rc.release)
+
+	deliveries := waitForDeliveries(t, webhooks, hook.WebhookID, 3)
+	time.Sleep(50 * time.Millisecond)
+	if got := rc.received.Load(); got != 3 || len(deliveries) != 3 {
+		t.Fatalf("delivered %d events (%d logged), want 3: one in flight and two queued", got, len(deliveries))
+	}
+}
+
+func TestWebhookDeletedDropsPendingRetries(t *testing.T) {
+	rc := &receiver{t: t, secret: "s3cret", failures: 100}
+	bus, webhooks, hook := newWebhookTestWith(t, rc, storage.NewWebhook(), WebhookConfig{
+		MaxAttempts:    5,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+		Timeout:        time.Second,
+		Workers:        1,
+		QueueSize:      16,
+	})
+
+	bus.Publish(statusEvent(1, entity.StatusChange{From: entity.StatusPending, To: entity.StatusCancelled, Actor: entity.ActorSystem}))
+	if first := waitForDeliveries(t, webhooks, hook.WebhookID, 1)[0]; first.NextAttemptAt == nil {
+		t.Fatalf("first attempt scheduled no retry: %+v", first)
+	}
+	if err := webhooks.DeleteWebhook(context.Background(), hook.WebhookID); err != nil {
+		t.Fatalf("DeleteWebhook: %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	if len(rc.received) != 1 {
+		t.Fatalf("receiver got %d requests, want 1: retries of a deleted webhook must be dropped", len(rc.received))
+	}
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"taxiAPI/internal/entity"
 	customErrors "taxiAPI/internal/errors"
@@ -10,16 +11,21 @@ import (
 type Driver struct {
 	mutex   sync.RWMutex
 	drivers map[int]*entity.Driver
+	// byPhone indexes the driver IDs by phone number.
+	byPhone map[string]int
 	nextID  int
 }
 
 func NewDriver() *Driver {
 	return &Driver{
 		drivers: make(map[int]*entity.Driver),
+		byPhone: make(map[string]int),
 		nextID:  1,
 	}
 }
 
+// RegisterDriver stores the driver unless another one has the same phone
+// number, in which case it returns ErrPhoneNumberExists.
 func (d *Driver) RegisterDriver(ctx context.Context, driver *entity.Driver) (*entity.Driver, error) {
 	select {
 	case <-ctx.Done():
@@ -27,8 +33,13 @@ func (d *Driver) RegisterDriver(ctx context.Context, driver *entity.Driver) (*en
 	default:
 		d.mutex.Lock()
 		defer d.mutex.Unlock()
+		if _, taken := d.byPhone[driver.PhoneNumber]; taken {
+			return nil, customErrors.ErrPhoneNumberExists
+		}
 		driver.DriverID = d.nextID
-		d.drivers[driver.DriverID] = driver
+		stored := *driver
+		d.drivers[driver.DriverID] = &stored
+		d.byPhone[driver.PhoneNumber] = driver.DriverID
 		d.nextID++
 		return driver, nil
 	}
@@ -46,22 +57,32 @@ func (d *Driver) GetDriverByID(ctx context.Context, id int) (*entity.Driver, err
 	if !ok {
 		return nil, customErrors.ErrDriverNotFound
 	}
-	return driver, nil
+	copied := *driver
+	return &copied, nil
 }
 
-func (d *Driver) GetAllDrivers(ctx context.Context) ([]*entity.Driver, error) {
+func (d *Driver) ListDrivers(ctx context.Context, query entity.DriverQuery) ([]*entity.Driver, int, error) {
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return nil, 0, ctx.Err()
 	default:
 	}
 	d.mutex.RLock()
 	defer d.mutex.RUnlock()
 	drivers := make([]*entity.Driver, 0, len(d.drivers))
 	for _, drv := range d.drivers {
-		drivers = append(drivers, drv)
+		if query.Matches(drv) {
+			copied := *drv
+			drivers = append(drivers, &copied)
+		}
 	}
-	return drivers, nil
+	slices.SortFunc(drivers, func(a, b *entity.Driver) int {
+		if query.Sort.Field == "last_name" {
+			return compareBy(query.Sort.Desc, a.LastName, b.LastName, a.DriverID, b.DriverID)
+		}
+		return compareBy(query.Sort.Desc, a.DriverID, b.DriverID, 0, 0)
+	})
+	return paginate(drivers, query.Page), len(drivers), nil
 }
 
 func (d *Driver) DeleteDriver(ctx context.Context, id int) error {
@@ -72,15 +93,33 @@ func (d *Driver) DeleteDriver(ctx context.Context, id int) error {
 	}
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
-	_, ok := d.drivers[id]
+	driver, ok := d.drivers[id]
 	if !ok {
 		return customErrors.ErrDriverNotFound
 	}
+	delete(d.byPhone, driver.PhoneNumber)
 	delete(d.drivers, id)
 	return nil
 }
 
-func (d *Driver) FindByPhoneNumber(ctx context.Context, phone int) (*entity.Driver, error) {
+func (d *Driver) UpdateDriverAvailability(ctx context.Context, id int, online, available bool) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	driver, ok := d.drivers[id]
+	if !ok {
+		return customErrors.ErrDriverNotFound
+	}
+	driver.IsOnline = online
+	driver.IsAvailable = available
+	return nil
+}
+
+func (d *Driver) FindByPhoneNumber(ctx context.Context, phone string) (*entity.Driver, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -89,10 +128,28 @@ func (d *Driver) FindByPhoneNumber(ctx context.Context, phone int) (*entity.Driv
 	d.mutex.RLock()
 	defer d.mutex.RUnlock()
 
-	for _, driver := range d.drivers {
-		if driver.PhoneNumber == phone {
-			return driver, nil
-		}
+	id, ok := d.byPhone[phone]
+	if !ok {
+		return nil, customErrors.ErrDriverNotFound
+	}
+	copied := *d.drivers[id]
+	return &copied, nil
+}
+
+// AddDriverRating folds score into the driver's average rating.
+func (d *Driver) AddDriverRating(ctx context.Context, id int, score int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
 	}
-	return nil, customErrors.ErrDriverNotFound
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	driver, ok := d.drivers[id]
+	if !ok {
+		return customErrors.ErrDriverNotFound
+	}
+	driver.Rating = (driver.Rating*float64(driver.RatingCount) + float64(score)) / float64(driver.RatingCount+1)
+	driver.RatingCount++
+	return nil
 }
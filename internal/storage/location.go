@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/geo"
+)
+
+// locationCellSize is the grid cell edge in degrees, roughly 1 km.
+const locationCellSize = 0.01
+
+type Location struct {
+	mutex     sync.RWMutex
+	locations map[int]*entity.DriverLocation
+	cells     map[geo.Cell]map[int]struct{}
+}
+
+func NewLocation() *Location {
+	return &Location{
+		locations: make(map[int]*entity.DriverLocation),
+		cells:     make(map[geo.Cell]map[int]struct{}),
+	}
+}
+
+// UpdateLocation stores loc unless a newer position is already known for the
+// driver, and returns whichever position is now the latest.
+func (l *Location) UpdateLocation(ctx context.Context, loc *entity.DriverLocation) (*entity.DriverLocation, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	current, ok := l.locations[loc.DriverID]
+	if ok {
+		if loc.Timestamp.Before(current.Timestamp) {
+			return current, nil
+		}
+		l.unindex(current)
+	}
+	l.locations[loc.DriverID] = loc
+	cell := geo.CellAt(loc.Point(), locationCellSize)
+	if l.cells[cell] == nil {
+		l.cells[cell] = make(map[int]struct{})
+	}
+	l.cells[cell][loc.DriverID] = struct{}{}
+	return loc, nil
+}
+
+func (l *Location) GetLocation(ctx context.Context, driverID int) (*entity.DriverLocation, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	loc, ok := l.locations[driverID]
+	if !ok {
+		return nil, customErrors.ErrLocationNotFound
+	}
+	return loc, nil
+}
+
+func (l *Location) DeleteLocation(ctx context.Context, driverID int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	loc, ok := l.locations[driverID]
+	if !ok {
+		return customErrors.ErrLocationNotFound
+	}
+	l.unindex(loc)
+	delete(l.locations, driverID)
+	return nil
+}
+
+// FindNearby returns the latest positions within radiusKm of center, closest
+// first.
+func (l *Location) FindNearby(ctx context.Context, center entity.Location, radiusKm float64) ([]*entity.DriverLocation, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	type candidate struct {
+		loc      *entity.DriverLocation
+		distance float64
+	}
+	candidates := make([]candidate, 0)
+	add := func(loc *entity.DriverLocation) {
+		if d := center.DistanceKm(loc.Point()); d <= radiusKm {
+			candidates = append(candidates, candidate{loc: loc, distance: d})
+		}
+	}
+	// When the circle spans more cells than hold drivers, as it always does
+	// near the poles, checking every driver is cheaper.
+	if cells, ok := geo.CellsWithin(center, radiusKm, locationCellSize, len(l.cells)); ok {
+		for _, cell := range cells {
+			for driverID := range l.cells[cell] {
+				add(l.locations[driverID])
+			}
+		}
+	} else {
+		for _, loc := range l.locations {
+			add(loc)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return compareBy(false, a.distance, b.distance, a.loc.DriverID, b.loc.DriverID)
+	})
+
+	nearby := make([]*entity.DriverLocation, len(candidates))
+	for i, c := range candidates {
+		nearby[i] = c.loc
+	}
+	return nearby, nil
+}
+
+func (l *Location) unindex(loc *entity.DriverLocation) {
+	cell := geo.CellAt(loc.Point(), locationCellSize)
+	delete(l.cells[cell], loc.DriverID)
+	if len(l.cells[cell]) == 0 {
+		delete(l.cells, cell)
+	}
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"time"
+)
+
+type Offer struct {
+	mutex  sync.RWMutex
+	offers map[int]*entity.Offer
+	nextID int
+}
+
+func NewOffer() *Offer {
+	return &Offer{
+		offers: make(map[int]*entity.Offer),
+		nextID: 1,
+	}
+}
+
+// SaveOffer stores a new pending offer. It returns ErrOfferAlreadyPending,
+// and stores nothing, if the ride or the driver already has a pending offer
+// that has not expired by the new offer's CreatedAt.
+func (o *Offer) SaveOffer(ctx context.Context, offer *entity.Offer) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	for _, other := range o.offers {
+		if (other.RideID == offer.RideID || other.DriverID == offer.DriverID) &&
+			other.Status == entity.OfferPending && !other.IsExpired(offer.CreatedAt) {
+			return customErrors.ErrOfferAlreadyPending
+		}
+	}
+	offer.OfferID = o.nextID
+	stored := *offer
+	o.offers[offer.OfferID] = &stored
+	o.nextID++
+	return nil
+}
+
+func (o *Offer) FindOfferByID(ctx context.Context, id int) (*entity.Offer, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	offer, ok := o.offers[id]
+	if !ok {
+		return nil, customErrors.ErrOfferNotFound
+	}
+	copied := *offer
+	return &copied, nil
+}
+
+// UpdateOfferStatus moves the offer from one status to another and fails
+// with ErrOfferNotPending if it is no longer in from.
+func (o *Offer) UpdateOfferStatus(ctx context.Context, offerID int, from, to entity.OfferStatus, at time.Time) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	offer, ok := o.offers[offerID]
+	if !ok {
+		return customErrors.ErrOfferNotFound
+	}
+	if offer.Status != from {
+		return customErrors.ErrOfferNotPending
+	}
+	offer.Status = to
+	offer.RespondedAt = &at
+	return nil
+}
+
+func (o *Offer) ListOffersByRide(ctx context.Context, rideID int) ([]*entity.Offer, error) {
+	return o.list(ctx, func(offer *entity.Offer) bool {
+		return offer.RideID == rideID
+	})
+}
+
+func (o *Offer) ListOffersByDriver(ctx context.Context, driverID int, status entity.OfferStatus) ([]*entity.Offer, error) {
+	return o.list(ctx, func(offer *entity.Offer) bool {
+		return offer.DriverID == driverID && (status == "" || offer.Status == status)
+	})
+}
+
+func (o *Offer) list(ctx context.Context, match func(*entity.Offer) bool) ([]*entity.Offer, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	offers := make([]*entity.Offer, 0)
+	for _, offer := range o.offers {
+		if match(offer) {
+			copied := *offer
+			offers = append(offers, &copied)
+		}
+	}
+	slices.SortFunc(offers, func(a, b *entity.Offer) int {
+		return a.OfferID - b.OfferID
+	})
+	return offers, nil
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"taxiAPI/internal/entity"
 	customErrors "taxiAPI/internal/errors"
@@ -10,16 +11,21 @@ import (
 type Passenger struct {
 	mutex      sync.RWMutex
 	passengers map[int]*entity.Passenger
-	nextID     int
+	// byPhone indexes the passenger IDs by phone number.
+	byPhone map[string]int
+	nextID  int
 }
 
 func NewPassenger() *Passenger {
 	return &Passenger{
 		passengers: make(map[int]*entity.Passenger),
+		byPhone:    make(map[string]int),
 		nextID:     1,
 	}
 }
 
+// RegisterPassenger stores the passenger unless another one has the same
+// phone number, in which case it returns ErrPhoneNumberExists.
 func (p *Passenger) RegisterPassenger(ctx context.Context, passenger *entity.Passenger) (*entity.Passenger, error) {
 	select {
 	case <-ctx.Done():
@@ -27,8 +33,13 @@ func (p *Passenger) RegisterPassenger(ctx context.Context, passenger *entity.Pas
 	default:
 		p.mutex.Lock()
 		defer p.mutex.Unlock()
+		if _, taken := p.byPhone[passenger.PhoneNumber]; taken {
+			return nil, customErrors.ErrPhoneNumberExists
+		}
 		passenger.PassengerID = p.nextID
-		p.passengers[passenger.PassengerID] = passenger
+		stored := *passenger
+		p.passengers[passenger.PassengerID] = &stored
+		p.byPhone[passenger.PhoneNumber] = passenger.PassengerID
 		p.nextID++
 		return passenger, nil
 	}
@@ -47,13 +58,14 @@ func (p *Passenger) GetPassengerByID(ctx context.Context, id int) (*entity.Passe
 	if !ok {
 		return nil, customErrors.ErrPassengerNotFound
 	}
-	return passenger, nil
+	copied := *passenger
+	return &copied, nil
 }
 
-func (p *Passenger) GetAllPassengers(ctx context.Context) ([]*entity.Passenger, error) {
+func (p *Passenger) ListPassengers(ctx context.Context, query entity.PassengerQuery) ([]*entity.Passenger, int, error) {
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return nil, 0, ctx.Err()
 	default:
 	}
 	p.mutex.RLock()
@@ -61,9 +73,16 @@ func (p *Passenger) GetAllPassengers(ctx context.Context) ([]*entity.Passenger,
 
 	passengers := make([]*entity.Passenger, 0, len(p.passengers))
 	for _, passenger := range p.passengers {
-		passengers = append(passengers, passenger)
+		copied := *passenger
+		passengers = append(passengers, &copied)
 	}
-	return passengers, nil
+	slices.SortFunc(passengers, func(a, b *entity.Passenger) int {
+		if query.Sort.Field == "last_name" {
+			return compareBy(query.Sort.Desc, a.LastName, b.LastName, a.PassengerID, b.PassengerID)
+		}
+		return compareBy(query.Sort.Desc, a.PassengerID, b.PassengerID, 0, 0)
+	})
+	return paginate(passengers, query.Page), len(passengers), nil
 }
 
 func (p *Passenger) DeletePassenger(ctx context.Context, id int) error {
@@ -75,15 +94,16 @@ func (p *Passenger) DeletePassenger(ctx context.Context, id int) error {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
-	_, ok := p.passengers[id]
+	passenger, ok := p.passengers[id]
 	if !ok {
 		return customErrors.ErrPassengerNotFound
 	}
+	delete(p.byPhone, passenger.PhoneNumber)
 	delete(p.passengers, id)
 	return nil
 }
 
-func (p *Passenger) FindByPhoneNumber(ctx context.Context, phone int) (*entity.Passenger, error) {
+func (p *Passenger) FindByPhoneNumber(ctx context.Context, phone string) (*entity.Passenger, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -92,10 +112,28 @@ func (p *Passenger) FindByPhoneNumber(ctx context.Context, phone int) (*entity.P
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
 
-	for _, passenger := range p.passengers {
-		if passenger.PhoneNumber == phone {
-			return passenger, nil
-		}
+	id, ok := p.byPhone[phone]
+	if !ok {
+		return nil, customErrors.ErrPassengerNotFound
+	}
+	copied := *p.passengers[id]
+	return &copied, nil
+}
+
+// AddPassengerRating folds score into the passenger's average rating.
+func (p *Passenger) AddPassengerRating(ctx context.Context, id int, score int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
 	}
-	return nil, customErrors.ErrPassengerNotFound
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	passenger, ok := p.passengers[id]
+	if !ok {
+		return customErrors.ErrPassengerNotFound
+	}
+	passenger.Rating = (passenger.Rating*float64(passenger.RatingCount) + float64(score)) / float64(passenger.RatingCount+1)
+	passenger.RatingCount++
+	return nil
 }
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"cmp"
+	"taxiAPI/internal/entity"
+)
+
+func paginate[T any](items []T, page entity.Page) []T {
+	if page.Offset >= len(items) {
+		return items[:0]
+	}
+	items = items[page.Offset:]
+	if page.Limit > 0 && page.Limit < len(items) {
+		items = items[:page.Limit]
+	}
+	return items
+}
+
+// compareBy orders by the sort key first and the ID second, honouring the
+// requested direction only for the sort key.
+func compareBy[K cmp.Ordered](desc bool, keyA, keyB K, idA, idB int) int {
+	c := cmp.Compare(keyA, keyB)
+	if desc {
+		c = -c
+	}
+	if c != 0 {
+		return c
+	}
+	return cmp.Compare(idA, idB)
+}
@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"taxiAPI/internal/entity"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	for _, tc := range []struct {
+		page entity.Page
+		want []int
+	}{
+		{entity.Page{}, []int{1, 2, 3, 4, 5}},
+		{entity.Page{Limit: 2}, []int{1, 2}},
+		{entity.Page{Limit: 2, Offset: 4}, []int{5}},
+		{entity.Page{Offset: 3}, []int{4, 5}},
+		{entity.Page{Limit: 10}, []int{1, 2, 3, 4, 5}},
+		{entity.Page{Offset: 5}, []int{}},
+		{entity.Page{Limit: 1, Offset: 9}, []int{}},
+	} {
+		if got := paginate(items, tc.page); !slices.Equal(got, tc.want) {
+			t.Errorf("paginate(%+v) = %v, want %v", tc.page, got, tc.want)
+		}
+	}
+}
+
+func TestListRidesFiltersSortsAndPages(t *testing.T) {
+	ctx := context.Background()
+	store := NewRide()
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		ride := &entity.Ride{
+			PassengerID: 1 + i%2,
+			Origin:      entity.Location{Address: "A"},
+			Destination: entity.Location{Address: "B"},
+			Status:      entity.StatusPending,
+			// Rides 5 and 6 share a creation time.
+			CreatedAt: base.Add(time.Duration(min(i, 4)) * time.Hour),
+		}
+		if i == 2 {
+			ride.Status = entity.StatusCancelled
+		}
+		if err := store.SaveRide(ctx, ride); err != nil {
+			t.Fatalf("SaveRide: %v", err)
+		}
+	}
+	ids := func(rides []*entity.Ride) []int {
+		var ids []int
+		for _, ride := range rides {
+			ids = append(ids, ride.RideID)
+		}
+		return ids
+	}
+
+	for _, tc := range []struct {
+		name      string
+		query     entity.RideQuery
+		want      []int
+		wantTotal int
+	}{
+		{"all", entity.RideQuery{}, []int{1, 2, 3, 4, 5, 6}, 6},
+		{"passenger", entity.RideQuery{PassengerID: 1}, []int{1, 3, 5}, 3},
+		{"status", entity.RideQuery{Status: entity.StatusCancelled}, []int{3}, 1},
+		{"created range", entity.RideQuery{CreatedFrom: base.Add(time.Hour), CreatedTo: base.Add(3 * time.Hour)}, []int{2, 3}, 2},
+		{"newest first, ties by ID", entity.RideQuery{Sort: entity.SortOrder{Field: "created_at", Desc: true}}, []int{5, 6, 4, 3, 2, 1}, 6},
+		{"ID descending", entity.RideQuery{Sort: entity.SortOrder{Field: "ride_id", Desc: true}}, []int{6, 5, 4, 3, 2, 1}, 6},
+		{"page", entity.RideQuery{PassengerID: 1, Sort: entity.SortOrder{Field: "created_at", Desc: true}, Page: entity.Page{Limit: 2}}, []int{5, 3}, 3},
+		{"page past the end", entity.RideQuery{Page: entity.Page{Limit: 2, Offset: 6}}, nil, 6},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rides, total, err := store.ListRides(ctx, tc.query)
+			if err != nil {
+				t.Fatalf("ListRides: %v", err)
+			}
+			if got := ids(rides); total != tc.wantTotal || !slices.Equal(got, tc.want) {
+				t.Fatalf("ListRides = %v of %d, want %v of %d", got, total, tc.want, tc.wantTotal)
+			}
+		})
+	}
+
+	// Paging through a sort with ties visits every ride once.
+	var paged []int
+	for offset := 0; offset < 6; offset += 4 {
+		rides, _, err := store.ListRides(ctx, entity.RideQuery{Sort: entity.SortOrder{Field: "created_at", Desc: true}, Page: entity.Page{Limit: 4, Offset: offset}})
+		if err != nil {
+			t.Fatalf("ListRides: %v", err)
+		}
+		paged = append(paged, ids(rides)...)
+	}
+	if want := []int{5, 6, 4, 3, 2, 1}; !slices.Equal(paged, want) {
+		t.Fatalf("paged = %v, want %v", paged, want)
+	}
+}
+
+func TestListDriversFiltersSortsAndPages(t *testing.T) {
+	ctx := context.Background()
+	store := NewDriver()
+	for i, d := range []struct {
+		lastName  string
+		carType   string
+		available bool
+	}{
+		{"Smith", "Prius", true},
+		{"Adams", "Van", true},
+		{"Smith", "Prius", false},
+		{"Brown", "Prius", true},
+	} {
+		_, err := store.RegisterDriver(ctx, &entity.Driver{
+			FirstName: "Alex", LastName: d.lastName, PhoneNumber: fmt.Sprintf("+14155550%03d", i),
+			CarType: d.carType, LicensePlate: 1, IsOnline: d.available, IsAvailable: d.available,
+		})
+		if err != nil {
+			t.Fatalf("RegisterDriver: %v", err)
+		}
+	}
+
+	available := true
+	for _, tc := range []struct {
+		name      string
+		query     entity.DriverQuery
+		want      []int
+		wantTotal int
+	}{
+		{"all", entity.DriverQuery{}, []int{1, 2, 3, 4}, 4},
+		{"available", entity.DriverQuery{IsAvailable: &available}, []int{1, 2, 4}, 3},
+		{"car type", entity.DriverQuery{CarType: "Prius"}, []int{1, 3, 4}, 3},
+		{"last name, ties by ID", entity.DriverQuery{Sort: entity.SortOrder{Field: "last_name"}}, []int{2, 4, 1, 3}, 4},
+		{"last name descending, ties by ID", entity.DriverQuery{Sort: entity.SortOrder{Field: "last_name", Desc: true}}, []int{1, 3, 4, 2}, 4},
+		{"page", entity.DriverQuery{Sort: entity.SortOrder{Field: "last_name"}, Page: entity.Page{Limit: 2, Offset: 1}}, []int{4, 1}, 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			drivers, total, err := store.ListDrivers(ctx, tc.query)
+			if err != nil {
+				t.Fatalf("ListDrivers: %v", err)
+			}
+			var got []int
+			for _, d := range drivers {
+				got = append(got, d.DriverID)
+			}
+			if total != tc.wantTotal || !slices.Equal(got, tc.want) {
+				t.Fatalf("ListDrivers = %v of %d, want %v of %d", got, total, tc.want, tc.wantTotal)
+			}
+		})
+	}
+}
+
+func TestListPassengersSortsAndPages(t *testing.T) {
+	ctx := context.Background()
+	store := NewPassenger()
+	for i, lastName := range []string{"Doe", "Adams", "Doe"} {
+		if _, err := store.RegisterPassenger(ctx, &entity.Passenger{FirstName: "Jo", LastName: lastName, PhoneNumber: fmt.Sprintf("+14155551%03d", i)}); err != nil {
+			t.Fatalf("RegisterPassenger: %v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		query entity.PassengerQuery
+		want  []int
+	}{
+		{"by ID", entity.PassengerQuery{}, []int{1, 2, 3}},
+		{"ID descending", entity.PassengerQuery{Sort: entity.SortOrder{Field: "passenger_id", Desc: true}}, []int{3, 2, 1}},
+		{"last name descending, ties by ID", entity.PassengerQuery{Sort: entity.SortOrder{Field: "last_name", Desc: true}}, []int{1, 3, 2}},
+		{"page", entity.PassengerQuery{Sort: entity.SortOrder{Field: "last_name"}, Page: entity.Page{Limit: 1, Offset: 1}}, []int{1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			passengers, total, err := store.ListPassengers(ctx, tc.query)
+			if err != nil {
+				t.Fatalf("ListPassengers: %v", err)
+			}
+			var got []int
+			for _, p := range passengers {
+				got = append(got, p.PassengerID)
+			}
+			if total != 3 || !slices.Equal(got, tc.want) {
+				t.Fatalf("ListPassengers = %v of %d, want %v of 3", got, total, tc.want)
+			}
+		})
+	}
+}
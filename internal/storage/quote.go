@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"time"
+)
+
+// Quote keeps fare quotes until they expire. Expired quotes are dropped
+// whenever a new one is saved.
+type Quote struct {
+	mutex  sync.RWMutex
+	quotes map[int]*entity.Quote
+	nextID int
+}
+
+func NewQuote() *Quote {
+	return &Quote{
+		quotes: make(map[int]*entity.Quote),
+		nextID: 1,
+	}
+}
+
+func (q *Quote) SaveQuote(ctx context.Context, quote *entity.Quote) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	now := time.Now()
+	for id, stored := range q.quotes {
+		if now.After(stored.ExpiresAt) {
+			delete(q.quotes, id)
+		}
+	}
+	quote.QuoteID = q.nextID
+	stored := *quote
+	q.quotes[quote.QuoteID] = &stored
+	q.nextID++
+	return nil
+}
+
+// MarkQuoteUsed records that a ride was booked with the quote at usedAt,
+// unless one already was.
+func (q *Quote) MarkQuoteUsed(ctx context.Context, id int, usedAt time.Time) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	quote, ok := q.quotes[id]
+	if !ok {
+		return customErrors.ErrQuoteNotFound
+	}
+	if quote.UsedAt != nil {
+		return customErrors.ErrQuoteUsed
+	}
+	quote.UsedAt = &usedAt
+	return nil
+}
+
+func (q *Quote) FindQuoteByID(ctx context.Context, id int) (*entity.Quote, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	quote, ok := q.quotes[id]
+	if !ok {
+		return nil, customErrors.ErrQuoteNotFound
+	}
+	copied := *quote
+	return &copied, nil
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+type ratingKey struct {
+	rideID int
+	actor  entity.Actor
+}
+
+type Rating struct {
+	mutex   sync.RWMutex
+	ratings map[ratingKey]entity.Rating
+}
+
+func NewRating() *Rating {
+	return &Rating{
+		ratings: make(map[ratingKey]entity.Rating),
+	}
+}
+
+// SaveRating stores the rating unless the same side already rated the ride.
+func (r *Rating) SaveRating(ctx context.Context, rating *entity.Rating) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	key := ratingKey{rideID: rating.RideID, actor: rating.Actor}
+	if _, ok := r.ratings[key]; ok {
+		return customErrors.ErrAlreadyRated
+	}
+	r.ratings[key] = *rating
+	return nil
+}
+
+func (r *Rating) ListRatingsByRide(ctx context.Context, rideID int) ([]*entity.Rating, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	ratings := make([]*entity.Rating, 0, 2)
+	for key, rating := range r.ratings {
+		if key.rideID == rideID {
+			copied := rating
+			ratings = append(ratings, &copied)
+		}
+	}
+	slices.SortFunc(ratings, func(a, b *entity.Rating) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return ratings, nil
+}
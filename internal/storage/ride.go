@@ -2,21 +2,25 @@ package storage
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"taxiAPI/internal/entity"
 	customErrors "taxiAPI/internal/errors"
+	"time"
 )
 
 type Ride struct {
-	mutex  sync.RWMutex
-	rides  map[int]*entity.Ride
-	nextID int
+	mutex   sync.RWMutex
+	rides   map[int]*entity.Ride
+	history map[int][]entity.StatusChange
+	nextID  int
 }
 
 func NewRide() *Ride {
 	return &Ride{
-		rides:  make(map[int]*entity.Ride),
-		nextID: 1,
+		rides:   make(map[int]*entity.Ride),
+		history: make(map[int][]entity.StatusChange),
+		nextID:  1,
 	}
 }
 
@@ -28,7 +32,7 @@ func (r *Ride) SaveRide(ctx context.Context, ride *entity.Ride) error {
 		r.mutex.Lock()
 		defer r.mutex.Unlock()
 		ride.RideID = r.nextID
-		r.rides[ride.RideID] = ride
+		r.rides[ride.RideID] = cloneRide(ride)
 		r.nextID++
 		return nil
 	}
@@ -46,10 +50,12 @@ func (r *Ride) FindRideByID(ctx context.Context, id int) (*entity.Ride, error) {
 	if !ok {
 		return nil, customErrors.ErrRideNotFound
 	}
-	return ride, nil
+	return cloneRide(ride), nil
 }
 
-func (r *Ride) UpdateRideStatus(ctx context.Context, rideID int, status entity.Status) error {
+// UpdateRide stores the ride's status, fares and timestamps. The driver is
+// only ever set through AssignDriverToRide.
+func (r *Ride) UpdateRide(ctx context.Context, ride *entity.Ride) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -57,15 +63,21 @@ func (r *Ride) UpdateRideStatus(ctx context.Context, rideID int, status entity.S
 	}
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
-	ride, ok := r.rides[rideID]
+	stored, ok := r.rides[ride.RideID]
 	if !ok {
 		return customErrors.ErrRideNotFound
 	}
-	ride.Status = status
+	updated := cloneRide(ride)
+	updated.DriverID = stored.DriverID
+	updated.AcceptedAt = stored.AcceptedAt
+	r.rides[ride.RideID] = updated
 	return nil
 }
 
-func (r *Ride) AssignDriverToRide(ctx context.Context, rideID, driverID int) error {
+// AssignDriverToRide atomically checks that the ride is pending and
+// unassigned and that the driver has no other active ride, then assigns the
+// driver and marks the ride accepted at acceptedAt.
+func (r *Ride) AssignDriverToRide(ctx context.Context, rideID, driverID int, acceptedAt time.Time) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -77,11 +89,69 @@ func (r *Ride) AssignDriverToRide(ctx context.Context, rideID, driverID int) err
 	if !ok {
 		return customErrors.ErrRideNotFound
 	}
+	if ride.DriverID != 0 {
+		if ride.DriverID == driverID {
+			return customErrors.ErrDriverAlreadyAssignedToRide
+		}
+		return customErrors.ErrRideAlreadyAssigned
+	}
+	if ride.Status != entity.StatusPending {
+		return customErrors.ErrCannotAssignDriverToNonPendingRide
+	}
+	if r.activeRideByDriver(driverID) != nil {
+		return customErrors.ErrDriverAlreadyOnActiveRide
+	}
 	ride.DriverID = driverID
+	ride.Status = entity.StatusAccepted
+	ride.AcceptedAt = &acceptedAt
+	return nil
+}
+
+// UnassignDriverFromRide takes the ride away from its driver before pickup
+// and puts it back to pending.
+func (r *Ride) UnassignDriverFromRide(ctx context.Context, rideID, driverID int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	ride, ok := r.rides[rideID]
+	if !ok {
+		return customErrors.ErrRideNotFound
+	}
+	switch {
+	case ride.DriverID == 0:
+		return customErrors.ErrRideNotAssigned
+	case ride.DriverID != driverID:
+		return customErrors.ErrRideAlreadyAssigned
+	case ride.Status != entity.StatusAccepted && ride.Status != entity.StatusDriverArriving:
+		return &customErrors.TransitionError{From: string(ride.Status), To: string(entity.StatusPending)}
+	}
+	ride.DriverID = 0
+	ride.Status = entity.StatusPending
+	ride.AcceptedAt = nil
 	return nil
 }
 
-func (r *Ride) GetAllRides(ctx context.Context) ([]*entity.Ride, error) {
+func (r *Ride) AppendRideHistory(ctx context.Context, rideID int, change entity.StatusChange) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.rides[rideID]; !ok {
+		return customErrors.ErrRideNotFound
+	}
+	r.history[rideID] = append(r.history[rideID], change)
+	return nil
+}
+
+// ListRideHistory returns the ride's status changes, oldest first.
+func (r *Ride) ListRideHistory(ctx context.Context, rideID int) ([]entity.StatusChange, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -89,11 +159,33 @@ func (r *Ride) GetAllRides(ctx context.Context) ([]*entity.Ride, error) {
 	}
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
+	if _, ok := r.rides[rideID]; !ok {
+		return nil, customErrors.ErrRideNotFound
+	}
+	return append([]entity.StatusChange{}, r.history[rideID]...), nil
+}
+
+func (r *Ride) ListRides(ctx context.Context, query entity.RideQuery) ([]*entity.Ride, int, error) {
+	select {
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	default:
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
 	rides := make([]*entity.Ride, 0, len(r.rides))
 	for _, ride := range r.rides {
-		rides = append(rides, ride)
+		if query.Matches(ride) {
+			rides = append(rides, cloneRide(ride))
+		}
 	}
-	return rides, nil
+	slices.SortFunc(rides, func(a, b *entity.Ride) int {
+		if query.Sort.Field == "created_at" {
+			return compareBy(query.Sort.Desc, a.CreatedAt.UnixNano(), b.CreatedAt.UnixNano(), a.RideID, b.RideID)
+		}
+		return compareBy(query.Sort.Desc, a.RideID, b.RideID, 0, 0)
+	})
+	return paginate(rides, query.Page), len(rides), nil
 }
 
 func (r *Ride) FindActiveRideByDriver(ctx context.Context, driverID int) (*entity.Ride, error) {
@@ -105,10 +197,52 @@ func (r *Ride) FindActiveRideByDriver(ctx context.Context, driverID int) (*entit
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
+	ride := r.activeRideByDriver(driverID)
+	if ride == nil {
+		return nil, customErrors.ErrRideNotFound
+	}
+	return cloneRide(ride), nil
+}
+
+func (r *Ride) activeRideByDriver(driverID int) *entity.Ride {
 	for _, ride := range r.rides {
-		if ride.DriverID == driverID && ride.Status != entity.StatusCompleted && ride.Status != entity.StatusCancelled {
-			return ride, nil
+		if ride.DriverID == driverID && !ride.Status.IsTerminal() {
+			return ride
 		}
 	}
-	return nil, customErrors.ErrRideNotFound
-}
\ No newline at end of file
+	return nil
+}
+
+// cloneRide copies a ride so callers never share memory with the store. The
+// passenger and driver are filled in by the service and are not stored.
+func cloneRide(ride *entity.Ride) *entity.Ride {
+	copied := *ride
+	copied.Passenger = nil
+	copied.Driver = nil
+	return &copied
+}
+
+// ListDueScheduledRides returns the scheduled rides whose pickup time is at
+// or before dueBy, earliest first.
+func (r *Ride) ListDueScheduledRides(ctx context.Context, dueBy time.Time) ([]*entity.Ride, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	rides := make([]*entity.Ride, 0)
+	for _, ride := range r.rides {
+		if ride.Status == entity.StatusScheduled && ride.ScheduledAt != nil && !ride.ScheduledAt.After(dueBy) {
+			rides = append(rides, cloneRide(ride))
+		}
+	}
+	slices.SortFunc(rides, func(a, b *entity.Ride) int {
+		if c := a.ScheduledAt.Compare(*b.ScheduledAt); c != 0 {
+			return c
+		}
+		return a.RideID - b.RideID
+	})
+	return rides, nil
+}
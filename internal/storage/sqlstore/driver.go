@@ -0,0 +1,127 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+const driverColumns = `driver_id, first_name, last_name, phone_number, is_online, is_available, car_type, license_plate,
+	rating, rating_count, password_hash`
+
+type Driver struct {
+	db *sql.DB
+}
+
+func NewDriver(db *sql.DB) *Driver {
+	return &Driver{db: db}
+}
+
+// RegisterDriver stores the driver unless another one has the same phone
+// number, in which case it returns ErrPhoneNumberExists.
+func (d *Driver) RegisterDriver(ctx context.Context, driver *entity.Driver) (*entity.Driver, error) {
+	res, err := conn(ctx, d.db).ExecContext(ctx,
+		`INSERT INTO drivers (first_name, last_name, phone_number, is_online, is_available, car_type, license_plate, password_hash)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (phone_number) DO NOTHING`,
+		driver.FirstName, driver.LastName, driver.PhoneNumber, driver.IsOnline, driver.IsAvailable, driver.CarType, driver.LicensePlate,
+		driver.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireAffected(res, customErrors.ErrPhoneNumberExists); err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	driver.DriverID = int(id)
+	return driver, nil
+}
+
+func (d *Driver) GetDriverByID(ctx context.Context, id int) (*entity.Driver, error) {
+	row := conn(ctx, d.db).QueryRowContext(ctx, `SELECT `+driverColumns+` FROM drivers WHERE driver_id = ?`, id)
+	return scanDriver(row)
+}
+
+func (d *Driver) ListDrivers(ctx context.Context, query entity.DriverQuery) ([]*entity.Driver, int, error) {
+	w := &where{}
+	if query.IsAvailable != nil {
+		w.add("is_available = ?", *query.IsAvailable)
+	}
+	if query.CarType != "" {
+		w.add("car_type = ?", query.CarType)
+	}
+
+	total, err := count(ctx, d.db, "drivers", w)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := conn(ctx, d.db).QueryContext(ctx,
+		`SELECT `+driverColumns+` FROM drivers`+w.String()+orderBy(query.Sort, entity.DriverSortFields, "driver_id")+limitOffset(query.Page),
+		w.args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	drivers := make([]*entity.Driver, 0)
+	for rows.Next() {
+		driver, err := scanDriver(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		drivers = append(drivers, driver)
+	}
+	return drivers, total, rows.Err()
+}
+
+func (d *Driver) DeleteDriver(ctx context.Context, id int) error {
+	res, err := conn(ctx, d.db).ExecContext(ctx, `DELETE FROM drivers WHERE driver_id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, customErrors.ErrDriverNotFound)
+}
+
+func (d *Driver) UpdateDriverAvailability(ctx context.Context, id int, online, available bool) error {
+	res, err := conn(ctx, d.db).ExecContext(ctx,
+		`UPDATE drivers SET is_online = ?, is_available = ? WHERE driver_id = ?`, online, available, id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, customErrors.ErrDriverNotFound)
+}
+
+func (d *Driver) FindByPhoneNumber(ctx context.Context, phone string) (*entity.Driver, error) {
+	row := conn(ctx, d.db).QueryRowContext(ctx, `SELECT `+driverColumns+` FROM drivers WHERE phone_number = ?`, phone)
+	return scanDriver(row)
+}
+
+// AddDriverRating folds score into the driver's average rating.
+func (d *Driver) AddDriverRating(ctx context.Context, id int, score int) error {
+	res, err := conn(ctx, d.db).ExecContext(ctx,
+		`UPDATE drivers SET rating = (rating * rating_count + ?) / (rating_count + 1), rating_count = rating_count + 1
+			WHERE driver_id = ?`, score, id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, customErrors.ErrDriverNotFound)
+}
+
+func scanDriver(row scanner) (*entity.Driver, error) {
+	var driver entity.Driver
+	err := row.Scan(&driver.DriverID, &driver.FirstName, &driver.LastName, &driver.PhoneNumber,
+		&driver.IsOnline, &driver.IsAvailable, &driver.CarType, &driver.LicensePlate,
+		&driver.Rating, &driver.RatingCount, &driver.PasswordHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, customErrors.ErrDriverNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &driver, nil
+}
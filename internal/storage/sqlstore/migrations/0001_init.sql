@@ -0,0 +1,27 @@
+CREATE TABLE passengers (
+    passenger_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    first_name   TEXT    NOT NULL,
+    last_name    TEXT    NOT NULL,
+    phone_number INTEGER NOT NULL
+);
+
+CREATE TABLE drivers (
+    driver_id     INTEGER PRIMARY KEY AUTOINCREMENT,
+    first_name    TEXT    NOT NULL,
+    last_name     TEXT    NOT NULL,
+    phone_number  INTEGER NOT NULL,
+    is_available  BOOLEAN NOT NULL DEFAULT 1,
+    car_type      TEXT    NOT NULL,
+    license_plate INTEGER NOT NULL
+);
+
+CREATE TABLE rides (
+    ride_id      INTEGER PRIMARY KEY AUTOINCREMENT,
+    passenger_id INTEGER NOT NULL,
+    driver_id    INTEGER NOT NULL DEFAULT 0,
+    origin       TEXT    NOT NULL,
+    destination  TEXT    NOT NULL,
+    status       TEXT    NOT NULL
+);
+
+CREATE INDEX rides_driver_id ON rides (driver_id);
@@ -0,0 +1,6 @@
+ALTER TABLE rides ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX rides_status ON rides (status);
+CREATE INDEX rides_passenger_id ON rides (passenger_id);
+CREATE INDEX rides_created_at ON rides (created_at);
+CREATE INDEX drivers_car_type ON drivers (car_type);
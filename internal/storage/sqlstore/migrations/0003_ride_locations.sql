@@ -0,0 +1,5 @@
+ALTER TABLE rides ADD COLUMN origin_lat REAL NOT NULL DEFAULT 0;
+ALTER TABLE rides ADD COLUMN origin_lng REAL NOT NULL DEFAULT 0;
+ALTER TABLE rides ADD COLUMN destination_lat REAL NOT NULL DEFAULT 0;
+ALTER TABLE rides ADD COLUMN destination_lng REAL NOT NULL DEFAULT 0;
+ALTER TABLE rides ADD COLUMN distance_km REAL NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE rides ADD COLUMN car_type TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,13 @@
+CREATE TABLE offers (
+    offer_id     INTEGER PRIMARY KEY AUTOINCREMENT,
+    ride_id      INTEGER NOT NULL,
+    driver_id    INTEGER NOT NULL,
+    distance_km  REAL    NOT NULL DEFAULT 0,
+    status       TEXT    NOT NULL,
+    created_at   INTEGER NOT NULL,
+    expires_at   INTEGER NOT NULL,
+    responded_at INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX offers_ride_id ON offers (ride_id);
+CREATE INDEX offers_driver_id_status ON offers (driver_id, status);
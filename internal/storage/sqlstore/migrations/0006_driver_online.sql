@@ -0,0 +1,8 @@
+ALTER TABLE drivers ADD COLUMN is_online BOOLEAN NOT NULL DEFAULT 1;
+
+-- Drivers used to stay available forever; recompute it from their rides.
+UPDATE drivers SET is_available = NOT EXISTS (
+    SELECT 1 FROM rides
+    WHERE rides.driver_id = drivers.driver_id
+      AND rides.status NOT IN ('completed', 'cancelled', 'no_show')
+);
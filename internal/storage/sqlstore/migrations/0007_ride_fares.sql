@@ -0,0 +1,5 @@
+ALTER TABLE rides ADD COLUMN currency TEXT NOT NULL DEFAULT '';
+ALTER TABLE rides ADD COLUMN quoted_fare INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE rides ADD COLUMN final_fare INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE rides ADD COLUMN started_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE rides ADD COLUMN completed_at INTEGER NOT NULL DEFAULT 0;
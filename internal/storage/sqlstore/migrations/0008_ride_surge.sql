@@ -0,0 +1 @@
+ALTER TABLE rides ADD COLUMN surge_multiplier REAL NOT NULL DEFAULT 0;
@@ -0,0 +1,14 @@
+ALTER TABLE rides ADD COLUMN accepted_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE rides ADD COLUMN cancelled_at INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE ride_history (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    ride_id     INTEGER NOT NULL,
+    from_status TEXT    NOT NULL DEFAULT '',
+    to_status   TEXT    NOT NULL,
+    actor       TEXT    NOT NULL,
+    reason      TEXT    NOT NULL DEFAULT '',
+    changed_at  INTEGER NOT NULL
+);
+
+CREATE INDEX ride_history_ride_id ON ride_history (ride_id);
@@ -0,0 +1,3 @@
+ALTER TABLE rides ADD COLUMN cancellation_fee INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE rides ADD COLUMN cancelled_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE rides ADD COLUMN cancel_reason TEXT NOT NULL DEFAULT '';
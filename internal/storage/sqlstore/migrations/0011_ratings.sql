@@ -0,0 +1,13 @@
+ALTER TABLE drivers ADD COLUMN rating REAL NOT NULL DEFAULT 0;
+ALTER TABLE drivers ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE passengers ADD COLUMN rating REAL NOT NULL DEFAULT 0;
+ALTER TABLE passengers ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE ratings (
+    ride_id    INTEGER NOT NULL,
+    actor      TEXT    NOT NULL,
+    score      INTEGER NOT NULL,
+    comment    TEXT    NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (ride_id, actor)
+);
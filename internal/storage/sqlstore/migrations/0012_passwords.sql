@@ -0,0 +1,2 @@
+ALTER TABLE passengers ADD COLUMN password_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE drivers ADD COLUMN password_hash TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,23 @@
+CREATE TABLE webhooks (
+    webhook_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    url        TEXT    NOT NULL,
+    events     TEXT    NOT NULL,
+    secret     TEXT    NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    delivery_id     INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id      INTEGER NOT NULL,
+    event_id        INTEGER NOT NULL,
+    event_type      TEXT    NOT NULL,
+    ride_id         INTEGER NOT NULL,
+    attempt         INTEGER NOT NULL,
+    succeeded       BOOLEAN NOT NULL,
+    status_code     INTEGER NOT NULL DEFAULT 0,
+    error           TEXT    NOT NULL DEFAULT '',
+    attempted_at    INTEGER NOT NULL,
+    next_attempt_at INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id);
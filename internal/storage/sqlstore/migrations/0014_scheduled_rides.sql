@@ -0,0 +1,3 @@
+ALTER TABLE rides ADD COLUMN scheduled_at INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX rides_status_scheduled_at ON rides (status, scheduled_at);
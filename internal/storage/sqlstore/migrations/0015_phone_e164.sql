@@ -0,0 +1,12 @@
+-- Phone numbers become E.164 text such as '+14155550100'. The old integers
+-- are kept as digits; NormalizePhoneNumbers adds their country code on the
+-- next start, as only the server knows the region they are from.
+ALTER TABLE passengers RENAME COLUMN phone_number TO phone_number_int;
+ALTER TABLE passengers ADD COLUMN phone_number TEXT NOT NULL DEFAULT '';
+UPDATE passengers SET phone_number = CAST(phone_number_int AS TEXT);
+ALTER TABLE passengers DROP COLUMN phone_number_int;
+
+ALTER TABLE drivers RENAME COLUMN phone_number TO phone_number_int;
+ALTER TABLE drivers ADD COLUMN phone_number TEXT NOT NULL DEFAULT '';
+UPDATE drivers SET phone_number = CAST(phone_number_int AS TEXT);
+ALTER TABLE drivers DROP COLUMN phone_number_int;
@@ -0,0 +1,16 @@
+-- Phone numbers identify accounts at login, so they must be unique. An
+-- account that shares its number with an older one gets '#dup' and its ID
+-- appended, which no login matches, until the number is fixed by hand.
+UPDATE passengers SET phone_number = phone_number || '#dup' || passenger_id
+WHERE EXISTS (
+    SELECT 1 FROM passengers AS older
+    WHERE older.phone_number = passengers.phone_number AND older.passenger_id < passengers.passenger_id
+);
+CREATE UNIQUE INDEX passengers_phone_number ON passengers (phone_number);
+
+UPDATE drivers SET phone_number = phone_number || '#dup' || driver_id
+WHERE EXISTS (
+    SELECT 1 FROM drivers AS older
+    WHERE older.phone_number = drivers.phone_number AND older.driver_id < drivers.driver_id
+);
+CREATE UNIQUE INDEX drivers_phone_number ON drivers (phone_number);
@@ -0,0 +1,128 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"time"
+)
+
+const offerColumns = `offer_id, ride_id, driver_id, distance_km, status, created_at, expires_at, responded_at`
+
+type Offer struct {
+	db *sql.DB
+}
+
+func NewOffer(db *sql.DB) *Offer {
+	return &Offer{db: db}
+}
+
+// SaveOffer stores a new pending offer. It returns ErrOfferAlreadyPending,
+// and stores nothing, if the ride or the driver already has a pending offer
+// that has not expired by the new offer's CreatedAt. The check and the
+// insert are one statement, so two offers cannot both get in.
+func (o *Offer) SaveOffer(ctx context.Context, offer *entity.Offer) error {
+	var respondedAt int64
+	if offer.RespondedAt != nil {
+		respondedAt = toUnixNano(*offer.RespondedAt)
+	}
+	createdAt := toUnixNano(offer.CreatedAt)
+	res, err := conn(ctx, o.db).ExecContext(ctx,
+		`INSERT INTO offers (ride_id, driver_id, distance_km, status, created_at, expires_at, responded_at)
+		 SELECT ?, ?, ?, ?, ?, ?, ?
+		 WHERE NOT EXISTS (
+		     SELECT 1 FROM offers
+		     WHERE (ride_id = ? OR driver_id = ?) AND status = ? AND expires_at > ?
+		 )`,
+		offer.RideID, offer.DriverID, offer.DistanceKm, offer.Status,
+		createdAt, toUnixNano(offer.ExpiresAt), respondedAt,
+		offer.RideID, offer.DriverID, entity.OfferPending, createdAt)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(res, customErrors.ErrOfferAlreadyPending); err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	offer.OfferID = int(id)
+	return nil
+}
+
+func (o *Offer) FindOfferByID(ctx context.Context, id int) (*entity.Offer, error) {
+	row := conn(ctx, o.db).QueryRowContext(ctx, `SELECT `+offerColumns+` FROM offers WHERE offer_id = ?`, id)
+	return scanOffer(row)
+}
+
+// UpdateOfferStatus moves the offer from one status to another and fails
+// with ErrOfferNotPending if it is no longer in from.
+func (o *Offer) UpdateOfferStatus(ctx context.Context, offerID int, from, to entity.OfferStatus, at time.Time) error {
+	res, err := conn(ctx, o.db).ExecContext(ctx,
+		`UPDATE offers SET status = ?, responded_at = ? WHERE offer_id = ? AND status = ?`,
+		to, toUnixNano(at), offerID, from)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(res, customErrors.ErrOfferNotPending); err != nil {
+		if _, findErr := o.FindOfferByID(ctx, offerID); findErr != nil {
+			return findErr
+		}
+		return err
+	}
+	return nil
+}
+
+func (o *Offer) ListOffersByRide(ctx context.Context, rideID int) ([]*entity.Offer, error) {
+	return o.list(ctx, `WHERE ride_id = ?`, rideID)
+}
+
+func (o *Offer) ListOffersByDriver(ctx context.Context, driverID int, status entity.OfferStatus) ([]*entity.Offer, error) {
+	if status == "" {
+		return o.list(ctx, `WHERE driver_id = ?`, driverID)
+	}
+	return o.list(ctx, `WHERE driver_id = ? AND status = ?`, driverID, status)
+}
+
+func (o *Offer) list(ctx context.Context, where string, args ...any) ([]*entity.Offer, error) {
+	rows, err := conn(ctx, o.db).QueryContext(ctx, `SELECT `+offerColumns+` FROM offers `+where+` ORDER BY offer_id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	offers := make([]*entity.Offer, 0)
+	for rows.Next() {
+		offer, err := scanOffer(rows)
+		if err != nil {
+			return nil, err
+		}
+		offers = append(offers, offer)
+	}
+	return offers, rows.Err()
+}
+
+func scanOffer(row scanner) (*entity.Offer, error) {
+	var (
+		offer                             entity.Offer
+		createdAt, expiresAt, respondedAt int64
+	)
+	err := row.Scan(&offer.OfferID, &offer.RideID, &offer.DriverID, &offer.DistanceKm, &offer.Status,
+		&createdAt, &expiresAt, &respondedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, customErrors.ErrOfferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	offer.CreatedAt = fromUnixNano(createdAt)
+	offer.ExpiresAt = fromUnixNano(expiresAt)
+	if respondedAt != 0 {
+		t := fromUnixNano(respondedAt)
+		offer.RespondedAt = &t
+	}
+	return &offer, nil
+}
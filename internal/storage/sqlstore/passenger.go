@@ -0,0 +1,106 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+const passengerColumns = `passenger_id, first_name, last_name, phone_number, rating, rating_count, password_hash`
+
+type Passenger struct {
+	db *sql.DB
+}
+
+func NewPassenger(db *sql.DB) *Passenger {
+	return &Passenger{db: db}
+}
+
+// RegisterPassenger stores the passenger unless another one has the same
+// phone number, in which case it returns ErrPhoneNumberExists.
+func (p *Passenger) RegisterPassenger(ctx context.Context, passenger *entity.Passenger) (*entity.Passenger, error) {
+	res, err := conn(ctx, p.db).ExecContext(ctx,
+		`INSERT INTO passengers (first_name, last_name, phone_number, password_hash) VALUES (?, ?, ?, ?)
+			ON CONFLICT (phone_number) DO NOTHING`,
+		passenger.FirstName, passenger.LastName, passenger.PhoneNumber, passenger.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireAffected(res, customErrors.ErrPhoneNumberExists); err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	passenger.PassengerID = int(id)
+	return passenger, nil
+}
+
+func (p *Passenger) GetPassengerByID(ctx context.Context, id int) (*entity.Passenger, error) {
+	row := conn(ctx, p.db).QueryRowContext(ctx, `SELECT `+passengerColumns+` FROM passengers WHERE passenger_id = ?`, id)
+	return scanPassenger(row)
+}
+
+func (p *Passenger) ListPassengers(ctx context.Context, query entity.PassengerQuery) ([]*entity.Passenger, int, error) {
+	w := &where{}
+	total, err := count(ctx, p.db, "passengers", w)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := conn(ctx, p.db).QueryContext(ctx,
+		`SELECT `+passengerColumns+` FROM passengers`+orderBy(query.Sort, entity.PassengerSortFields, "passenger_id")+limitOffset(query.Page))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	passengers := make([]*entity.Passenger, 0)
+	for rows.Next() {
+		passenger, err := scanPassenger(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		passengers = append(passengers, passenger)
+	}
+	return passengers, total, rows.Err()
+}
+
+func (p *Passenger) DeletePassenger(ctx context.Context, id int) error {
+	res, err := conn(ctx, p.db).ExecContext(ctx, `DELETE FROM passengers WHERE passenger_id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, customErrors.ErrPassengerNotFound)
+}
+
+func (p *Passenger) FindByPhoneNumber(ctx context.Context, phone string) (*entity.Passenger, error) {
+	row := conn(ctx, p.db).QueryRowContext(ctx, `SELECT `+passengerColumns+` FROM passengers WHERE phone_number = ?`, phone)
+	return scanPassenger(row)
+}
+
+// AddPassengerRating folds score into the passenger's average rating.
+func (p *Passenger) AddPassengerRating(ctx context.Context, id int, score int) error {
+	res, err := conn(ctx, p.db).ExecContext(ctx,
+		`UPDATE passengers SET rating = (rating * rating_count + ?) / (rating_count + 1), rating_count = rating_count + 1
+			WHERE passenger_id = ?`, score, id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, customErrors.ErrPassengerNotFound)
+}
+
+func scanPassenger(row scanner) (*entity.Passenger, error) {
+	var passenger entity.Passenger
+	err := row.Scan(&passenger.PassengerID, &passenger.FirstName, &passenger.LastName, &passenger.PhoneNumber,
+		&passenger.Rating, &passenger.RatingCount, &passenger.PasswordHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, customErrors.ErrPassengerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &passenger, nil
+}
@@ -0,0 +1,75 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/phone"
+)
+
+// NormalizePhoneNumbers puts the phone numbers stored before migration
+// 0015, which lack a country code, into E.164 form, reading them as
+// numbers of region, oldest account first. Numbers that do not parse, or
+// that another account already has in E.164 form, are logged and left as
+// they are; they match no login until they are fixed by hand.
+func NormalizePhoneNumbers(ctx context.Context, db *sql.DB, region string) error {
+	return NewTransactor(db).WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, table := range []struct{ name, id string }{
+			{"passengers", "passenger_id"},
+			{"drivers", "driver_id"},
+		} {
+			if err := normalizePhoneNumbers(ctx, conn(ctx, db), table.name, table.id, region); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func normalizePhoneNumbers(ctx context.Context, q querier, table, idColumn, region string) error {
+	rows, err := q.QueryContext(ctx, `SELECT `+idColumn+`, phone_number FROM `+table+` WHERE phone_number NOT LIKE '+%' ORDER BY `+idColumn)
+	if err != nil {
+		return err
+	}
+	type stored struct {
+		id     int
+		number string
+	}
+	var pending []stored
+	for rows.Next() {
+		var s stored
+		if err := rows.Scan(&s.id, &s.number); err != nil {
+			rows.Close()
+			return err
+		}
+		pending = append(pending, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	count := 0
+	for _, s := range pending {
+		normalized, err := phone.Parse(s.number, region)
+		if err != nil {
+			slog.Warn("phone number left as stored", "table", table, "id", s.id, "phone_number", s.number, "err", err)
+			continue
+		}
+		res, err := q.ExecContext(ctx, `UPDATE OR IGNORE `+table+` SET phone_number = ? WHERE `+idColumn+` = ?`, normalized, s.id)
+		if err != nil {
+			return err
+		}
+		if err := requireAffected(res, customErrors.ErrPhoneNumberExists); err != nil {
+			slog.Warn("phone number left as stored", "table", table, "id", s.id, "phone_number", s.number, "err", err)
+			continue
+		}
+		count++
+	}
+	if count > 0 {
+		slog.Info("normalized stored phone numbers", "table", table, "count", count, "region", region)
+	}
+	return nil
+}
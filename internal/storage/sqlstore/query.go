@@ -0,0 +1,94 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"taxiAPI/internal/entity"
+)
+
+type where struct {
+	conds []string
+	args  []any
+}
+
+func (w *where) add(cond string, arg any) {
+	w.conds = append(w.conds, cond)
+	w.args = append(w.args, arg)
+}
+
+func (w *where) String() string {
+	if len(w.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conds, " AND ")
+}
+
+// orderBy only accepts fields from allowed, so user input never reaches the
+// query text; anything else falls back to ordering by ID.
+func orderBy(sort entity.SortOrder, allowed []string, idColumn string) string {
+	dir := "ASC"
+	if sort.Desc {
+		dir = "DESC"
+	}
+	if sort.Field == "" || sort.Field == idColumn || !slices.Contains(allowed, sort.Field) {
+		return fmt.Sprintf(" ORDER BY %s %s", idColumn, dir)
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, %s ASC", sort.Field, dir, idColumn)
+}
+
+func limitOffset(page entity.Page) string {
+	if page.Limit <= 0 {
+		return fmt.Sprintf(" LIMIT -1 OFFSET %d", page.Offset)
+	}
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", page.Limit, page.Offset)
+}
+
+func count(ctx context.Context, db *sql.DB, table string, w *where) (int, error) {
+	var total int
+	err := conn(ctx, db).QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+w.String(), w.args...).Scan(&total)
+	return total, err
+}
+
+// inList renders a "(?, ?, ...)" placeholder list for values.
+func inList[T any](values []T) (string, []any) {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ") + ")", args
+}
+
+func toUnixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnixNano(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
+
+// optionalUnixNano and optionalTime store a missing time as 0.
+func optionalUnixNano(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return toUnixNano(*t)
+}
+
+func optionalTime(n int64) *time.Time {
+	if n == 0 {
+		return nil
+	}
+	t := fromUnixNano(n)
+	return &t
+}
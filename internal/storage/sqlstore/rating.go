@@ -0,0 +1,51 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+type Rating struct {
+	db *sql.DB
+}
+
+func NewRating(db *sql.DB) *Rating {
+	return &Rating{db: db}
+}
+
+// SaveRating stores the rating unless the same side already rated the ride.
+func (r *Rating) SaveRating(ctx context.Context, rating *entity.Rating) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO ratings (ride_id, actor, score, comment, created_at) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (ride_id, actor) DO NOTHING`,
+		rating.RideID, rating.Actor, rating.Score, rating.Comment, toUnixNano(rating.CreatedAt))
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, customErrors.ErrAlreadyRated)
+}
+
+func (r *Rating) ListRatingsByRide(ctx context.Context, rideID int) ([]*entity.Rating, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT ride_id, actor, score, comment, created_at FROM ratings WHERE ride_id = ? ORDER BY created_at`, rideID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ratings := make([]*entity.Rating, 0, 2)
+	for rows.Next() {
+		var (
+			rating    entity.Rating
+			createdAt int64
+		)
+		if err := rows.Scan(&rating.RideID, &rating.Actor, &rating.Score, &rating.Comment, &createdAt); err != nil {
+			return nil, err
+		}
+		rating.CreatedAt = fromUnixNano(createdAt)
+		ratings = append(ratings, &rating)
+	}
+	return ratings, rows.Err()
+}
@@ -0,0 +1,289 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"time"
+)
+
+const rideColumns = `ride_id, passenger_id, driver_id, origin, origin_lat, origin_lng,
+	destination, destination_lat, destination_lng, distance_km, car_type, status, created_at,
+	currency, quoted_fare, surge_multiplier, final_fare, accepted_at, started_at, completed_at, cancelled_at,
+	cancellation_fee, cancelled_by, cancel_reason, scheduled_at`
+
+// scanner is implemented by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+type Ride struct {
+	db *sql.DB
+}
+
+func NewRide(db *sql.DB) *Ride {
+	return &Ride{db: db}
+}
+
+func (r *Ride) SaveRide(ctx context.Context, ride *entity.Ride) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO rides (passenger_id, driver_id, origin, origin_lat, origin_lng,
+			destination, destination_lat, destination_lng, distance_km, car_type, status, created_at,
+			currency, quoted_fare, surge_multiplier, final_fare, accepted_at, started_at, completed_at, cancelled_at,
+			cancellation_fee, cancelled_by, cancel_reason, scheduled_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		ride.PassengerID, ride.DriverID,
+		ride.Origin.Address, ride.Origin.Latitude, ride.Origin.Longitude,
+		ride.Destination.Address, ride.Destination.Latitude, ride.Destination.Longitude,
+		ride.DistanceKm, ride.CarType, ride.Status, toUnixNano(ride.CreatedAt),
+		ride.Currency, ride.QuotedFare, ride.SurgeMultiplier, ride.FinalFare,
+		optionalUnixNano(ride.AcceptedAt), optionalUnixNano(ride.StartedAt),
+		optionalUnixNano(ride.CompletedAt), optionalUnixNano(ride.CancelledAt),
+		ride.CancellationFee, ride.CancelledBy, ride.CancelReason, optionalUnixNano(ride.ScheduledAt))
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	ride.RideID = int(id)
+	return nil
+}
+
+func (r *Ride) FindRideByID(ctx context.Context, id int) (*entity.Ride, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+rideColumns+` FROM rides WHERE ride_id = ?`, id)
+	return scanRide(row)
+}
+
+// UpdateRide stores the ride's status, fares and timestamps. The driver and
+// accepted_at are only ever set through AssignDriverToRide.
+func (r *Ride) UpdateRide(ctx context.Context, ride *entity.Ride) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE rides SET origin = ?, origin_lat = ?, origin_lng = ?, destination = ?, destination_lat = ?, destination_lng = ?,
+			distance_km = ?, car_type = ?, scheduled_at = ?,
+			status = ?, currency = ?, quoted_fare = ?, surge_multiplier = ?, final_fare = ?,
+			started_at = ?, completed_at = ?, cancelled_at = ?, cancellation_fee = ?, cancelled_by = ?, cancel_reason = ?
+			WHERE ride_id = ?`,
+		ride.Origin.Address, ride.Origin.Latitude, ride.Origin.Longitude,
+		ride.Destination.Address, ride.Destination.Latitude, ride.Destination.Longitude,
+		ride.DistanceKm, ride.CarType, optionalUnixNano(ride.ScheduledAt),
+		ride.Status, ride.Currency, ride.QuotedFare, ride.SurgeMultiplier, ride.FinalFare,
+		optionalUnixNano(ride.StartedAt), optionalUnixNano(ride.CompletedAt), optionalUnixNano(ride.CancelledAt),
+		ride.CancellationFee, ride.CancelledBy, ride.CancelReason, ride.RideID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, customErrors.ErrRideNotFound)
+}
+
+// AssignDriverToRide atomically checks that the ride is pending and
+// unassigned and that the driver has no other active ride, then assigns the
+// driver and marks the ride accepted at acceptedAt. The checks and the write
+// are a single UPDATE, so concurrent assignments cannot both succeed.
+func (r *Ride) AssignDriverToRide(ctx context.Context, rideID, driverID int, acceptedAt time.Time) error {
+	terminal, terminalArgs := inList(entity.TerminalStatuses())
+	args := append([]any{driverID, entity.StatusAccepted, toUnixNano(acceptedAt), rideID, entity.StatusPending, driverID}, terminalArgs...)
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE rides SET driver_id = ?, status = ?, accepted_at = ?
+			WHERE ride_id = ? AND driver_id = 0 AND status = ?
+			AND NOT EXISTS (SELECT 1 FROM rides WHERE driver_id = ? AND status NOT IN `+terminal+`)`,
+		args...)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 1 {
+		return err
+	}
+
+	// Nothing was updated; work out which check failed.
+	ride, err := r.FindRideByID(ctx, rideID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case ride.DriverID == driverID:
+		return customErrors.ErrDriverAlreadyAssignedToRide
+	case ride.DriverID != 0:
+		return customErrors.ErrRideAlreadyAssigned
+	case ride.Status != entity.StatusPending:
+		return customErrors.ErrCannotAssignDriverToNonPendingRide
+	}
+	return customErrors.ErrDriverAlreadyOnActiveRide
+}
+
+func (r *Ride) ListRides(ctx context.Context, query entity.RideQuery) ([]*entity.Ride, int, error) {
+	w := &where{}
+	if query.Status != "" {
+		w.add("status = ?", query.Status)
+	}
+	if query.PassengerID != 0 {
+		w.add("passenger_id = ?", query.PassengerID)
+	}
+	if query.DriverID != 0 {
+		w.add("driver_id = ?", query.DriverID)
+	}
+	if !query.CreatedFrom.IsZero() {
+		w.add("created_at >= ?", toUnixNano(query.CreatedFrom))
+	}
+	if !query.CreatedTo.IsZero() {
+		w.add("created_at < ?", toUnixNano(query.CreatedTo))
+	}
+
+	total, err := count(ctx, r.db, "rides", w)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+rideColumns+` FROM rides`+w.String()+orderBy(query.Sort, entity.RideSortFields, "ride_id")+limitOffset(query.Page),
+		w.args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	rides := make([]*entity.Ride, 0)
+	for rows.Next() {
+		ride, err := scanRide(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		rides = append(rides, ride)
+	}
+	return rides, total, rows.Err()
+}
+
+func (r *Ride) FindActiveRideByDriver(ctx context.Context, driverID int) (*entity.Ride, error) {
+	terminal, terminalArgs := inList(entity.TerminalStatuses())
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+rideColumns+` FROM rides WHERE driver_id = ? AND status NOT IN `+terminal+` ORDER BY ride_id LIMIT 1`,
+		append([]any{driverID}, terminalArgs...)...)
+	return scanRide(row)
+}
+
+// UnassignDriverFromRide takes the ride away from its driver before pickup
+// and puts it back to pending.
+func (r *Ride) UnassignDriverFromRide(ctx context.Context, rideID, driverID int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE rides SET driver_id = 0, status = ?, accepted_at = 0
+			WHERE ride_id = ? AND driver_id = ? AND status IN (?, ?)`,
+		entity.StatusPending, rideID, driverID, entity.StatusAccepted, entity.StatusDriverArriving)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 1 {
+		return err
+	}
+
+	ride, err := r.FindRideByID(ctx, rideID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case ride.DriverID == 0:
+		return customErrors.ErrRideNotAssigned
+	case ride.DriverID != driverID:
+		return customErrors.ErrRideAlreadyAssigned
+	}
+	return &customErrors.TransitionError{From: string(ride.Status), To: string(entity.StatusPending)}
+}
+
+func (r *Ride) AppendRideHistory(ctx context.Context, rideID int, change entity.StatusChange) error {
+	if _, err := r.FindRideByID(ctx, rideID); err != nil {
+		return err
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO ride_history (ride_id, from_status, to_status, actor, reason, changed_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		rideID, change.From, change.To, change.Actor, change.Reason, toUnixNano(change.At))
+	return err
+}
+
+// ListRideHistory returns the ride's status changes, oldest first.
+func (r *Ride) ListRideHistory(ctx context.Context, rideID int) ([]entity.StatusChange, error) {
+	if _, err := r.FindRideByID(ctx, rideID); err != nil {
+		return nil, err
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT from_status, to_status, actor, reason, changed_at FROM ride_history WHERE ride_id = ? ORDER BY id`,
+		rideID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]entity.StatusChange, 0)
+	for rows.Next() {
+		var (
+			change    entity.StatusChange
+			changedAt int64
+		)
+		if err := rows.Scan(&change.From, &change.To, &change.Actor, &change.Reason, &changedAt); err != nil {
+			return nil, err
+		}
+		change.At = fromUnixNano(changedAt)
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
+
+// ListDueScheduledRides returns the scheduled rides whose pickup time is at
+// or before dueBy, earliest first.
+func (r *Ride) ListDueScheduledRides(ctx context.Context, dueBy time.Time) ([]*entity.Ride, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+rideColumns+` FROM rides WHERE status = ? AND scheduled_at <= ? ORDER BY scheduled_at, ride_id`,
+		entity.StatusScheduled, toUnixNano(dueBy))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rides := make([]*entity.Ride, 0)
+	for rows.Next() {
+		ride, err := scanRide(rows)
+		if err != nil {
+			return nil, err
+		}
+		rides = append(rides, ride)
+	}
+	return rides, rows.Err()
+}
+
+func scanRide(row scanner) (*entity.Ride, error) {
+	var (
+		ride                                                                    entity.Ride
+		createdAt, acceptedAt, startedAt, completedAt, cancelledAt, scheduledAt int64
+	)
+	err := row.Scan(&ride.RideID, &ride.PassengerID, &ride.DriverID,
+		&ride.Origin.Address, &ride.Origin.Latitude, &ride.Origin.Longitude,
+		&ride.Destination.Address, &ride.Destination.Latitude, &ride.Destination.Longitude,
+		&ride.DistanceKm, &ride.CarType, &ride.Status, &createdAt,
+		&ride.Currency, &ride.QuotedFare, &ride.SurgeMultiplier, &ride.FinalFare,
+		&acceptedAt, &startedAt, &completedAt, &cancelledAt,
+		&ride.CancellationFee, &ride.CancelledBy, &ride.CancelReason, &scheduledAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, customErrors.ErrRideNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	ride.CreatedAt = fromUnixNano(createdAt)
+	ride.AcceptedAt = optionalTime(acceptedAt)
+	ride.StartedAt = optionalTime(startedAt)
+	ride.CompletedAt = optionalTime(completedAt)
+	ride.CancelledAt = optionalTime(cancelledAt)
+	ride.ScheduledAt = optionalTime(scheduledAt)
+	return &ride, nil
+}
+
+func requireAffected(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,602 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Open(context.Background(), filepath.Join(t.TempDir(), "taxi.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	db := openTestDB(t)
+	if err := Migrate(context.Background(), db); err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+}
+
+func TestPassengerStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewPassenger(openTestDB(t))
+
+	created, err := store.RegisterPassenger(ctx, &entity.Passenger{FirstName: "John", LastName: "Doe", PhoneNumber: "+14155550100"})
+	if err != nil {
+		t.Fatalf("RegisterPassenger: %v", err)
+	}
+	if created.PassengerID == 0 {
+		t.Fatal("expected an ID to be assigned")
+	}
+
+	got, err := store.GetPassengerByID(ctx, created.PassengerID)
+	if err != nil {
+		t.Fatalf("GetPassengerByID: %v", err)
+	}
+	if *got != *created {
+		t.Fatalf("got %+v, want %+v", got, created)
+	}
+	if _, err := store.FindByPhoneNumber(ctx, "+14155550100"); err != nil {
+		t.Fatalf("FindByPhoneNumber: %v", err)
+	}
+
+	if err := store.DeletePassenger(ctx, created.PassengerID); err != nil {
+		t.Fatalf("DeletePassenger: %v", err)
+	}
+	if err := store.DeletePassenger(ctx, created.PassengerID); !errors.Is(err, customErrors.ErrPassengerNotFound) {
+		t.Fatalf("second DeletePassenger: got %v, want ErrPassengerNotFound", err)
+	}
+	if _, err := store.GetPassengerByID(ctx, created.PassengerID); !errors.Is(err, customErrors.ErrPassengerNotFound) {
+		t.Fatalf("GetPassengerByID after delete: got %v, want ErrPassengerNotFound", err)
+	}
+}
+
+func TestDriverStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewDriver(openTestDB(t))
+
+	for _, phone := range []string{"+14155550111", "+14155550222"} {
+		driver := &entity.Driver{FirstName: "Alex", LastName: "Smith", PhoneNumber: phone, IsAvailable: true, CarType: "Toyota Prius", LicensePlate: 123456}
+		if _, err := store.RegisterDriver(ctx, driver); err != nil {
+			t.Fatalf("RegisterDriver: %v", err)
+		}
+	}
+
+	drivers, total, err := store.ListDrivers(ctx, entity.DriverQuery{})
+	if err != nil {
+		t.Fatalf("ListDrivers: %v", err)
+	}
+	if total != 2 || len(drivers) != 2 || drivers[0].DriverID != 1 || drivers[1].DriverID != 2 {
+		t.Fatalf("unexpected drivers: %+v", drivers)
+	}
+	if !drivers[0].IsAvailable {
+		t.Fatal("expected IsAvailable to round-trip")
+	}
+	found, err := store.FindByPhoneNumber(ctx, "+14155550222")
+	if err != nil || found.DriverID != 2 {
+		t.Fatalf("FindByPhoneNumber: got %+v, %v", found, err)
+	}
+	if _, err := store.FindByPhoneNumber(ctx, "+14155550333"); !errors.Is(err, customErrors.ErrDriverNotFound) {
+		t.Fatalf("FindByPhoneNumber unknown: got %v, want ErrDriverNotFound", err)
+	}
+}
+
+func TestRideStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewRide(openTestDB(t))
+
+	ride := &entity.Ride{PassengerID: 1, Origin: entity.Location{Address: "Tel Aviv"}, Destination: entity.Location{Address: "Jerusalem"}, Status: entity.StatusPending}
+	if err := store.SaveRide(ctx, ride); err != nil {
+		t.Fatalf("SaveRide: %v", err)
+	}
+	if err := store.AssignDriverToRide(ctx, ride.RideID, 7, time.Now()); err != nil {
+		t.Fatalf("AssignDriverToRide: %v", err)
+	}
+
+	active, err := store.FindActiveRideByDriver(ctx, 7)
+	if err != nil {
+		t.Fatalf("FindActiveRideByDriver: %v", err)
+	}
+	if active.RideID != ride.RideID || active.Status != entity.StatusAccepted {
+		t.Fatalf("unexpected active ride: %+v", active)
+	}
+
+	ride.Status = entity.StatusCancelled
+	ride.Currency, ride.QuotedFare = "ILS", 4550
+	if err := store.UpdateRide(ctx, ride); err != nil {
+		t.Fatalf("UpdateRide: %v", err)
+	}
+	got, err := store.FindRideByID(ctx, ride.RideID)
+	if err != nil {
+		t.Fatalf("FindRideByID: %v", err)
+	}
+	if got.AcceptedAt == nil || got.DriverID != 7 || got.Status != entity.StatusCancelled || got.QuotedFare != 4550 || got.StartedAt != nil {
+		t.Fatalf("unexpected ride after update: %+v", got)
+	}
+	if _, err := store.FindActiveRideByDriver(ctx, 7); !errors.Is(err, customErrors.ErrRideNotFound) {
+		t.Fatalf("FindActiveRideByDriver after cancel: got %v, want ErrRideNotFound", err)
+	}
+	if err := store.UpdateRide(ctx, &entity.Ride{RideID: 99}); !errors.Is(err, customErrors.ErrRideNotFound) {
+		t.Fatalf("UpdateRide unknown ride: got %v, want ErrRideNotFound", err)
+	}
+
+	changes := []entity.StatusChange{
+		{To: entity.StatusPending, Actor: entity.ActorPassenger, At: time.Unix(1, 0).UTC()},
+		{From: entity.StatusPending, To: entity.StatusCancelled, Actor: entity.ActorSystem, Reason: "timeout", At: time.Unix(2, 0).UTC()},
+	}
+	for _, change := range changes {
+		if err := store.AppendRideHistory(ctx, ride.RideID, change); err != nil {
+			t.Fatalf("AppendRideHistory: %v", err)
+		}
+	}
+	history, err := store.ListRideHistory(ctx, ride.RideID)
+	if err != nil {
+		t.Fatalf("ListRideHistory: %v", err)
+	}
+	if !reflect.DeepEqual(history, changes) {
+		t.Fatalf("ListRideHistory = %+v, want %+v", history, changes)
+	}
+	if err := store.AppendRideHistory(ctx, 99, changes[0]); !errors.Is(err, customErrors.ErrRideNotFound) {
+		t.Fatalf("AppendRideHistory unknown ride: got %v, want ErrRideNotFound", err)
+	}
+}
+
+func TestRatingStore(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	ratings, drivers := NewRating(db), NewDriver(db)
+
+	driver, err := drivers.RegisterDriver(ctx, &entity.Driver{FirstName: "Alex", LastName: "Smith", PhoneNumber: "+14155550111", CarType: "Toyota Prius", LicensePlate: 1})
+	if err != nil {
+		t.Fatalf("RegisterDriver: %v", err)
+	}
+	for _, score := range []int{5, 4, 2} {
+		if err := drivers.AddDriverRating(ctx, driver.DriverID, score); err != nil {
+			t.Fatalf("AddDriverRating: %v", err)
+		}
+	}
+	got, err := drivers.GetDriverByID(ctx, driver.DriverID)
+	if err != nil {
+		t.Fatalf("GetDriverByID: %v", err)
+	}
+	if got.RatingCount != 3 || got.Rating < 3.66 || got.Rating > 3.67 {
+		t.Fatalf("average rating = %v over %d, want 3.67 over 3", got.Rating, got.RatingCount)
+	}
+	if err := drivers.AddDriverRating(ctx, 99, 5); !errors.Is(err, customErrors.ErrDriverNotFound) {
+		t.Fatalf("AddDriverRating unknown driver: got %v, want ErrDriverNotFound", err)
+	}
+
+	rating := &entity.Rating{RideID: 1, Actor: entity.ActorPassenger, Score: 5, Comment: "great", CreatedAt: time.Unix(1, 0).UTC()}
+	if err := ratings.SaveRating(ctx, rating); err != nil {
+		t.Fatalf("SaveRating: %v", err)
+	}
+	if err := ratings.SaveRating(ctx, rating); !errors.Is(err, customErrors.ErrAlreadyRated) {
+		t.Fatalf("SaveRating twice: got %v, want ErrAlreadyRated", err)
+	}
+	if err := ratings.SaveRating(ctx, &entity.Rating{RideID: 1, Actor: entity.ActorDriver, Score: 3, CreatedAt: time.Unix(2, 0).UTC()}); err != nil {
+		t.Fatalf("SaveRating other side: %v", err)
+	}
+	list, err := ratings.ListRatingsByRide(ctx, 1)
+	if err != nil {
+		t.Fatalf("ListRatingsByRide: %v", err)
+	}
+	if len(list) != 2 || !reflect.DeepEqual(list[0], rating) || list[1].Actor != entity.ActorDriver {
+		t.Fatalf("unexpected ratings: %+v", list)
+	}
+}
+
+func TestWebhookStore(t *testing.T) {
+	ctx := context.Background()
+	webhooks := NewWebhook(openTestDB(t))
+
+	webhook := &entity.Webhook{
+		URL:       "https://billing.example.com/hooks",
+		Events:    []entity.RideEventType{entity.EventRideCreated, entity.EventRideStatusChanged},
+		Secret:    "s3cret",
+		CreatedAt: time.Unix(1, 0).UTC(),
+	}
+	if err := webhooks.SaveWebhook(ctx, webhook); err != nil {
+		t.Fatalf("SaveWebhook: %v", err)
+	}
+	got, err := webhooks.FindWebhookByID(ctx, webhook.WebhookID)
+	if err != nil {
+		t.Fatalf("FindWebhookByID: %v", err)
+	}
+	if !reflect.DeepEqual(got, webhook) {
+		t.Fatalf("FindWebhookByID = %+v, want %+v", got, webhook)
+	}
+
+	next := time.Unix(3, 0).UTC()
+	deliveries := []*entity.WebhookDelivery{
+		{WebhookID: webhook.WebhookID, EventID: 1792311942438689, EventType: entity.EventRideCreated, RideID: 7, Attempt: 1,
+			StatusCode: 500, Error: "receiver responded 500", AttemptedAt: time.Unix(2, 0).UTC(), NextAttemptAt: &next},
+		{WebhookID: webhook.WebhookID, EventID: 1792311942438689, EventType: entity.EventRideCreated, RideID: 7, Attempt: 2,
+			Succeeded: true, StatusCode: 200, AttemptedAt: next},
+	}
+	for _, delivery := range deliveries {
+		if err := webhooks.SaveDelivery(ctx, delivery); err != nil {
+			t.Fatalf("SaveDelivery: %v", err)
+		}
+	}
+	log, err := webhooks.ListDeliveries(ctx, webhook.WebhookID)
+	if err != nil {
+		t.Fatalf("ListDeliveries: %v", err)
+	}
+	if !reflect.DeepEqual(log, deliveries) {
+		t.Fatalf("ListDeliveries = %+v, want %+v", log, deliveries)
+	}
+
+	if err := webhooks.DeleteWebhook(ctx, webhook.WebhookID); err != nil {
+		t.Fatalf("DeleteWebhook: %v", err)
+	}
+	if err := webhooks.SaveDelivery(ctx, &entity.WebhookDelivery{WebhookID: webhook.WebhookID, AttemptedAt: next}); !errors.Is(err, customErrors.ErrWebhookNotFound) {
+		t.Fatalf("SaveDelivery after delete: got %v, want ErrWebhookNotFound", err)
+	}
+	if _, err := webhooks.ListDeliveries(ctx, webhook.WebhookID); !errors.Is(err, customErrors.ErrWebhookNotFound) {
+		t.Fatalf("ListDeliveries after delete: got %v, want ErrWebhookNotFound", err)
+	}
+}
+
+func TestListRidesFiltersSortsAndPages(t *testing.T) {
+	ctx := context.Background()
+	store := NewRide(openTestDB(t))
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		ride := &entity.Ride{
+			PassengerID: 1 + i%2,
+			Origin:      entity.Location{Address: "A"},
+			Destination: entity.Location{Address: "B"},
+			Status:      entity.StatusPending,
+			CreatedAt:   base.Add(time.Duration(i) * time.Hour),
+		}
+		if err := store.SaveRide(ctx, ride); err != nil {
+			t.Fatalf("SaveRide: %v", err)
+		}
+	}
+
+	rides, total, err := store.ListRides(ctx, entity.RideQuery{
+		PassengerID: 1,
+		Sort:        entity.SortOrder{Field: "created_at", Desc: true},
+		Page:        entity.Page{Limit: 2},
+	})
+	if err != nil {
+		t.Fatalf("ListRides: %v", err)
+	}
+	if total != 3 || len(rides) != 2 || rides[0].RideID != 5 || rides[1].RideID != 3 {
+		t.Fatalf("unexpected page: total=%d rides=%+v", total, rides)
+	}
+	if !rides[0].CreatedAt.Equal(base.Add(4 * time.Hour)) {
+		t.Fatalf("created_at did not round-trip: %v", rides[0].CreatedAt)
+	}
+
+	rides, total, err = store.ListRides(ctx, entity.RideQuery{
+		CreatedFrom: base.Add(time.Hour),
+		CreatedTo:   base.Add(3 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ListRides: %v", err)
+	}
+	if total != 2 || rides[0].RideID != 2 || rides[1].RideID != 3 {
+		t.Fatalf("unexpected time range result: total=%d rides=%+v", total, rides)
+	}
+}
+
+func TestListDueScheduledRides(t *testing.T) {
+	ctx := context.Background()
+	store := NewRide(openTestDB(t))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(d)
+		return &ts
+	}
+	rides := []*entity.Ride{
+		{PassengerID: 1, Status: entity.StatusScheduled, ScheduledAt: at(10 * time.Minute)},
+		{PassengerID: 1, Status: entity.StatusScheduled, ScheduledAt: at(2 * time.Hour)},
+		{PassengerID: 1, Status: entity.StatusScheduled, ScheduledAt: at(-5 * time.Minute)},
+		{PassengerID: 1, Status: entity.StatusPending},
+	}
+	for _, ride := range rides {
+		if err := store.SaveRide(ctx, ride); err != nil {
+			t.Fatalf("SaveRide: %v", err)
+		}
+	}
+
+	due, err := store.ListDueScheduledRides(ctx, now.Add(15*time.Minute))
+	if err != nil {
+		t.Fatalf("ListDueScheduledRides: %v", err)
+	}
+	if len(due) != 2 || due[0].RideID != rides[2].RideID || due[1].RideID != rides[0].RideID {
+		t.Fatalf("unexpected due rides: %+v", due)
+	}
+	if !due[1].ScheduledAt.Equal(*rides[0].ScheduledAt) {
+		t.Fatalf("scheduled_at = %v, want %v", due[1].ScheduledAt, rides[0].ScheduledAt)
+	}
+
+	rides[1].ScheduledAt = at(5 * time.Minute)
+	rides[1].Destination = entity.Location{Address: "Haifa"}
+	if err := store.UpdateRide(ctx, rides[1]); err != nil {
+		t.Fatalf("UpdateRide: %v", err)
+	}
+	got, err := store.FindRideByID(ctx, rides[1].RideID)
+	if err != nil {
+		t.Fatalf("FindRideByID: %v", err)
+	}
+	if !got.ScheduledAt.Equal(*rides[1].ScheduledAt) || got.Destination.Address != "Haifa" {
+		t.Fatalf("unexpected ride after edit: %+v", got)
+	}
+}
+
+func TestAssignDriverToRideIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	store := NewRide(openTestDB(t))
+
+	const numRides, numDrivers = 10, 4
+	for i := 0; i < numRides; i++ {
+		ride := &entity.Ride{PassengerID: 1, Origin: entity.Location{Address: "A"}, Destination: entity.Location{Address: "B"}, Status: entity.StatusPending}
+		if err := store.SaveRide(ctx, ride); err != nil {
+			t.Fatalf("SaveRide: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 200; i++ {
+		rideID, driverID := 1+i%numRides, 1+(i+i/numRides)%numDrivers
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			store.AssignDriverToRide(ctx, rideID, driverID, time.Now())
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	rides, _, err := store.ListRides(ctx, entity.RideQuery{})
+	if err != nil {
+		t.Fatalf("ListRides: %v", err)
+	}
+	seen := make(map[int]int)
+	for _, ride := range rides {
+		if ride.DriverID != 0 {
+			if ride.Status != entity.StatusAccepted {
+				t.Errorf("ride %d assigned but %q", ride.RideID, ride.Status)
+			}
+			if prev, ok := seen[ride.DriverID]; ok {
+				t.Errorf("driver %d assigned to rides %d and %d", ride.DriverID, prev, ride.RideID)
+			}
+			seen[ride.DriverID] = ride.RideID
+		}
+	}
+
+	if len(seen) != numDrivers {
+		t.Fatalf("expected all %d drivers to be assigned, got %d", numDrivers, len(seen))
+	}
+	for driverID, rideID := range seen {
+		if err := store.AssignDriverToRide(ctx, rideID, 99, time.Now()); !errors.Is(err, customErrors.ErrRideAlreadyAssigned) {
+			t.Errorf("assigning taken ride %d: got %v, want ErrRideAlreadyAssigned", rideID, err)
+		}
+		free := &entity.Ride{PassengerID: 1, Origin: entity.Location{Address: "A"}, Destination: entity.Location{Address: "B"}, Status: entity.StatusPending}
+		if err := store.SaveRide(ctx, free); err != nil {
+			t.Fatalf("SaveRide: %v", err)
+		}
+		if err := store.AssignDriverToRide(ctx, free.RideID, driverID, time.Now()); !errors.Is(err, customErrors.ErrDriverAlreadyOnActiveRide) {
+			t.Errorf("assigning busy driver %d: got %v, want ErrDriverAlreadyOnActiveRide", driverID, err)
+		}
+	}
+}
+
+func TestDataSurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "taxi.db")
+
+	db, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	ride := &entity.Ride{
+		PassengerID: 1,
+		Origin:      entity.Location{Latitude: 32.794, Longitude: 34.9896, Address: "Haifa"},
+		Destination: entity.Location{Latitude: 29.5577, Longitude: 34.9519, Address: "Eilat"},
+		DistanceKm:  360,
+		Status:      entity.StatusPending,
+	}
+	if err := NewRide(db).SaveRide(ctx, ride); err != nil {
+		t.Fatalf("SaveRide: %v", err)
+	}
+	db.Close()
+
+	db, err = Open(ctx, path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer db.Close()
+	got, err := NewRide(db).FindRideByID(ctx, ride.RideID)
+	if err != nil {
+		t.Fatalf("FindRideByID: %v", err)
+	}
+	if got.Origin != ride.Origin || got.Destination != ride.Destination || got.DistanceKm != ride.DistanceKm {
+		t.Fatalf("unexpected ride after reopen: %+v", got)
+	}
+}
+
+func TestPhoneNumbersMigrateToE164(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "taxi.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Build the schema as it was while phone numbers were integers.
+	if _, err := db.ExecContext(ctx, `CREATE TABLE schema_migrations (version TEXT PRIMARY KEY)`); err != nil {
+		t.Fatal(err)
+	}
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		version := strings.TrimSuffix(strings.TrimPrefix(name, "migrations/"), ".sql")
+		if version >= "0015" {
+			break
+		}
+		if err := applyMigration(ctx, db, version, name); err != nil {
+			t.Fatalf("migration %s: %v", version, err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO passengers (first_name, last_name, phone_number) VALUES ('John', 'Doe', 4155550100), ('Jane', 'Doe', 12), ('Jim', 'Doe', 14155550100)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO drivers (first_name, last_name, phone_number, car_type, license_plate) VALUES ('Alex', 'Smith', 14155550111, 'Prius', 1)`); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if err := NormalizePhoneNumbers(ctx, db, "US"); err != nil {
+		t.Fatalf("NormalizePhoneNumbers: %v", err)
+	}
+
+	passengers := NewPassenger(db)
+	if found, err := passengers.FindByPhoneNumber(ctx, "+14155550100"); err != nil || found.PassengerID != 1 {
+		t.Fatalf("FindByPhoneNumber: got %+v, %v", found, err)
+	}
+	// A number that does not parse is kept for an admin to fix.
+	if found, err := passengers.GetPassengerByID(ctx, 2); err != nil || found.PhoneNumber != "12" {
+		t.Fatalf("unparsable number: got %+v, %v", found, err)
+	}
+	// So is one that turns out to be another passenger's.
+	if found, err := passengers.GetPassengerByID(ctx, 3); err != nil || found.PhoneNumber != "14155550100" {
+		t.Fatalf("duplicate number: got %+v, %v", found, err)
+	}
+	if found, err := NewDriver(db).FindByPhoneNumber(ctx, "+14155550111"); err != nil || found.DriverID != 1 {
+		t.Fatalf("driver FindByPhoneNumber: got %+v, %v", found, err)
+	}
+}
+
+func TestRegisterRejectsTakenPhoneNumbers(t *testing.T) {
+	const attempts = 10
+	ctx := context.Background()
+	db := openTestDB(t)
+	passengers, drivers := NewPassenger(db), NewDriver(db)
+
+	var (
+		wg                  sync.WaitGroup
+		mu                  sync.Mutex
+		created, duplicates int
+	)
+	for range attempts {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := passengers.RegisterPassenger(ctx, &entity.Passenger{FirstName: "John", LastName: "Doe", PhoneNumber: "+14155550100"})
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				created++
+			case errors.Is(err, customErrors.ErrPhoneNumberExists):
+				duplicates++
+			default:
+				t.Errorf("RegisterPassenger: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := drivers.RegisterDriver(ctx, &entity.Driver{FirstName: "Alex", LastName: "Smith", PhoneNumber: "+14155550100", CarType: "Prius", LicensePlate: 1})
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				created++
+			case errors.Is(err, customErrors.ErrPhoneNumberExists):
+				duplicates++
+			default:
+				t.Errorf("RegisterDriver: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	// One passenger and one driver; the two account types do not share numbers.
+	if created != 2 || duplicates != 2*attempts-2 {
+		t.Fatalf("created %d, rejected %d; want 2 and %d", created, duplicates, 2*attempts-2)
+	}
+}
+
+func TestSaveOfferRejectsSecondPendingOffer(t *testing.T) {
+	ctx := context.Background()
+	store := NewOffer(openTestDB(t))
+	now := time.Now().UTC()
+	offer := func(rideID, driverID int) *entity.Offer {
+		return &entity.Offer{RideID: rideID, DriverID: driverID, Status: entity.OfferPending, CreatedAt: now, ExpiresAt: now.Add(time.Minute)}
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		saved int
+	)
+	for driverID := 1; driverID <= 10; driverID++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := store.SaveOffer(ctx, offer(1, driverID))
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				saved++
+			case !errors.Is(err, customErrors.ErrOfferAlreadyPending):
+				t.Errorf("SaveOffer: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if saved != 1 {
+		t.Fatalf("%d pending offers saved for one ride, want 1", saved)
+	}
+
+	pending, err := store.ListOffersByRide(ctx, 1)
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("ListOffersByRide = %v, %v", pending, err)
+	}
+	if err := store.SaveOffer(ctx, offer(2, pending[0].DriverID)); !errors.Is(err, customErrors.ErrOfferAlreadyPending) {
+		t.Fatalf("second offer to a busy driver: got %v, want ErrOfferAlreadyPending", err)
+	}
+	if err := store.UpdateOfferStatus(ctx, pending[0].OfferID, entity.OfferPending, entity.OfferDeclined, now); err != nil {
+		t.Fatalf("UpdateOfferStatus: %v", err)
+	}
+	if err := store.SaveOffer(ctx, offer(1, 11)); err != nil {
+		t.Fatalf("offer after a decline: %v", err)
+	}
+	expired := offer(3, 12)
+	expired.ExpiresAt = now
+	if err := store.SaveOffer(ctx, expired); err != nil {
+		t.Fatalf("SaveOffer: %v", err)
+	}
+	if err := store.SaveOffer(ctx, offer(3, 13)); err != nil {
+		t.Fatalf("offer after the last one expired: %v", err)
+	}
+}
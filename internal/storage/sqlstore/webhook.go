@@ -0,0 +1,169 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+const (
+	webhookColumns  = `webhook_id, url, events, secret, created_at`
+	deliveryColumns = `delivery_id, webhook_id, event_id, event_type, ride_id, attempt, succeeded, status_code, error,
+	attempted_at, next_attempt_at`
+)
+
+type Webhook struct {
+	db *sql.DB
+}
+
+func NewWebhook(db *sql.DB) *Webhook {
+	return &Webhook{db: db}
+}
+
+func (s *Webhook) SaveWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	res, err := conn(ctx, s.db).ExecContext(ctx,
+		`INSERT INTO webhooks (url, events, secret, created_at) VALUES (?, ?, ?, ?)`,
+		webhook.URL, joinEvents(webhook.Events), webhook.Secret, toUnixNano(webhook.CreatedAt))
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	webhook.WebhookID = int(id)
+	return nil
+}
+
+func (s *Webhook) FindWebhookByID(ctx context.Context, id int) (*entity.Webhook, error) {
+	row := conn(ctx, s.db).QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE webhook_id = ?`, id)
+	return scanWebhook(row)
+}
+
+func (s *Webhook) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	rows, err := conn(ctx, s.db).QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY webhook_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]*entity.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// DeleteWebhook removes the webhook together with its delivery log. Run it
+// in a transaction to remove both atomically.
+func (s *Webhook) DeleteWebhook(ctx context.Context, id int) error {
+	res, err := conn(ctx, s.db).ExecContext(ctx, `DELETE FROM webhooks WHERE webhook_id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(res, customErrors.ErrWebhookNotFound); err != nil {
+		return err
+	}
+	_, err = conn(ctx, s.db).ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE webhook_id = ?`, id)
+	return err
+}
+
+// SaveDelivery appends to the delivery log of the webhook, unless the
+// webhook was deleted in the meantime.
+func (s *Webhook) SaveDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	res, err := conn(ctx, s.db).ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, ride_id, attempt, succeeded, status_code, error,
+			attempted_at, next_attempt_at)
+			SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ? WHERE EXISTS (SELECT 1 FROM webhooks WHERE webhook_id = ?)`,
+		delivery.WebhookID, int64(delivery.EventID), delivery.EventType, delivery.RideID, delivery.Attempt, delivery.Succeeded,
+		delivery.StatusCode, delivery.Error, toUnixNano(delivery.AttemptedAt), optionalUnixNano(delivery.NextAttemptAt),
+		delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(res, customErrors.ErrWebhookNotFound); err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	delivery.DeliveryID = int(id)
+	return nil
+}
+
+// ListDeliveries returns the delivery log of the webhook, oldest first.
+func (s *Webhook) ListDeliveries(ctx context.Context, webhookID int) ([]*entity.WebhookDelivery, error) {
+	if _, err := s.FindWebhookByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	rows, err := conn(ctx, s.db).QueryContext(ctx,
+		`SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE webhook_id = ? ORDER BY delivery_id`, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]*entity.WebhookDelivery, 0)
+	for rows.Next() {
+		var (
+			delivery                   entity.WebhookDelivery
+			eventID                    int64
+			attemptedAt, nextAttemptAt int64
+		)
+		err := rows.Scan(&delivery.DeliveryID, &delivery.WebhookID, &eventID, &delivery.EventType, &delivery.RideID,
+			&delivery.Attempt, &delivery.Succeeded, &delivery.StatusCode, &delivery.Error, &attemptedAt, &nextAttemptAt)
+		if err != nil {
+			return nil, err
+		}
+		delivery.EventID = uint64(eventID)
+		delivery.AttemptedAt = fromUnixNano(attemptedAt)
+		delivery.NextAttemptAt = optionalTime(nextAttemptAt)
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanWebhook(row scanner) (*entity.Webhook, error) {
+	var (
+		webhook   entity.Webhook
+		events    string
+		createdAt int64
+	)
+	err := row.Scan(&webhook.WebhookID, &webhook.URL, &events, &webhook.Secret, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, customErrors.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	webhook.Events = splitEvents(events)
+	webhook.CreatedAt = fromUnixNano(createdAt)
+	return &webhook, nil
+}
+
+// Event types are stored as a comma separated list.
+func joinEvents(events []entity.RideEventType) string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = string(event)
+	}
+	return strings.Join(names, ",")
+}
+
+func splitEvents(s string) []entity.RideEventType {
+	events := make([]entity.RideEventType, 0)
+	for _, name := range strings.Split(s, ",") {
+		if name != "" {
+			events = append(events, entity.RideEventType(name))
+		}
+	}
+	return events
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"sync"
+)
+
+type txKey struct{}
+
+// Transactor serialises transactions over the in-memory stores. The stores
+// cannot roll back, so fn must do its checks before its first write.
+type Transactor struct {
+	mutex sync.Mutex
+}
+
+func NewTransactor() *Transactor {
+	return &Transactor{}
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return fn(context.WithValue(ctx, txKey{}, t))
+}
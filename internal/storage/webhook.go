@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+type Webhook struct {
+	mutex          sync.RWMutex
+	webhooks       map[int]*entity.Webhook
+	deliveries     map[int][]entity.WebhookDelivery
+	nextID         int
+	nextDeliveryID int
+}
+
+func NewWebhook() *Webhook {
+	return &Webhook{
+		webhooks:       make(map[int]*entity.Webhook),
+		deliveries:     make(map[int][]entity.WebhookDelivery),
+		nextID:         1,
+		nextDeliveryID: 1,
+	}
+}
+
+func copyWebhook(w *entity.Webhook) *entity.Webhook {
+	copied := *w
+	copied.Events = slices.Clone(w.Events)
+	return &copied
+}
+
+func (s *Webhook) SaveWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	webhook.WebhookID = s.nextID
+	s.webhooks[webhook.WebhookID] = copyWebhook(webhook)
+	s.nextID++
+	return nil
+}
+
+func (s *Webhook) FindWebhookByID(ctx context.Context, id int) (*entity.Webhook, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	webhook, ok := s.webhooks[id]
+	if !ok {
+		return nil, customErrors.ErrWebhookNotFound
+	}
+	return copyWebhook(webhook), nil
+}
+
+func (s *Webhook) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	webhooks := make([]*entity.Webhook, 0, len(s.webhooks))
+	for _, webhook := range s.webhooks {
+		webhooks = append(webhooks, copyWebhook(webhook))
+	}
+	slices.SortFunc(webhooks, func(a, b *entity.Webhook) int {
+		return a.WebhookID - b.WebhookID
+	})
+	return webhooks, nil
+}
+
+// DeleteWebhook removes the webhook together with its delivery log.
+func (s *Webhook) DeleteWebhook(ctx context.Context, id int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.webhooks[id]; !ok {
+		return customErrors.ErrWebhookNotFound
+	}
+	delete(s.webhooks, id)
+	delete(s.deliveries, id)
+	return nil
+}
+
+func (s *Webhook) SaveDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.webhooks[delivery.WebhookID]; !ok {
+		return customErrors.ErrWebhookNotFound
+	}
+	delivery.DeliveryID = s.nextDeliveryID
+	s.deliveries[delivery.WebhookID] = append(s.deliveries[delivery.WebhookID], *delivery)
+	s.nextDeliveryID++
+	return nil
+}
+
+// ListDeliveries returns the delivery log of the webhook, oldest first.
+func (s *Webhook) ListDeliveries(ctx context.Context, webhookID int) ([]*entity.WebhookDelivery, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if _, ok := s.webhooks[webhookID]; !ok {
+		return nil, customErrors.ErrWebhookNotFound
+	}
+	log := s.deliveries[webhookID]
+	deliveries := make([]*entity.WebhookDelivery, 0, len(log))
+	for _, delivery := range log {
+		copied := delivery
+		deliveries = append(deliveries, &copied)
+	}
+	return deliveries, nil
+}
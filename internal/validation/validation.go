@@ -0,0 +1,165 @@
+// Package validation checks request fields and reports every problem at
+// once, instead of stopping at the first one, as a
+// customErrors.ValidationError.
+package validation
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"taxiAPI/internal/auth"
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+	"taxiAPI/internal/phone"
+)
+
+const (
+	MaxNameLength = 50
+
+	maxLicensePlate = 99_999_999
+	minTripDistance = 0.05 // km; closer points count as the same place
+)
+
+// Validator collects the problems found in a request. The zero value is
+// ready to use.
+type Validator struct {
+	errs []*customErrors.FieldError
+}
+
+type problemsKey struct{}
+
+// WithProblems returns a copy of ctx carrying problems found with a request
+// before it reached the code that validates it, such as fields the request
+// has no room for. A Validator from FromContext starts out with them, so
+// they are reported together with everything else.
+func WithProblems(ctx context.Context, problems []*customErrors.FieldError) context.Context {
+	if len(problems) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, problemsKey{}, problems)
+}
+
+// FromContext returns a Validator holding the problems ctx carries, if any.
+func FromContext(ctx context.Context) Validator {
+	problems, _ := ctx.Value(problemsKey{}).([]*customErrors.FieldError)
+	return Validator{errs: append([]*customErrors.FieldError(nil), problems...)}
+}
+
+// Add records err against field, unless a problem with field is already
+// recorded: a value of the wrong type, say, is not reported as missing too.
+func (v *Validator) Add(field string, err error) {
+	for _, recorded := range v.errs {
+		if recorded.Field == field {
+			return
+		}
+	}
+	v.errs = append(v.errs, &customErrors.FieldError{Field: field, Err: err})
+}
+
+// Check records err against field unless ok, and returns ok.
+func (v *Validator) Check(ok bool, field string, err error) bool {
+	if !ok {
+		v.Add(field, err)
+	}
+	return ok
+}
+
+// Err returns a *customErrors.ValidationError listing every problem
+// recorded, or nil if there were none.
+func (v *Validator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &customErrors.ValidationError{Errors: v.errs}
+}
+
+// Name checks a person's name; required is reported if it is empty.
+func (v *Validator) Name(field, name string, required error) {
+	if v.Check(name != "", field, required) {
+		v.Check(validName(name), field, customErrors.ErrInvalidName)
+	}
+}
+
+func validName(name string) bool {
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return false
+	}
+	for i, r := range name {
+		if i == 0 && !unicode.IsLetter(r) {
+			return false
+		}
+		switch {
+		case unicode.IsLetter(r), unicode.Is(unicode.Mn, r):
+		case r == ' ', r == '-', r == '\'', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// PhoneNumber checks a phone number and returns it in E.164 form, reading
+// numbers without a country code as numbers of region. It returns "" if
+// the number is invalid.
+func (v *Validator) PhoneNumber(field, number, region string) string {
+	if !v.Check(strings.TrimSpace(number) != "", field, customErrors.ErrPhoneNumber) {
+		return ""
+	}
+	normalized, err := phone.Parse(number, region)
+	if !v.Check(err == nil, field, customErrors.ErrInvalidPhoneNumber) {
+		return ""
+	}
+	return normalized
+}
+
+// LicensePlate checks the number on a driver's plate.
+func (v *Validator) LicensePlate(field string, plate int) {
+	if v.Check(plate != 0, field, customErrors.ErrLicensePlateRequired) {
+		v.Check(plate > 0 && plate <= maxLicensePlate, field, customErrors.ErrInvalidLicensePlate)
+	}
+}
+
+// Password checks a new password against the rules auth.HashPassword
+// enforces.
+func (v *Validator) Password(field, password string) {
+	if v.Check(password != "", field, customErrors.ErrPasswordRequired) {
+		v.Check(len(password) >= auth.MinPasswordLength, field, customErrors.ErrPasswordTooShort)
+		v.Check(len(password) <= auth.MaxPasswordLength, field, customErrors.ErrPasswordTooLong)
+	}
+}
+
+// Location checks that loc is set and its coordinates are on the map.
+// It reports whether loc is valid.
+func (v *Validator) Location(field string, loc entity.Location, required error) bool {
+	if !v.Check(!loc.IsZero(), field, required) {
+		return false
+	}
+	lat := v.Check(loc.ValidLatitude(), field+".lat", customErrors.ErrInvalidLatitude)
+	lng := v.Check(loc.ValidLongitude(), field+".lng", customErrors.ErrInvalidLongitude)
+	return lat && lng
+}
+
+// Trip checks the origin and destination of a ride or quote, including
+// that they are not the same place.
+func (v *Validator) Trip(origin, destination entity.Location) {
+	originOK := v.Location("origin", origin, customErrors.ErrOriginRequired)
+	destinationOK := v.Location("destination", destination, customErrors.ErrDestinationRequired)
+	if originOK && destinationOK {
+		v.Check(!samePlace(origin, destination), "destination", customErrors.ErrSameOriginDestination)
+	}
+}
+
+// samePlace reports whether two locations are the same pickup point. A
+// location with coordinates and one with only an address are never the
+// same, as there is no telling where the address is.
+func samePlace(a, b entity.Location) bool {
+	switch {
+	case a.HasCoordinates() && b.HasCoordinates():
+		return a.DistanceKm(b) < minTripDistance
+	case a.HasCoordinates() || b.HasCoordinates():
+		return false
+	}
+	return strings.EqualFold(strings.Join(strings.Fields(a.Address), " "), strings.Join(strings.Fields(b.Address), " "))
+}
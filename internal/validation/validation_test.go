@@ -0,0 +1,126 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"taxiAPI/internal/entity"
+	customErrors "taxiAPI/internal/errors"
+)
+
+func TestValidatorReportsEveryProblem(t *testing.T) {
+	var v Validator
+	v.Name("first_name", "", customErrors.ErrFirstName)
+	v.Name("last_name", "O'Brien-Smith Jr.", customErrors.ErrLastName)
+	v.PhoneNumber("phone_number", "12345", "US")
+	v.LicensePlate("license_plate", -1)
+	v.Password("password", "short")
+
+	var validationErr *customErrors.ValidationError
+	if err := v.Err(); !errors.As(err, &validationErr) {
+		t.Fatalf("Err() = %v, want a *ValidationError", err)
+	}
+	want := []struct {
+		field string
+		err   error
+	}{
+		{"first_name", customErrors.ErrFirstName},
+		{"phone_number", customErrors.ErrInvalidPhoneNumber},
+		{"license_plate", customErrors.ErrInvalidLicensePlate},
+		{"password", customErrors.ErrPasswordTooShort},
+	}
+	if len(validationErr.Errors) != len(want) {
+		t.Fatalf("errors = %v, want %d", validationErr.Errors, len(want))
+	}
+	for i, w := range want {
+		if got := validationErr.Errors[i]; got.Field != w.field || got.Err != w.err {
+			t.Errorf("errors[%d] = %v, want %s: %v", i, got, w.field, w.err)
+		}
+	}
+	if !errors.Is(v.Err(), customErrors.ErrValidation) || !errors.Is(v.Err(), customErrors.ErrPasswordTooShort) {
+		t.Error("a validation error should match ErrValidation and each of its field errors")
+	}
+}
+
+func TestValidatorAcceptsValidInput(t *testing.T) {
+	var v Validator
+	v.Name("first_name", "Zoë", customErrors.ErrFirstName)
+	if got := v.PhoneNumber("phone_number", "0151 1234 5678", "DE"); got != "+4915112345678" {
+		t.Errorf("PhoneNumber = %q, want +4915112345678", got)
+	}
+	v.LicensePlate("license_plate", 1234)
+	v.Password("password", "secret123")
+	v.Trip(entity.Location{Latitude: 52.52, Longitude: 13.405}, entity.Location{Address: "Alexanderplatz 1"})
+	if err := v.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil", err)
+	}
+}
+
+func TestName(t *testing.T) {
+	for name, ok := range map[string]bool{
+		"Anne-Marie":            true,
+		"d'Artagnan":            true,
+		"José":                  true,
+		"John3":                 false,
+		"-John":                 false,
+		"Robert'); DROP TABLE":  false,
+		strings.Repeat("a", 50): true,
+		strings.Repeat("a", 51): false,
+		strings.Repeat("é", 50): true,
+		"Mary\nJane":            false,
+	} {
+		var v Validator
+		v.Name("first_name", name, customErrors.ErrFirstName)
+		if got := v.Err() == nil; got != ok {
+			t.Errorf("Name(%q) valid = %v, want %v", name, got, ok)
+		}
+	}
+}
+
+func TestTripRejectsSamePlace(t *testing.T) {
+	berlin := entity.Location{Latitude: 52.52, Longitude: 13.405}
+	for _, tc := range []struct {
+		name                string
+		origin, destination entity.Location
+		want                []string
+	}{
+		{"same coordinates", berlin, entity.Location{Latitude: 52.5201, Longitude: 13.4051}, []string{"destination"}},
+		{"same address", entity.Location{Address: "Main St 1"}, entity.Location{Address: " main st  1"}, []string{"destination"}},
+		{"both missing", entity.Location{}, entity.Location{}, []string{"origin", "destination"}},
+		{"bad coordinates", entity.Location{Latitude: 91, Longitude: 200}, berlin, []string{"origin.lat", "origin.lng"}},
+		{"different places", berlin, entity.Location{Latitude: 52.50, Longitude: 13.45}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var v Validator
+			v.Trip(tc.origin, tc.destination)
+			var fields []string
+			var validationErr *customErrors.ValidationError
+			if errors.As(v.Err(), &validationErr) {
+				for _, fieldErr := range validationErr.Errors {
+					fields = append(fields, fieldErr.Field)
+				}
+			}
+			if strings.Join(fields, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("fields = %v, want %v", fields, tc.want)
+			}
+		})
+	}
+}
+
+func TestPassword(t *testing.T) {
+	for password, want := range map[string]error{
+		"":                      customErrors.ErrPasswordRequired,
+		"short":                 customErrors.ErrPasswordTooShort,
+		"secret123":             nil,
+		strings.Repeat("a", 72): nil,
+		strings.Repeat("a", 73): customErrors.ErrPasswordTooLong,
+		strings.Repeat("é", 37): customErrors.ErrPasswordTooLong,
+	} {
+		var v Validator
+		v.Password("password", password)
+		if err := v.Err(); !errors.Is(err, want) || (want == nil) != (err == nil) {
+			t.Errorf("Password(%d bytes) = %v, want %v", len(password), err, want)
+		}
+	}
+}
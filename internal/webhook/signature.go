@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	customErrors "taxiAPI/internal/errors"
+	"time"
+)
+
+// SignatureHeader carries the signature of every delivery in the form
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">".
+const SignatureHeader = "X-Taxi-Signature"
+
+// DefaultTolerance is how old a signature Verify accepts, limiting replays.
+const DefaultTolerance = 5 * time.Minute
+
+// Sign returns the SignatureHeader value for body sent at timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := timestamp.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", t, hex.EncodeToString(mac(secret, t, body)))
+}
+
+// Verify checks a SignatureHeader value against the body. Signatures older
+// or newer than tolerance relative to now are rejected.
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var (
+		t         int64
+		signature []byte
+		err       error
+	)
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			if t, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return customErrors.ErrInvalidSignature
+			}
+		case "v1":
+			if signature, err = hex.DecodeString(value); err != nil {
+				return customErrors.ErrInvalidSignature
+			}
+		}
+	}
+	if t == 0 || signature == nil {
+		return customErrors.ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(t, 0)); age > tolerance || age < -tolerance {
+		return customErrors.ErrInvalidSignature
+	}
+	if !hmac.Equal(signature, mac(secret, t, body)) {
+		return customErrors.ErrInvalidSignature
+	}
+	return nil
+}
+
+func mac(secret string, t int64, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(h, "%d.", t)
+	h.Write(body)
+	return h.Sum(nil)
+}